@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/initialization"
+	repoImpl "github.com/tienhai2808/ecom_go/internal/repository/implement"
+	svcImpl "github.com/tienhai2808/ecom_go/internal/service/implement"
+)
+
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	db, err := initialization.InitMySQL(cfg)
+	if err != nil {
+		log.Fatalf("Khởi tạo MySQL thất bại: %v", err)
+	}
+	defer db.Close()
+
+	es, err := initialization.InitElasticsearch(cfg)
+	if err != nil {
+		log.Fatalf("Khởi tạo Elasticsearch thất bại: %v", err)
+	}
+
+	productRepo := repoImpl.NewProductRepository(db.Gorm)
+	productIndexRepo := repoImpl.NewProductIndexRepository(es)
+	productIndexSvc := svcImpl.NewProductIndexService(productRepo, productIndexRepo)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	total, err := productIndexSvc.ReindexProducts(ctx)
+	if err != nil {
+		log.Fatalf("Đánh chỉ mục lại sản phẩm thất bại: %v", err)
+	}
+
+	log.Printf("Đánh chỉ mục lại %d sản phẩm thành công", total)
+}
@@ -11,12 +11,136 @@ const (
 	RoutingKeyImageUpload = "product.image.upload"
 	RoutingKeyImageDelete = "product.image.delete"
 
+	QueueNameReviewImageUpload  = "product.review.image.upload"
+	RoutingKeyReviewImageUpload = "product.review.image.upload"
+
+	QueueNameMediaUpload  = "product.media.upload"
+	RoutingKeyMediaUpload = "product.media.upload"
+
+	QueueNameProductImport  = "product.import.process"
+	ExchangeProductImport   = "product.import"
+	RoutingKeyProductImport = "product.import.process"
+
+	QueueNameProductIndex  = "product.search.index"
+	ExchangeProductSearch  = "product.search"
+	RoutingKeyProductIndex = "product.search.index"
+
 
 
 	GenderMale   = "male"
 	GenderFemale = "female"
 	GenderOther  = "other"
 
-	RoleUser  = "user"
-	RoleAdmin = "admin"
+	RoleUser        = "user"
+	RoleAdmin       = "admin"
+	RoleContributor = "contributor"
+	RoleStaff       = "staff"
+
+	PermUserRead       = "user:read"
+	PermUserWrite      = "user:write"
+	PermProductWrite   = "product:write"
+	PermCategoryWrite  = "category:write"
+	PermOrderRead      = "order:read"
+	PermOrderRefund    = "order:refund"
+	PermRoleManage     = "role:manage"
+	PermReviewModerate = "review:moderate"
+
+	AuditEntityUser = "user"
+
+	AuditActionUserCreate = "user.create"
+	AuditActionUserUpdate = "user.update"
+	AuditActionUserDelete = "user.delete"
+
+	ProductHistoryActionCreate     = "product.create"
+	ProductHistoryActionUpdate     = "product.update"
+	ProductHistoryActionBulkUpdate = "product.bulk_update"
+	ProductHistoryActionImport     = "product.import"
+	ProductHistoryActionRevert     = "product.revert"
+	ProductHistoryActionPublish    = "product.publish"
+	ProductHistoryActionUnpublish  = "product.unpublish"
+	ProductHistoryActionDelete     = "product.delete"
+	ProductHistoryActionRestore    = "product.restore"
+	ProductHistoryActionPurge      = "product.purge"
+	ProductHistoryActionRetryImage = "product.retry_image"
+
+	ProductStatusDraft     = "draft"
+	ProductStatusScheduled = "scheduled"
+	ProductStatusPublished = "published"
+	ProductStatusArchived  = "archived"
+
+	AttributeTypeText    = "text"
+	AttributeTypeNumber  = "number"
+	AttributeTypeEnum    = "enum"
+	AttributeTypeBoolean = "boolean"
+
+	StockStatusInStock    = "in_stock"
+	StockStatusLowStock   = "low_stock"
+	StockStatusOutOfStock = "out_of_stock"
+
+	OrderStatusConfirmed = "confirmed"
+	OrderStatusShipped   = "shipped"
+	OrderStatusDelivered = "delivered"
+
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+
+	StorageDriverCloudinary = "cloudinary"
+	StorageDriverLocal      = "local"
+	StorageDriverS3         = "s3"
+
+	ImageStatusPending = "pending"
+	ImageStatusReady   = "ready"
+	ImageStatusFailed  = "failed"
+
+	UploadStatusPending  = "pending"
+	UploadStatusUploaded = "uploaded"
+	UploadStatusAttached = "attached"
+
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+
+	ImportFormatCSV  = "csv"
+	ImportFormatJSON = "json"
+
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+
+	FeedGoogleMerchant = "google_merchant"
+	FeedSitemap        = "sitemap"
+	FeedSitemapPage    = "sitemap_%d"
+
+	SearchEngineAuto          = "auto"
+	SearchEngineElasticsearch = "elasticsearch"
+	SearchEngineMySQL         = "mysql"
+
+	IndexProducts    = "ecom_go.products"
+	IndexSuggestions = "ecom_go.suggestions"
+
+	SuggestionTypeProduct  = "product"
+	SuggestionTypeCategory = "category"
 )
+
+var AllRoles = []string{RoleUser, RoleAdmin, RoleContributor, RoleStaff}
+
+var AllPermissions = []string{
+	PermUserRead,
+	PermUserWrite,
+	PermProductWrite,
+	PermCategoryWrite,
+	PermOrderRead,
+	PermOrderRefund,
+	PermRoleManage,
+	PermReviewModerate,
+}
+
+var DefaultRolePermissions = map[string][]string{
+	RoleStaff:       {PermUserRead, PermProductWrite, PermCategoryWrite, PermOrderRead, PermOrderRefund, PermReviewModerate},
+	RoleContributor: {PermProductWrite},
+}
+
+var AddedRolePermissions = map[string][]string{
+	RoleStaff: {PermReviewModerate},
+}
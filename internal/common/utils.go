@@ -2,6 +2,8 @@ package common
 
 import (
 	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-sql-driver/mysql"
@@ -21,6 +23,24 @@ func GenerateSlug(str string) string {
 	return slug.Make(str)
 }
 
+func AttributeTerm(attributeID int64, value string) string {
+	return strconv.FormatInt(attributeID, 10) + ":" + value
+}
+
+func ParseAttributeTerm(term string) (int64, string, bool) {
+	idStr, value, ok := strings.Cut(term, ":")
+	if !ok {
+		return 0, "", false
+	}
+
+	attributeID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return attributeID, value, true
+}
+
 func IsUniqueViolation(err error) bool {
 	var mysqlErr *mysql.MySQLError
 	if errors.As(err, &mysqlErr) {
@@ -19,6 +19,24 @@ type Config struct {
 		ApiPrefix   string `yaml:"api_prefix"`
 	} `yaml:"app"`
 
+	Account struct {
+		DeletionGraceDays int `yaml:"deletion_grace_days"`
+	} `yaml:"account"`
+
+	Catalog struct {
+		TrashRetentionDays      int `yaml:"trash_retention_days"`
+		CoPurchaseIntervalHours int `yaml:"co_purchase_interval_hours"`
+		PublishIntervalMinutes  int `yaml:"publish_interval_minutes"`
+	} `yaml:"catalog"`
+
+	Feed struct {
+		SiteURL         string `yaml:"site_url"`
+		Title           string `yaml:"title"`
+		Description     string `yaml:"description"`
+		Currency        string `yaml:"currency"`
+		IntervalMinutes int    `yaml:"interval_minutes"`
+	} `yaml:"feed"`
+
 	Database struct {
 		User     string `yaml:"user"`
 		Password string `yaml:"password"`
@@ -48,6 +66,13 @@ type Config struct {
 		Password  string   `yaml:"password"`
 	} `yaml:"elasticsearch"`
 
+	Search struct {
+		Engine                 string `yaml:"engine"`
+		BreakerThreshold       int    `yaml:"breaker_threshold"`
+		BreakerCooldownSeconds int    `yaml:"breaker_cooldown_seconds"`
+		PrimaryTimeoutMillis   int    `yaml:"primary_timeout_millis"`
+	} `yaml:"search"`
+
 	SMTP struct {
 		Host string `yaml:"host"`
 		Port string `yaml:"port"`
@@ -61,6 +86,30 @@ type Config struct {
 		PrivateKey  string `yaml:"private_key"`
 	} `yaml:"imagekit"`
 
+	Storage struct {
+		Driver              string `yaml:"driver"`
+		Folder              string `yaml:"folder"`
+		MaxUploadBytes      int64  `yaml:"max_upload_bytes"`
+		UploadExpiryMinutes int    `yaml:"upload_expiry_minutes"`
+		OrphanGraceMinutes  int    `yaml:"orphan_grace_minutes"`
+
+		Local struct {
+			Dir       string `yaml:"dir"`
+			BaseURL   string `yaml:"base_url"`
+			ServePath string `yaml:"serve_path"`
+		} `yaml:"local"`
+
+		S3 struct {
+			Endpoint  string `yaml:"endpoint"`
+			Region    string `yaml:"region"`
+			Bucket    string `yaml:"bucket"`
+			AccessKey string `yaml:"access_key"`
+			SecretKey string `yaml:"secret_key"`
+			UseSSL    bool   `yaml:"use_ssl"`
+			PublicURL string `yaml:"public_url"`
+		} `yaml:"s3"`
+	} `yaml:"storage"`
+
 	Cloudinary struct {
 		CloudName string `yaml:"cloud_name"`
 		ApiKey    string `yaml:"api_key"`
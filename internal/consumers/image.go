@@ -1,29 +1,48 @@
 package consumers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
 	"log"
 
-	"github.com/tienhai2808/ecom_go/internal/cloudinary"
 	"github.com/tienhai2808/ecom_go/internal/common"
 	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/imaging"
 	"github.com/tienhai2808/ecom_go/internal/initialization"
+	"github.com/tienhai2808/ecom_go/internal/model"
 	"github.com/tienhai2808/ecom_go/internal/rabbitmq"
 	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/storage"
 	"github.com/tienhai2808/ecom_go/internal/types"
 )
 
-func StartDeleteImageMessage(mqc *initialization.RabbitMQConn, cld cloudinary.CloudinaryService) {
+func StartDeleteImageMessage(mqc *initialization.RabbitMQConn, store storage.Storage, mediaRepo repository.MediaRepository) {
 	if err := rabbitmq.ConsumeMessage(mqc.Chan, common.QueueNameImageDelete, common.ExchangeImage, common.RoutingKeyImageDelete, func(body []byte) error {
 		publicID := string(body)
 		ctx := context.Background()
 
-		if err := cld.DeleteFile(ctx, publicID, "image"); err != nil {
+		referenced, err := mediaRepo.IsKeyReferenced(ctx, publicID)
+		if err != nil {
+			return fmt.Errorf("kiểm tra tham chiếu hình ảnh thất bại: %w", err)
+		}
+		if referenced {
+			log.Printf("Hình ảnh có PublicID: %s vẫn đang được sử dụng, bỏ qua xóa file", publicID)
+			return nil
+		}
+
+		if err := store.Delete(ctx, publicID); err != nil {
 			return fmt.Errorf("xóa file thất bại: %w", err)
 		}
+		for _, variant := range imaging.Variants {
+			if err := store.Delete(ctx, storage.VariantKey(publicID, variant.Name)); err != nil {
+				return fmt.Errorf("xóa file %s thất bại: %w", variant.Name, err)
+			}
+		}
 		log.Printf("Xóa hình ảnh có PublicID: %s thành công", publicID)
 
 		return nil
@@ -32,8 +51,8 @@ func StartDeleteImageMessage(mqc *initialization.RabbitMQConn, cld cloudinary.Cl
 	}
 }
 
-func StartUploadImageMessage(mqc *initialization.RabbitMQConn, cld cloudinary.CloudinaryService, imageRepo repository.ImageRepository) {
-	if err := rabbitmq.ConsumeMessage(mqc.Chan, common.QueueNameImageUpload, common.ExchangeImage, common.RoutingKeyImageUpload, func(body []byte) error {
+func StartUploadImageMessage(mqc *initialization.RabbitMQConn, store storage.Storage, imageRepo repository.ImageRepository, productIndexSvc service.ProductIndexService) {
+	if err := rabbitmq.ConsumeMessageWithFailure(mqc.Chan, common.QueueNameImageUpload, common.ExchangeImage, common.RoutingKeyImageUpload, func(body []byte) error {
 		var msg types.UploadImageMessage
 		if err := json.Unmarshal(body, &msg); err != nil {
 			return fmt.Errorf("chuyển đổi tin nhắn upload ảnh thất bại: %w", err)
@@ -41,16 +60,12 @@ func StartUploadImageMessage(mqc *initialization.RabbitMQConn, cld cloudinary.Cl
 
 		ctx := context.Background()
 
-		res, err := cld.UploadBinaryFile(ctx, msg.FileData, msg.FileName)
+		updateData, err := processImage(ctx, store, msg.Key)
 		if err != nil {
-			return fmt.Errorf("upload ảnh thất bại: %w", err)
-		}
-		log.Printf("Upload ảnh %s lên Cloudinary thành công", res.URL)
-
-		updateData := map[string]any{
-			"public_id": res.PublicID,
-			"url":       res.URL,
+			return fmt.Errorf("xử lý ảnh %s thất bại: %w", msg.Key, err)
 		}
+		log.Printf("Xử lý ảnh %s thành công", msg.Key)
+		updateData["status"] = common.ImageStatusReady
 
 		if err = imageRepo.Update(ctx, msg.ImageID, updateData); err != nil {
 			if errors.Is(err, customErr.ErrImageNotFound) {
@@ -60,8 +75,161 @@ func StartUploadImageMessage(mqc *initialization.RabbitMQConn, cld cloudinary.Cl
 		}
 		log.Printf("Cập nhật ảnh có ID %d thành công", msg.ImageID)
 
+		image, err := imageRepo.FindByID(ctx, msg.ImageID)
+		if err != nil {
+			return fmt.Errorf("lấy thông tin hình ảnh thất bại: %w", err)
+		}
+		if image != nil && image.IsThumbnail {
+			if err = productIndexSvc.SyncProducts(ctx, types.ProductIndexMessage{ProductIDs: []int64{image.ProductID}}); err != nil {
+				log.Printf("Đồng bộ chỉ mục sản phẩm %d thất bại: %v", image.ProductID, err)
+			}
+		}
+
 		return nil
+	}, func(body []byte, cause error) {
+		var msg types.UploadImageMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return
+		}
+
+		if err := imageRepo.Update(context.Background(), msg.ImageID, map[string]any{"status": common.ImageStatusFailed}); err != nil && !errors.Is(err, customErr.ErrImageNotFound) {
+			log.Printf("Cập nhật trạng thái lỗi cho ảnh có ID %d thất bại: %v", msg.ImageID, err)
+			return
+		}
+		log.Printf("Ảnh có ID %d được đánh dấu lỗi: %v", msg.ImageID, cause)
 	}); err != nil {
 		log.Printf("Lỗi khởi tạo upload image consumer: %v", err)
 	}
 }
+
+func StartUploadMediaMessage(mqc *initialization.RabbitMQConn, store storage.Storage, mediaRepo repository.MediaRepository) {
+	if err := rabbitmq.ConsumeMessageWithFailure(mqc.Chan, common.QueueNameMediaUpload, common.ExchangeImage, common.RoutingKeyMediaUpload, func(body []byte) error {
+		var msg types.UploadImageMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return fmt.Errorf("chuyển đổi tin nhắn xử lý ảnh thư viện thất bại: %w", err)
+		}
+
+		ctx := context.Background()
+
+		updateData, err := processImage(ctx, store, msg.Key)
+		if err != nil {
+			return fmt.Errorf("xử lý ảnh %s thất bại: %w", msg.Key, err)
+		}
+		delete(updateData, "public_id")
+		updateData["status"] = common.ImageStatusReady
+
+		if err = mediaRepo.Update(ctx, msg.ImageID, updateData); err != nil {
+			if errors.Is(err, customErr.ErrMediaAssetNotFound) {
+				return err
+			}
+			return fmt.Errorf("cập nhật tài nguyên thư viện ảnh thất bại: %w", err)
+		}
+		log.Printf("Xử lý ảnh thư viện có ID %d thành công", msg.ImageID)
+
+		return nil
+	}, func(body []byte, cause error) {
+		var msg types.UploadImageMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return
+		}
+
+		if err := mediaRepo.Update(context.Background(), msg.ImageID, map[string]any{"status": common.ImageStatusFailed}); err != nil && !errors.Is(err, customErr.ErrMediaAssetNotFound) {
+			log.Printf("Cập nhật trạng thái lỗi cho ảnh thư viện có ID %d thất bại: %v", msg.ImageID, err)
+			return
+		}
+		log.Printf("Ảnh thư viện có ID %d được đánh dấu lỗi: %v", msg.ImageID, cause)
+	}); err != nil {
+		log.Printf("Lỗi khởi tạo upload media consumer: %v", err)
+	}
+}
+
+func StartUploadReviewImageMessage(mqc *initialization.RabbitMQConn, store storage.Storage, reviewRepo repository.ReviewRepository) {
+	if err := rabbitmq.ConsumeMessageWithFailure(mqc.Chan, common.QueueNameReviewImageUpload, common.ExchangeImage, common.RoutingKeyReviewImageUpload, func(body []byte) error {
+		var msg types.UploadImageMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return fmt.Errorf("chuyển đổi tin nhắn upload ảnh đánh giá thất bại: %w", err)
+		}
+
+		ctx := context.Background()
+
+		file, err := store.Open(ctx, msg.Key)
+		if err != nil {
+			return fmt.Errorf("đọc file ảnh đánh giá thất bại: %w", err)
+		}
+		_, _, err = imaging.Inspect(file)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("kiểm tra ảnh đánh giá %s thất bại: %w", msg.Key, err)
+		}
+
+		updateData := map[string]any{
+			"public_id": msg.Key,
+			"url":       store.URL(msg.Key),
+			"status":    common.ImageStatusReady,
+		}
+
+		if err = reviewRepo.UpdateImage(ctx, msg.ImageID, updateData); err != nil {
+			if errors.Is(err, customErr.ErrImageNotFound) {
+				return err
+			}
+			return fmt.Errorf("cập nhật ảnh đánh giá thất bại: %w", err)
+		}
+		log.Printf("Cập nhật ảnh đánh giá có ID %d thành công", msg.ImageID)
+
+		return nil
+	}, func(body []byte, cause error) {
+		var msg types.UploadImageMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return
+		}
+
+		if err := reviewRepo.UpdateImage(context.Background(), msg.ImageID, map[string]any{"status": common.ImageStatusFailed}); err != nil && !errors.Is(err, customErr.ErrImageNotFound) {
+			log.Printf("Cập nhật trạng thái lỗi cho ảnh đánh giá có ID %d thất bại: %v", msg.ImageID, err)
+			return
+		}
+		log.Printf("Ảnh đánh giá có ID %d được đánh dấu lỗi: %v", msg.ImageID, cause)
+	}); err != nil {
+		log.Printf("Lỗi khởi tạo upload review image consumer: %v", err)
+	}
+}
+
+func processImage(ctx context.Context, store storage.Storage, key string) (map[string]any, error) {
+	file, err := store.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, info, err := imaging.Inspect(file)
+	if err != nil {
+		return nil, err
+	}
+
+	src, _, err := image.Decode(content)
+	if err != nil {
+		return nil, fmt.Errorf("giải mã ảnh thất bại: %w", err)
+	}
+
+	srcset := model.ImageSrcset{}
+	for _, variant := range imaging.Variants {
+		var buf bytes.Buffer
+		if err = imaging.EncodeWebP(&buf, imaging.Resize(src, variant.Width)); err != nil {
+			return nil, fmt.Errorf("tạo ảnh %s thất bại: %w", variant.Name, err)
+		}
+
+		variantKey := storage.VariantKey(key, variant.Name)
+		if err = store.Upload(ctx, variantKey, &buf, int64(buf.Len()), "image/webp"); err != nil {
+			return nil, fmt.Errorf("tải ảnh %s lên thất bại: %w", variant.Name, err)
+		}
+		srcset[variant.Name] = store.URL(variantKey)
+	}
+
+	return map[string]any{
+		"public_id":         key,
+		"url":               store.URL(key),
+		"width":             info.Width,
+		"height":            info.Height,
+		"placeholder_color": imaging.PlaceholderColor(src),
+		"srcset":            srcset,
+	}, nil
+}
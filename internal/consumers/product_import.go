@@ -0,0 +1,45 @@
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/initialization"
+	"github.com/tienhai2808/ecom_go/internal/rabbitmq"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+func StartProductImportConsumer(mqc *initialization.RabbitMQConn, productImportSvc service.ProductImportService) {
+	if err := rabbitmq.ConsumeMessageWithFailure(mqc.Chan, common.QueueNameProductImport, common.ExchangeProductImport, common.RoutingKeyProductImport, func(body []byte) error {
+		var msg types.ProductImportMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return fmt.Errorf("chuyển đổi tin nhắn nhập sản phẩm thất bại: %w", err)
+		}
+
+		ctx := context.Background()
+
+		if err := productImportSvc.ProcessImportJob(ctx, msg.JobID); err != nil {
+			return fmt.Errorf("xử lý phiên nhập sản phẩm %d thất bại: %w", msg.JobID, err)
+		}
+		log.Printf("Xử lý phiên nhập sản phẩm %d thành công", msg.JobID)
+
+		return nil
+	}, func(body []byte, cause error) {
+		var msg types.ProductImportMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return
+		}
+
+		if err := productImportSvc.FailImportJob(context.Background(), msg.JobID, cause); err != nil {
+			log.Printf("Cập nhật trạng thái lỗi cho phiên nhập sản phẩm %d thất bại: %v", msg.JobID, err)
+			return
+		}
+		log.Printf("Phiên nhập sản phẩm %d được đánh dấu lỗi: %v", msg.JobID, cause)
+	}); err != nil {
+		log.Printf("Lỗi khởi tạo product import consumer: %v", err)
+	}
+}
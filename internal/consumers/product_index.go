@@ -0,0 +1,34 @@
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/initialization"
+	"github.com/tienhai2808/ecom_go/internal/rabbitmq"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+func StartProductIndexConsumer(mqc *initialization.RabbitMQConn, productIndexSvc service.ProductIndexService) {
+	if err := rabbitmq.ConsumeMessage(mqc.Chan, common.QueueNameProductIndex, common.ExchangeProductSearch, common.RoutingKeyProductIndex, func(body []byte) error {
+		var msg types.ProductIndexMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return fmt.Errorf("chuyển đổi tin nhắn đồng bộ chỉ mục sản phẩm thất bại: %w", err)
+		}
+
+		ctx := context.Background()
+
+		if err := productIndexSvc.SyncProducts(ctx, msg); err != nil {
+			return err
+		}
+		log.Printf("Đồng bộ chỉ mục cho %d sản phẩm, %d danh mục thành công", len(msg.ProductIDs), len(msg.CategoryIDs))
+
+		return nil
+	}); err != nil {
+		log.Printf("Lỗi khởi tạo product index consumer: %v", err)
+	}
+}
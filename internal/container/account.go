@@ -0,0 +1,36 @@
+package container
+
+import (
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/redis/go-redis/v9"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	repoImpl "github.com/tienhai2808/ecom_go/internal/repository/implement"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	svcImpl "github.com/tienhai2808/ecom_go/internal/service/implement"
+	"gorm.io/gorm"
+)
+
+type AccountModule struct {
+	AccountSvc service.AccountService
+	AccountHdl *handler.AccountHandler
+}
+
+func NewAccountContainer(db *gorm.DB, rdb *redis.Client, rabbitChan *amqp091.Channel, cfg *config.Config) *AccountModule {
+	userRepo := repoImpl.NewUserRepository(db)
+	profileRepo := repoImpl.NewProfileRepository(db)
+	addressRepo := repoImpl.NewAddressRepository(db)
+	cartRepo := repoImpl.NewCartRepository(db, rdb, cfg)
+	orderRepo := repoImpl.NewOrderRepository(db)
+	reviewRepo := repoImpl.NewReviewRepository(db)
+	auditLogRepo := repoImpl.NewAuditLogRepository(db)
+	uploadRepo := repoImpl.NewUploadRepository(db)
+	mediaRepo := repoImpl.NewMediaRepository(db)
+	accountSvc := svcImpl.NewAccountService(db, userRepo, profileRepo, addressRepo, cartRepo, orderRepo, reviewRepo, auditLogRepo, uploadRepo, mediaRepo, rabbitChan, cfg)
+	accountHdl := handler.NewAccountHandler(accountSvc, cfg)
+
+	return &AccountModule{
+		accountSvc,
+		accountHdl,
+	}
+}
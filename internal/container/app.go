@@ -1,41 +1,55 @@
 package container
 
 import (
-	"github.com/cloudinary/cloudinary-go/v2"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/rabbitmq/amqp091-go"
 	"github.com/redis/go-redis/v9"
 	"github.com/sony/sonyflake/v2"
-	customCld "github.com/tienhai2808/ecom_go/internal/cloudinary"
 	"github.com/tienhai2808/ecom_go/internal/config"
 	"github.com/tienhai2808/ecom_go/internal/smtp"
 	customSf "github.com/tienhai2808/ecom_go/internal/snowflake"
+	"github.com/tienhai2808/ecom_go/internal/storage"
 	"gorm.io/gorm"
 )
 
 type Container struct {
-	UserModule     *UserModule
-	AuthModule     *AuthModule
-	AddressModule  *AddressModule
-	ProductModule  *ProductModule
-	ProfileModule  *ProfileModule
-	CategoryModule *CategoryModule
-	CartModule     *CartModule
-	SMTPSvc        smtp.SMTPService
-	CloudinarySvc  customCld.CloudinaryService
+	UserModule           *UserModule
+	AuthModule           *AuthModule
+	AddressModule        *AddressModule
+	ProductModule        *ProductModule
+	ProfileModule        *ProfileModule
+	CategoryModule       *CategoryModule
+	CartModule           *CartModule
+	PermissionModule     *PermissionModule
+	AccountModule        *AccountModule
+	ReviewModule         *ReviewModule
+	RecommendationModule *RecommendationModule
+	UploadModule         *UploadModule
+	MediaModule          *MediaModule
+	ProductImportModule  *ProductImportModule
+	CatalogExportModule  *CatalogExportModule
+	SMTPSvc              smtp.SMTPService
+	Storage              storage.Storage
 }
 
-func NewContainer(db *gorm.DB, rdb *redis.Client, cfg *config.Config, rabbitChan *amqp091.Channel, sf *sonyflake.Sonyflake, cld *cloudinary.Cloudinary, es *elasticsearch.TypedClient) *Container {
+func NewContainer(db *gorm.DB, rdb *redis.Client, cfg *config.Config, rabbitChan *amqp091.Channel, sf *sonyflake.Sonyflake, store storage.Storage, es *elasticsearch.TypedClient) *Container {
 	cSfg := customSf.NewSnowflakeGenerator(sf)
 	smtp := smtp.NewSMTPService(cfg)
-	cCld := customCld.NewCloudinaryService(cld)
 	userModule := NewUserContainer(db, cSfg)
 	authModule := NewAuthContainer(rdb, cfg, db, rabbitChan, cSfg)
 	addressModule := NewAddressContainer(db, cSfg)
-	productModule := NewProductContainer(db, rabbitChan, cSfg, es)
+	productModule := NewProductContainer(db, rabbitChan, cSfg, es, cfg)
 	profileModule := NewProfileContainer(db)
-	categoryModule := NewCategoryContainer(db, cSfg)
-	cartModule := NewCartModule(db, cSfg, es, cfg, rdb)
+	categoryModule := NewCategoryContainer(db, rabbitChan, cSfg, es)
+	recommendationModule := NewRecommendationContainer(db, es)
+	cartModule := NewCartModule(db, cSfg, cfg, rdb, recommendationModule.RecommendationSvc)
+	permissionModule := NewPermissionContainer(db)
+	accountModule := NewAccountContainer(db, rdb, rabbitChan, cfg)
+	reviewModule := NewReviewContainer(db, rabbitChan, cSfg)
+	uploadModule := NewUploadContainer(db, store, cfg, cSfg)
+	mediaModule := NewMediaContainer(db, rabbitChan, cSfg)
+	productImportModule := NewProductImportContainer(db, rabbitChan, cSfg, es, store, cfg)
+	catalogExportModule := NewCatalogExportContainer(db, rdb, cfg)
 
 	return &Container{
 		userModule,
@@ -45,7 +59,15 @@ func NewContainer(db *gorm.DB, rdb *redis.Client, cfg *config.Config, rabbitChan
 		profileModule,
 		categoryModule,
 		cartModule,
+		permissionModule,
+		accountModule,
+		reviewModule,
+		recommendationModule,
+		uploadModule,
+		mediaModule,
+		productImportModule,
+		catalogExportModule,
 		smtp,
-		cCld,
+		store,
 	}
 }
@@ -19,8 +19,9 @@ func NewAuthContainer(rdb *redis.Client, cfg *config.Config, db *gorm.DB, rabbit
 	authRepo := repoImpl.NewAuthRepository(rdb, cfg)
 	userRepo := repoImpl.NewUserRepository(db)
 	profileRepo := repoImpl.NewProfileRepository(db)
+	auditLogRepo := repoImpl.NewAuditLogRepository(db)
 	authSvc := svcImpl.NewAuthService(userRepo, authRepo, profileRepo, rabbitChan, cfg, sfg)
-	userSvc := svcImpl.NewUserService(userRepo, profileRepo, sfg)
+	userSvc := svcImpl.NewUserService(db, userRepo, profileRepo, auditLogRepo, sfg)
 	authHandler := handler.NewAuthHandler(authSvc, userSvc, cfg)
 
 	return &AuthModule{authHandler}
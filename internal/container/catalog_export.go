@@ -0,0 +1,29 @@
+package container
+
+import (
+	"github.com/redis/go-redis/v9"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	repoImpl "github.com/tienhai2808/ecom_go/internal/repository/implement"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	svcImpl "github.com/tienhai2808/ecom_go/internal/service/implement"
+	"gorm.io/gorm"
+)
+
+type CatalogExportModule struct {
+	CatalogExportSvc service.CatalogExportService
+	CatalogExportHdl *handler.CatalogExportHandler
+}
+
+func NewCatalogExportContainer(db *gorm.DB, rdb *redis.Client, cfg *config.Config) *CatalogExportModule {
+	productRepo := repoImpl.NewProductRepository(db)
+	categoryRepo := repoImpl.NewCategoryRepository(db)
+	feedRepo := repoImpl.NewFeedRepository(rdb, cfg)
+	catalogExportSvc := svcImpl.NewCatalogExportService(productRepo, categoryRepo, feedRepo, cfg)
+	catalogExportHdl := handler.NewCatalogExportHandler(catalogExportSvc)
+
+	return &CatalogExportModule{
+		catalogExportSvc,
+		catalogExportHdl,
+	}
+}
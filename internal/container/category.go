@@ -1,21 +1,31 @@
 package container
 
 import (
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/rabbitmq/amqp091-go"
 	"github.com/tienhai2808/ecom_go/internal/handler"
 	repoImpl "github.com/tienhai2808/ecom_go/internal/repository/implement"
+	"github.com/tienhai2808/ecom_go/internal/service"
 	svcImpl "github.com/tienhai2808/ecom_go/internal/service/implement"
 	"github.com/tienhai2808/ecom_go/internal/snowflake"
 	"gorm.io/gorm"
 )
 
 type CategoryModule struct {
+	CategorySvc service.CategoryService
 	CategoryHdl *handler.CategoryHandler
 }
 
-func NewCategoryContainer(db *gorm.DB, sfg snowflake.SnowflakeGenerator) *CategoryModule {
+func NewCategoryContainer(db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator, es *elasticsearch.TypedClient) *CategoryModule {
 	categoryRepo := repoImpl.NewCategoryRepository(db)
-	categorySvc := svcImpl.NewCategoryService(categoryRepo, sfg)
+	attributeRepo := repoImpl.NewAttributeRepository(db)
+	suggestionRepo := repoImpl.NewSuggestionRepository(es)
+	mediaRepo := repoImpl.NewMediaRepository(db)
+	categorySvc := svcImpl.NewCategoryService(categoryRepo, attributeRepo, suggestionRepo, mediaRepo, db, rabbitChan, sfg)
 	categoryHdl := handler.NewCategoryHandler(categorySvc)
 
-	return &CategoryModule{categoryHdl}
+	return &CategoryModule{
+		categorySvc,
+		categoryHdl,
+	}
 }
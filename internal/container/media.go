@@ -0,0 +1,31 @@
+package container
+
+import (
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	repoImpl "github.com/tienhai2808/ecom_go/internal/repository/implement"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	svcImpl "github.com/tienhai2808/ecom_go/internal/service/implement"
+	"github.com/tienhai2808/ecom_go/internal/snowflake"
+	"gorm.io/gorm"
+)
+
+type MediaModule struct {
+	MediaSvc  service.MediaService
+	MediaHdl  *handler.MediaHandler
+	MediaRepo repository.MediaRepository
+}
+
+func NewMediaContainer(db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator) *MediaModule {
+	mediaRepo := repoImpl.NewMediaRepository(db)
+	uploadRepo := repoImpl.NewUploadRepository(db)
+	mediaSvc := svcImpl.NewMediaService(mediaRepo, uploadRepo, db, rabbitChan, sfg)
+	mediaHdl := handler.NewMediaHandler(mediaSvc)
+
+	return &MediaModule{
+		mediaSvc,
+		mediaHdl,
+		mediaRepo,
+	}
+}
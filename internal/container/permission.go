@@ -0,0 +1,25 @@
+package container
+
+import (
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	repoImpl "github.com/tienhai2808/ecom_go/internal/repository/implement"
+	svcImpl "github.com/tienhai2808/ecom_go/internal/service/implement"
+	"gorm.io/gorm"
+)
+
+type PermissionModule struct {
+	PermissionRepo repository.PermissionRepository
+	PermissionHdl  *handler.PermissionHandler
+}
+
+func NewPermissionContainer(db *gorm.DB) *PermissionModule {
+	permissionRepo := repoImpl.NewPermissionRepository(db)
+	permissionSvc := svcImpl.NewPermissionService(permissionRepo)
+	permissionHdl := handler.NewPermissionHandler(permissionSvc)
+
+	return &PermissionModule{
+		permissionRepo,
+		permissionHdl,
+	}
+}
@@ -1,31 +1,66 @@
 package container
 
 import (
+	"time"
+
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/rabbitmq/amqp091-go"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
 	"github.com/tienhai2808/ecom_go/internal/handler"
 	"github.com/tienhai2808/ecom_go/internal/repository"
 	repoImpl "github.com/tienhai2808/ecom_go/internal/repository/implement"
+	"github.com/tienhai2808/ecom_go/internal/service"
 	svcImpl "github.com/tienhai2808/ecom_go/internal/service/implement"
 	"github.com/tienhai2808/ecom_go/internal/snowflake"
 	"gorm.io/gorm"
 )
 
 type ProductModule struct {
-	ProductHdl *handler.ProductHandler
-	ImageRepo  repository.ImageRepository
+	ProductSvc      service.ProductService
+	ProductIndexSvc service.ProductIndexService
+	ProductHdl      *handler.ProductHandler
+	ImageRepo       repository.ImageRepository
 }
 
-func NewProductContainer(db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator, es *elasticsearch.TypedClient) *ProductModule {
-	productRepo := repoImpl.NewProductRepository(db, es)
+func NewProductContainer(db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator, es *elasticsearch.TypedClient, cfg *config.Config) *ProductModule {
+	productRepo := repoImpl.NewProductRepository(db)
+	searcher := newProductSearcher(db, es, cfg)
 	categoryRepo := repoImpl.NewCategoryRepository(db)
 	inventoryRepo := repoImpl.NewInventoryRepository(db)
 	imageRepo := repoImpl.NewImageRepository(db)
-	productSvc := svcImpl.NewProductService(productRepo, categoryRepo, inventoryRepo, imageRepo, db, rabbitChan, sfg)
+	variantRepo := repoImpl.NewVariantRepository(db)
+	attributeRepo := repoImpl.NewAttributeRepository(db)
+	suggestionRepo := repoImpl.NewSuggestionRepository(es)
+	uploadRepo := repoImpl.NewUploadRepository(db)
+	mediaRepo := repoImpl.NewMediaRepository(db)
+	historyRepo := repoImpl.NewProductHistoryRepository(db)
+	productSvc := svcImpl.NewProductService(productRepo, searcher, categoryRepo, inventoryRepo, imageRepo, variantRepo, attributeRepo, suggestionRepo, uploadRepo, mediaRepo, historyRepo, db, rabbitChan, sfg)
+	productIndexRepo := repoImpl.NewProductIndexRepository(es)
+	productIndexSvc := svcImpl.NewProductIndexService(productRepo, productIndexRepo)
 	productHdl := handler.NewProductHandler(productSvc)
 
 	return &ProductModule{
+		productSvc,
+		productIndexSvc,
 		productHdl,
 		imageRepo,
 	}
 }
+
+func newProductSearcher(db *gorm.DB, es *elasticsearch.TypedClient, cfg *config.Config) repository.ProductSearcher {
+	mysqlSearcher := repoImpl.NewMySQLProductSearcher(db)
+	if es == nil {
+		return mysqlSearcher
+	}
+
+	esSearcher := repoImpl.NewESProductSearcher(es)
+	switch cfg.Search.Engine {
+	case common.SearchEngineMySQL:
+		return mysqlSearcher
+	case common.SearchEngineElasticsearch:
+		return esSearcher
+	}
+
+	return repoImpl.NewFallbackProductSearcher(esSearcher, mysqlSearcher, cfg.Search.BreakerThreshold, time.Duration(cfg.Search.BreakerCooldownSeconds)*time.Second, time.Duration(cfg.Search.PrimaryTimeoutMillis)*time.Millisecond)
+}
@@ -0,0 +1,37 @@
+package container
+
+import (
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	repoImpl "github.com/tienhai2808/ecom_go/internal/repository/implement"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	svcImpl "github.com/tienhai2808/ecom_go/internal/service/implement"
+	"github.com/tienhai2808/ecom_go/internal/snowflake"
+	"github.com/tienhai2808/ecom_go/internal/storage"
+	"gorm.io/gorm"
+)
+
+type ProductImportModule struct {
+	ProductImportSvc service.ProductImportService
+	ProductImportHdl *handler.ProductImportHandler
+}
+
+func NewProductImportContainer(db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator, es *elasticsearch.TypedClient, store storage.Storage, cfg *config.Config) *ProductImportModule {
+	importRepo := repoImpl.NewProductImportRepository(db)
+	productRepo := repoImpl.NewProductRepository(db)
+	categoryRepo := repoImpl.NewCategoryRepository(db)
+	inventoryRepo := repoImpl.NewInventoryRepository(db)
+	imageRepo := repoImpl.NewImageRepository(db)
+	attributeRepo := repoImpl.NewAttributeRepository(db)
+	historyRepo := repoImpl.NewProductHistoryRepository(db)
+	suggestionRepo := repoImpl.NewSuggestionRepository(es)
+	productImportSvc := svcImpl.NewProductImportService(importRepo, productRepo, categoryRepo, inventoryRepo, imageRepo, attributeRepo, historyRepo, suggestionRepo, store, cfg, db, rabbitChan, sfg)
+	productImportHdl := handler.NewProductImportHandler(productImportSvc)
+
+	return &ProductImportModule{
+		productImportSvc,
+		productImportHdl,
+	}
+}
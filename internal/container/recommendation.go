@@ -0,0 +1,28 @@
+package container
+
+import (
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	repoImpl "github.com/tienhai2808/ecom_go/internal/repository/implement"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	svcImpl "github.com/tienhai2808/ecom_go/internal/service/implement"
+	"gorm.io/gorm"
+)
+
+type RecommendationModule struct {
+	RecommendationSvc service.RecommendationService
+	RecommendationHdl *handler.RecommendationHandler
+}
+
+func NewRecommendationContainer(db *gorm.DB, es *elasticsearch.TypedClient) *RecommendationModule {
+	productRepo := repoImpl.NewProductRepository(db)
+	productIndexRepo := repoImpl.NewProductIndexRepository(es)
+	coPurchaseRepo := repoImpl.NewCoPurchaseRepository(db)
+	recommendationSvc := svcImpl.NewRecommendationService(productRepo, productIndexRepo, coPurchaseRepo, db)
+	recommendationHdl := handler.NewRecommendationHandler(recommendationSvc)
+
+	return &RecommendationModule{
+		recommendationSvc,
+		recommendationHdl,
+	}
+}
@@ -0,0 +1,33 @@
+package container
+
+import (
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	repoImpl "github.com/tienhai2808/ecom_go/internal/repository/implement"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	svcImpl "github.com/tienhai2808/ecom_go/internal/service/implement"
+	"github.com/tienhai2808/ecom_go/internal/snowflake"
+	"gorm.io/gorm"
+)
+
+type ReviewModule struct {
+	ReviewSvc  service.ReviewService
+	ReviewHdl  *handler.ReviewHandler
+	ReviewRepo repository.ReviewRepository
+}
+
+func NewReviewContainer(db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator) *ReviewModule {
+	reviewRepo := repoImpl.NewReviewRepository(db)
+	productRepo := repoImpl.NewProductRepository(db)
+	orderRepo := repoImpl.NewOrderRepository(db)
+	uploadRepo := repoImpl.NewUploadRepository(db)
+	reviewSvc := svcImpl.NewReviewService(reviewRepo, productRepo, orderRepo, uploadRepo, db, rabbitChan, sfg)
+	reviewHdl := handler.NewReviewHandler(reviewSvc)
+
+	return &ReviewModule{
+		reviewSvc,
+		reviewHdl,
+		reviewRepo,
+	}
+}
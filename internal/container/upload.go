@@ -0,0 +1,28 @@
+package container
+
+import (
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	repoImpl "github.com/tienhai2808/ecom_go/internal/repository/implement"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	svcImpl "github.com/tienhai2808/ecom_go/internal/service/implement"
+	"github.com/tienhai2808/ecom_go/internal/snowflake"
+	"github.com/tienhai2808/ecom_go/internal/storage"
+	"gorm.io/gorm"
+)
+
+type UploadModule struct {
+	UploadSvc service.UploadService
+	UploadHdl *handler.UploadHandler
+}
+
+func NewUploadContainer(db *gorm.DB, store storage.Storage, cfg *config.Config, sfg snowflake.SnowflakeGenerator) *UploadModule {
+	uploadRepo := repoImpl.NewUploadRepository(db)
+	uploadSvc := svcImpl.NewUploadService(uploadRepo, store, cfg, sfg)
+	uploadHdl := handler.NewUploadHandler(uploadSvc)
+
+	return &UploadModule{
+		uploadSvc,
+		uploadHdl,
+	}
+}
@@ -18,7 +18,8 @@ type UserModule struct {
 func NewUserContainer(db *gorm.DB, sfg snowflake.SnowflakeGenerator) *UserModule {
 	userRepo := repoImpl.NewUserRepository(db)
 	profileRepo := repoImpl.NewProfileRepository(db)
-	userSvc := svcImpl.NewUserService(userRepo, profileRepo, sfg)
+	auditLogRepo := repoImpl.NewAuditLogRepository(db)
+	userSvc := svcImpl.NewUserService(db, userRepo, profileRepo, auditLogRepo, sfg)
 	userHdl := handler.NewUserHandler(userSvc)
 
 	return &UserModule{
@@ -0,0 +1,17 @@
+package errors
+
+import "errors"
+
+var (
+	ErrAttributeNotFound = errors.New("không tìm thấy thuộc tính của danh mục sản phẩm")
+
+	ErrHasAttributeNotFound = errors.New("có thuộc tính không thuộc danh mục của sản phẩm")
+
+	ErrAttributeNameAlreadyExists = errors.New("tên thuộc tính đã tồn tại trong danh mục sản phẩm")
+
+	ErrAttributeOptionsRequired = errors.New("thuộc tính dạng lựa chọn phải có danh sách giá trị")
+
+	ErrInvalidAttributeValue = errors.New("giá trị thuộc tính sản phẩm không hợp lệ")
+
+	ErrAttributeValueRequired = errors.New("thiếu giá trị cho thuộc tính bắt buộc của sản phẩm")
+)
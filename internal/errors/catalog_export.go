@@ -0,0 +1,9 @@
+package errors
+
+import "errors"
+
+var (
+	ErrFeedNotFound = errors.New("không tìm thấy nguồn dữ liệu")
+
+	ErrFeedNotGenerated = errors.New("nguồn dữ liệu chưa được tạo, vui lòng thử lại sau")
+)
@@ -8,4 +8,10 @@ var (
 	ErrCategorySlugAlreadyExists = errors.New("slug của danh mục sản phẩm đã tồn tại")
 
 	ErrHasCategoryNotFound = errors.New("có danh mục sản phẩm không tìm thấy")
+
+	ErrCategoryParentNotFound = errors.New("không tìm thấy danh mục cha")
+
+	ErrInvalidCategoryParent = errors.New("không thể chuyển danh mục vào chính nó hoặc danh mục con của nó")
+
+	ErrCategoryHasChildren = errors.New("danh mục sản phẩm vẫn còn danh mục con")
 )
\ No newline at end of file
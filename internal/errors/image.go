@@ -6,4 +6,10 @@ var (
 	ErrImageNotFound = errors.New("không tìm thấy hình ảnh")
 
 	ErrHasImageNotFound = errors.New("có hình ảnh không tìm thấy")
+
+	ErrInvalidImage = errors.New("file không phải là hình ảnh hợp lệ")
+
+	ErrInvalidImageDimension = errors.New("kích thước hình ảnh không nằm trong giới hạn cho phép")
+
+	ErrImageTypeMismatch = errors.New("định dạng hình ảnh không khớp với phiên tải lên")
 )
\ No newline at end of file
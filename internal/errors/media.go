@@ -0,0 +1,13 @@
+package errors
+
+import "errors"
+
+var (
+	ErrMediaAssetNotFound = errors.New("không tìm thấy tài nguyên trong thư viện ảnh")
+
+	ErrHasMediaAssetNotFound = errors.New("có tài nguyên không tồn tại trong thư viện ảnh")
+
+	ErrMediaAssetNotReady = errors.New("có tài nguyên trong thư viện ảnh chưa xử lý xong")
+
+	ErrMediaAssetInUse = errors.New("tài nguyên đang được sử dụng bởi sản phẩm hoặc danh mục")
+)
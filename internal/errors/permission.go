@@ -0,0 +1,11 @@
+package errors
+
+import "errors"
+
+var (
+	ErrInvalidRole = errors.New("vai trò không hợp lệ")
+
+	ErrInvalidPermission = errors.New("quyền hạn không hợp lệ")
+
+	ErrAdminRoleImmutable = errors.New("không thể thay đổi quyền hạn của vai trò admin")
+)
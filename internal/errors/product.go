@@ -10,4 +10,28 @@ var (
 	ErrProductSlugAlreadyExists = errors.New("slug của sản phẩm đã tồn tại")
 
 	ErrHasProductNotFound = errors.New("có sản phẩm không tìm thấy")
+
+	ErrInvalidPriceRange = errors.New("khoảng giá không hợp lệ")
+
+	ErrBulkTargetConflict = errors.New("chỉ được chọn sản phẩm theo danh sách ID hoặc theo bộ lọc")
+
+	ErrEmptyBulkFilter = errors.New("bộ lọc sản phẩm phải có ít nhất một điều kiện")
+
+	ErrEmptyBulkChanges = errors.New("phải có ít nhất một thay đổi cho sản phẩm")
+
+	ErrBulkTooManyProducts = errors.New("số lượng sản phẩm cần cập nhật vượt quá giới hạn cho phép")
+
+	ErrBulkUpdateHasConflicts = errors.New("có sản phẩm không thể áp dụng thay đổi")
+
+	ErrBulkPriceProductHasVariants = errors.New("sản phẩm có biến thể, không thể điều chỉnh giá trực tiếp")
+
+	ErrProductHistoryNotFound = errors.New("không tìm thấy phiên bản trong lịch sử sản phẩm")
+
+	ErrRevertImagesChanged = errors.New("hình ảnh sản phẩm đã được thêm hoặc xóa so với phiên bản cần khôi phục, vui lòng cập nhật hình ảnh thủ công")
+
+	ErrInvalidPublishTime = errors.New("thời gian đăng bán không hợp lệ")
+
+	ErrPublishAtRequired = errors.New("sản phẩm hẹn giờ đăng bán phải có thời gian đăng bán ở tương lai")
+
+	ErrInvalidUnpublishTime = errors.New("thời gian ngừng bán phải sau thời gian đăng bán và ở tương lai")
 )
@@ -0,0 +1,27 @@
+package errors
+
+import "errors"
+
+var (
+	ErrImportJobNotFound = errors.New("không tìm thấy phiên nhập sản phẩm")
+
+	ErrUnsupportedImportFormat = errors.New("chỉ hỗ trợ nhập sản phẩm từ file CSV hoặc JSON")
+
+	ErrInvalidImportFile = errors.New("file nhập sản phẩm không đúng định dạng")
+
+	ErrMissingImportColumn = errors.New("file nhập sản phẩm phải có các cột name, category_slug, price, description")
+
+	ErrImportFileTooLarge = errors.New("file nhập sản phẩm vượt quá dung lượng cho phép")
+
+	ErrImportTooManyRows = errors.New("file nhập sản phẩm vượt quá số dòng cho phép")
+
+	ErrEmptyImportFile = errors.New("file nhập sản phẩm không có dữ liệu")
+
+	ErrImportProductHasVariants = errors.New("sản phẩm có biến thể, không thể cập nhật số lượng trực tiếp")
+
+	ErrImportQuantityBelowPurchased = errors.New("số lượng nhỏ hơn số lượng đã bán")
+
+	ErrImportImageURLNotAllowed = errors.New("URL ảnh không hợp lệ hoặc trỏ tới địa chỉ nội bộ")
+
+	ErrImportTooManyRedirects = errors.New("URL ảnh chuyển hướng quá nhiều lần")
+)
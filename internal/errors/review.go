@@ -0,0 +1,11 @@
+package errors
+
+import "errors"
+
+var (
+	ErrReviewNotFound = errors.New("không tìm thấy đánh giá")
+
+	ErrReviewAlreadyExists = errors.New("bạn đã đánh giá sản phẩm này")
+
+	ErrReviewNotAllowed = errors.New("chỉ khách hàng đã nhận được sản phẩm mới có thể đánh giá")
+)
@@ -0,0 +1,9 @@
+package errors
+
+import "errors"
+
+var (
+	ErrSearchUnavailable = errors.New("dịch vụ tìm kiếm tạm thời không khả dụng")
+
+	ErrReindexInProgress = errors.New("chỉ mục sản phẩm đang được xây dựng lại")
+)
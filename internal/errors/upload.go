@@ -0,0 +1,15 @@
+package errors
+
+import "errors"
+
+var (
+	ErrUploadNotFound = errors.New("không tìm thấy phiên tải lên")
+
+	ErrUploadExpired = errors.New("phiên tải lên đã hết hạn")
+
+	ErrUploadNotReady = errors.New("có file chưa được tải lên hoặc đã được sử dụng")
+
+	ErrUploadTooLarge = errors.New("file vượt quá dung lượng cho phép")
+
+	ErrUploadSizeMismatch = errors.New("kích thước file không khớp với phiên tải lên")
+)
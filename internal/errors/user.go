@@ -22,4 +22,14 @@ var (
 	ErrUserRoleNotFound = errors.New("không tìm thấy quyền của người dùng")
 
 	ErrInvalidUser = errors.New("người dùng không hợp lệ")
+
+	ErrSameEmail = errors.New("email mới trùng với email hiện tại")
+
+	ErrLastAdmin = errors.New("không thể xóa hoặc hạ quyền admin cuối cùng")
+
+	ErrDemoteSelf = errors.New("không thể tự hạ quyền của chính bạn")
+
+	ErrAdminOnly = errors.New("chỉ admin mới được thay đổi tài khoản admin")
+
+	ErrAccountDeactivated = errors.New("tài khoản đã bị vô hiệu hóa và đang chờ xóa")
 )
@@ -0,0 +1,19 @@
+package errors
+
+import "errors"
+
+var (
+	ErrVariantNotFound = errors.New("không tìm thấy biến thể sản phẩm")
+
+	ErrHasVariantNotFound = errors.New("có biến thể sản phẩm không tìm thấy")
+
+	ErrVariantRequired = errors.New("vui lòng chọn biến thể sản phẩm")
+
+	ErrInvalidVariantOptions = errors.New("giá trị tùy chọn của biến thể không hợp lệ")
+
+	ErrDuplicateVariant = errors.New("biến thể sản phẩm bị trùng lặp")
+
+	ErrVariantSKUAlreadyExists = errors.New("SKU của biến thể sản phẩm đã tồn tại")
+
+	ErrProductHasVariants = errors.New("không thể thay đổi tùy chọn khi sản phẩm đã có biến thể")
+)
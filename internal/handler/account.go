@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/mapper"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type AccountHandler struct {
+	accountSvc service.AccountService
+	cfg        *config.Config
+}
+
+func NewAccountHandler(accountSvc service.AccountService, cfg *config.Config) *AccountHandler {
+	return &AccountHandler{
+		accountSvc,
+		cfg,
+	}
+}
+
+func (h *AccountHandler) DeleteMe(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	var req request.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	deletedUser, err := h.accountSvc.RequestDeletion(ctx, user.ID, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrUserNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrIncorrectPassword:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		case customErr.ErrLastAdmin:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	c.SetCookie(h.cfg.App.AccessName, "", -1, "/", "", false, true)
+	c.SetCookie(h.cfg.App.RefreshName, "", -1, fmt.Sprintf("%s/auth/refresh-token", h.cfg.App.ApiPrefix), "", false, true)
+
+	common.JSON(c, http.StatusOK, "Yêu cầu xóa tài khoản thành công", gin.H{
+		"deletion": mapper.ToAccountDeletionResponse(deletedUser),
+	})
+}
+
+func (h *AccountHandler) ExportMe(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	archive, err := h.accountSvc.ExportData(ctx, user.ID)
+	if err != nil {
+		switch err {
+		case customErr.ErrUserNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="account-%d-%s.json"`, user.ID, archive.ExportedAt.Format("20060102150405")))
+	c.IndentedJSON(http.StatusOK, archive)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/request"
+)
+
+func parseProductAttributeForms(c *gin.Context) ([]request.ProductAttributeForm, error) {
+	var attributes []request.ProductAttributeForm
+	for i := 0; ; i++ {
+		attributeIDStr := strings.TrimSpace(c.PostForm(fmt.Sprintf("attributes[%d][attribute_id]", i)))
+		if attributeIDStr == "" {
+			break
+		}
+
+		attributeID, err := strconv.ParseInt(attributeIDStr, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		attributes = append(attributes, request.ProductAttributeForm{
+			AttributeID: attributeID,
+			Value:       strings.TrimSpace(c.PostForm(fmt.Sprintf("attributes[%d][value]", i))),
+		})
+	}
+
+	return attributes, nil
+}
+
+func parseAttributeFilters(c *gin.Context) (map[int64][]string, error) {
+	filters := map[int64][]string{}
+	for key, value := range c.QueryMap("attrs") {
+		attributeID, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				filters[attributeID] = append(filters[attributeID], v)
+			}
+		}
+	}
+
+	return filters, nil
+}
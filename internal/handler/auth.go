@@ -298,3 +298,81 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 		"user": userRes,
 	})
 }
+
+func (h *AuthHandler) ChangeEmail(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var req request.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	token, err := h.authSvc.ChangeEmail(ctx, user.ID, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrIncorrectPassword, customErr.ErrUserNotFound, customErr.ErrSameEmail, customErr.ErrEmailExists:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Vui lòng kiểm tra email mới để lấy mã OTP", gin.H{
+		"change_email_token": token,
+	})
+}
+
+func (h *AuthHandler) VerifyChangeEmail(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var req request.VerifyChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	userRes, err := h.authSvc.VerifyChangeEmail(ctx, user.ID, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrInvalidOTP, customErr.ErrTooManyAttempts, customErr.ErrKeyNotFound, customErr.ErrEmailExists, customErr.ErrUserNotFound:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Thay đổi email thành công", gin.H{
+		"user": userRes,
+	})
+}
@@ -15,12 +15,15 @@ import (
 	"github.com/tienhai2808/ecom_go/internal/types"
 )
 
+const cartSuggestionLimit = 6
+
 type CartHandler struct {
-	cartSvc service.CartService
+	cartSvc           service.CartService
+	recommendationSvc service.RecommendationService
 }
 
-func NewCartHandler(cartSvc service.CartService) *CartHandler {
-	return &CartHandler{cartSvc}
+func NewCartHandler(cartSvc service.CartService, recommendationSvc service.RecommendationService) *CartHandler {
+	return &CartHandler{cartSvc, recommendationSvc}
 }
 
 func (h *CartHandler) GetMyCart(c *gin.Context) {
@@ -50,8 +53,18 @@ func (h *CartHandler) GetMyCart(c *gin.Context) {
 		return
 	}
 
+	cartResp := mapper.ToCartResponse(cart)
+
+	productIDs := make([]int64, 0, len(cart.CartItems))
+	for _, item := range cart.CartItems {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	if suggestions, err := h.recommendationSvc.GetCartSuggestions(ctx, productIDs, cartSuggestionLimit); err == nil {
+		cartResp.Suggestions = mapper.ToBaseProductsResponse(suggestions)
+	}
+
 	common.JSON(c, http.StatusOK, "Lấy giỏ hàng thành công", gin.H{
-		"cart": mapper.ToCartResponse(cart),
+		"cart": cartResp,
 	})
 }
 
@@ -81,8 +94,10 @@ func (h *CartHandler) AddCartItem(c *gin.Context) {
 	cart, err := h.cartSvc.AddCartItem(ctx, user.ID, req)
 	if err != nil {
 		switch err {
-		case customErr.ErrCartNotFound, customErr.ErrProductNotFound:
+		case customErr.ErrCartNotFound, customErr.ErrProductNotFound, customErr.ErrVariantNotFound:
 			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrVariantRequired:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
@@ -199,8 +214,10 @@ func (h *CartHandler) GuestAddCartItem(c *gin.Context) {
 	convertedCart, err := h.cartSvc.GuestAddCartItem(ctx, guestID, req)
 	if err != nil {
 		switch err {
-		case customErr.ErrProductNotFound, customErr.ErrHasProductNotFound:
+		case customErr.ErrProductNotFound, customErr.ErrHasProductNotFound, customErr.ErrVariantNotFound:
 			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrVariantRequired:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
@@ -233,6 +250,14 @@ func (h *CartHandler) GetGuestCart(c *gin.Context) {
 		return
 	}
 
+	productIDs := make([]int64, 0, len(convertedCart.CartItems))
+	for _, item := range convertedCart.CartItems {
+		productIDs = append(productIDs, item.Product.ID)
+	}
+	if suggestions, err := h.recommendationSvc.GetCartSuggestions(ctx, productIDs, cartSuggestionLimit); err == nil {
+		convertedCart.Suggestions = mapper.ToBaseProductsResponse(suggestions)
+	}
+
 	common.JSON(c, http.StatusOK, "Lấy thông tin giỏ hàng thành công", gin.H{
 		"cart": convertedCart,
 	})
@@ -249,6 +274,16 @@ func (h *CartHandler) GuestUpdateCartItem(c *gin.Context) {
 		return
 	}
 
+	var variantID *int64
+	if variantIDStr := c.Query("variant_id"); variantIDStr != "" {
+		id, err := strconv.ParseInt(variantIDStr, 10, 64)
+		if err != nil {
+			common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+			return
+		}
+		variantID = &id
+	}
+
 	guestID := c.GetString("guest_id")
 	if guestID == "" {
 		common.JSON(c, http.StatusBadRequest, "Không có thông tin khách hàng", nil)
@@ -262,7 +297,7 @@ func (h *CartHandler) GuestUpdateCartItem(c *gin.Context) {
 		return
 	}
 
-	convertedCart, err := h.cartSvc.GuestUpdateCartItem(ctx, guestID, productID, req.Quantity)
+	convertedCart, err := h.cartSvc.GuestUpdateCartItem(ctx, guestID, productID, variantID, req.Quantity)
 	if err != nil {
 		switch err {
 		case customErr.ErrProductNotFound, customErr.ErrHasProductNotFound, customErr.ErrCartNotFound, customErr.ErrCartItemNotFound:
@@ -289,13 +324,23 @@ func (h *CartHandler) GuestDeleteCartItem(c *gin.Context) {
 		return
 	}
 
+	var variantID *int64
+	if variantIDStr := c.Query("variant_id"); variantIDStr != "" {
+		id, err := strconv.ParseInt(variantIDStr, 10, 64)
+		if err != nil {
+			common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+			return
+		}
+		variantID = &id
+	}
+
 	guestID := c.GetString("guest_id")
 	if guestID == "" {
 		common.JSON(c, http.StatusBadRequest, "Không có thông tin khách hàng", nil)
 		return
 	}
 
-	convertedCart, err := h.cartSvc.GuestDeleteCartItem(ctx, guestID, productID)
+	convertedCart, err := h.cartSvc.GuestDeleteCartItem(ctx, guestID, productID, variantID)
 	if err != nil {
 		switch err {
 		case customErr.ErrProductNotFound, customErr.ErrHasProductNotFound, customErr.ErrCartNotFound, customErr.ErrCartItemNotFound:
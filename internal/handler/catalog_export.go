@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+)
+
+type CatalogExportHandler struct {
+	catalogExportSvc service.CatalogExportService
+}
+
+func NewCatalogExportHandler(catalogExportSvc service.CatalogExportService) *CatalogExportHandler {
+	return &CatalogExportHandler{catalogExportSvc}
+}
+
+func (h *CatalogExportHandler) ExportProducts(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Minute)
+	defer cancel()
+
+	var query request.ExportProductsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+	if query.Format == "" {
+		query.Format = common.ExportFormatCSV
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if query.Format == common.ExportFormatJSON {
+		contentType = "application/json; charset=utf-8"
+	}
+
+	fileName := fmt.Sprintf("products_%s.%s", time.Now().Format("20060102150405"), query.Format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Status(http.StatusOK)
+
+	if err := h.catalogExportSvc.ExportProducts(ctx, query, c.Writer); err != nil {
+		log.Printf("xuất danh sách sản phẩm thất bại: %v", err)
+		abortStream(c)
+	}
+}
+
+func abortStream(c *gin.Context) {
+	c.Writer.Flush()
+
+	conn, _, err := c.Writer.Hijack()
+	if err != nil {
+		log.Printf("ngắt kết nối xuất dữ liệu thất bại: %v", err)
+		return
+	}
+	_ = conn.Close()
+}
+
+func (h *CatalogExportHandler) GetGoogleMerchantFeed(c *gin.Context) {
+	h.serveFeed(c, common.FeedGoogleMerchant)
+}
+
+func (h *CatalogExportHandler) GetSitemap(c *gin.Context) {
+	h.serveFeed(c, common.FeedSitemap)
+}
+
+func (h *CatalogExportHandler) GetSitemapPage(c *gin.Context) {
+	page, err := strconv.Atoi(strings.TrimSuffix(c.Param("page"), ".xml"))
+	if err != nil || page < 1 {
+		common.JSON(c, http.StatusNotFound, customErr.ErrFeedNotFound.Error(), nil)
+		return
+	}
+
+	h.serveFeed(c, fmt.Sprintf(common.FeedSitemapPage, page))
+}
+
+func (h *CatalogExportHandler) serveFeed(c *gin.Context, name string) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	content, err := h.catalogExportSvc.GetFeed(ctx, name)
+	if err != nil {
+		switch err {
+		case customErr.ErrFeedNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrFeedNotGenerated:
+			common.JSON(c, http.StatusServiceUnavailable, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", content)
+}
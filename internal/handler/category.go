@@ -39,6 +39,8 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 		switch err {
 		case customErr.ErrCategorySlugAlreadyExists:
 			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrCategoryParentNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
@@ -50,18 +52,54 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	})
 }
 
-func (h *CategoryHandler) GetAllCategories(c *gin.Context) {
+func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	categories, err := h.categorySvc.GetAllCategories(ctx)
+	categories, err := h.categorySvc.GetCategoryTree(ctx)
 	if err != nil {
 		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		return
 	}
 
-	common.JSON(c, http.StatusOK, "Lấy danh sách danh mục sản phẩm thành công", gin.H{
-		"categories": mapper.ToCategoriesResponse(categories),
+	common.JSON(c, http.StatusOK, "Lấy cây danh mục sản phẩm thành công", gin.H{
+		"categories": mapper.ToCategoryTreeResponse(categories),
+	})
+}
+
+func (h *CategoryHandler) MoveCategory(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	categoryIDStr := c.Param("id")
+	categoryID, err := strconv.ParseInt(categoryIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	var req request.MoveCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	category, err := h.categorySvc.MoveCategory(ctx, categoryID, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrCategoryNotFound, customErr.ErrCategoryParentNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrInvalidCategoryParent:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Di chuyển danh mục sản phẩm thành công", gin.H{
+		"category": mapper.ToCategoryResponse(category),
 	})
 }
 
@@ -86,10 +124,12 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	category, err := h.categorySvc.UpdateCategory(ctx, categoryID, req)
 	if err != nil {
 		switch err {
-		case customErr.ErrCategoryNotFound:
+		case customErr.ErrCategoryNotFound, customErr.ErrMediaAssetNotFound:
 			common.JSON(c, http.StatusNotFound, err.Error(), nil)
 		case customErr.ErrCategorySlugAlreadyExists:
 			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrMediaAssetNotReady:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
@@ -116,6 +156,8 @@ func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 		switch err {
 		case customErr.ErrCategoryNotFound:
 			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrCategoryHasChildren:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
@@ -141,6 +183,8 @@ func (h *CategoryHandler) DeleteCategories(c *gin.Context) {
 		switch err {
 		case customErr.ErrHasCategoryNotFound:
 			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrCategoryHasChildren:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
@@ -150,3 +194,188 @@ func (h *CategoryHandler) DeleteCategories(c *gin.Context) {
 	message := fmt.Sprintf("Xóa thành công %d danh mục sản phẩm", rowsAccepted)
 	common.JSON(c, http.StatusOK, message, nil)
 }
+
+func (h *CategoryHandler) GetDeletedCategories(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	categories, err := h.categorySvc.GetDeletedCategories(ctx)
+	if err != nil {
+		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy danh sách danh mục sản phẩm đã xóa thành công", gin.H{
+		"categories": mapper.ToDeletedCategoriesResponse(categories),
+	})
+}
+
+func (h *CategoryHandler) RestoreCategory(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	categoryIDStr := c.Param("id")
+	categoryID, err := strconv.ParseInt(categoryIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	category, err := h.categorySvc.RestoreCategory(ctx, categoryID)
+	if err != nil {
+		switch err {
+		case customErr.ErrCategoryNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrCategorySlugAlreadyExists:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Khôi phục danh mục sản phẩm thành công", gin.H{
+		"category": mapper.ToCategoryResponse(category),
+	})
+}
+
+func (h *CategoryHandler) GetCategoryAttributes(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	categoryIDStr := c.Param("id")
+	categoryID, err := strconv.ParseInt(categoryIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	attributes, err := h.categorySvc.GetCategoryAttributes(ctx, categoryID)
+	if err != nil {
+		switch err {
+		case customErr.ErrCategoryNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy danh sách thuộc tính của danh mục sản phẩm thành công", gin.H{
+		"attributes": mapper.ToCategoryAttributesResponse(attributes),
+	})
+}
+
+func (h *CategoryHandler) CreateCategoryAttribute(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	categoryIDStr := c.Param("id")
+	categoryID, err := strconv.ParseInt(categoryIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	var req request.CreateCategoryAttributeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	attribute, err := h.categorySvc.CreateCategoryAttribute(ctx, categoryID, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrCategoryNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrAttributeNameAlreadyExists:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrAttributeOptionsRequired:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusCreated, "Tạo thuộc tính của danh mục sản phẩm thành công", gin.H{
+		"attribute": mapper.ToCategoryAttributeResponse(attribute),
+	})
+}
+
+func (h *CategoryHandler) UpdateCategoryAttribute(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	categoryIDStr := c.Param("id")
+	categoryID, err := strconv.ParseInt(categoryIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	attributeIDStr := c.Param("attribute_id")
+	attributeID, err := strconv.ParseInt(attributeIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	var req request.UpdateCategoryAttributeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	attribute, err := h.categorySvc.UpdateCategoryAttribute(ctx, categoryID, attributeID, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrAttributeNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrAttributeNameAlreadyExists:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrAttributeOptionsRequired:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Cập nhật thuộc tính của danh mục sản phẩm thành công", gin.H{
+		"attribute": mapper.ToCategoryAttributeResponse(attribute),
+	})
+}
+
+func (h *CategoryHandler) DeleteCategoryAttribute(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	categoryIDStr := c.Param("id")
+	categoryID, err := strconv.ParseInt(categoryIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	attributeIDStr := c.Param("attribute_id")
+	attributeID, err := strconv.ParseInt(attributeIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	if err := h.categorySvc.DeleteCategoryAttribute(ctx, categoryID, attributeID); err != nil {
+		switch err {
+		case customErr.ErrAttributeNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Xóa thuộc tính của danh mục sản phẩm thành công", nil)
+}
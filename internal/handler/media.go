@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/mapper"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type MediaHandler struct {
+	mediaSvc service.MediaService
+}
+
+func NewMediaHandler(mediaSvc service.MediaService) *MediaHandler {
+	return &MediaHandler{mediaSvc}
+}
+
+func (h *MediaHandler) GetMediaAssets(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query request.MediaPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	assets, meta, err := h.mediaSvc.GetMediaAssets(ctx, query)
+	if err != nil {
+		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy danh sách thư viện ảnh thành công", gin.H{
+		"media": mapper.ToMediaAssetListResponse(assets, meta),
+	})
+}
+
+func (h *MediaHandler) GetMediaAssetByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	assetIDStr := c.Param("id")
+	assetID, err := strconv.ParseInt(assetIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	asset, err := h.mediaSvc.GetMediaAssetByID(ctx, assetID)
+	if err != nil {
+		switch err {
+		case customErr.ErrMediaAssetNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy thông tin tài nguyên thư viện ảnh thành công", gin.H{
+		"asset": mapper.ToMediaAssetResponse(asset),
+	})
+}
+
+func (h *MediaHandler) CreateMediaAsset(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	var req request.CreateMediaAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	asset, err := h.mediaSvc.CreateMediaAsset(ctx, user.ID, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrUploadNotReady:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusCreated, "Thêm tài nguyên vào thư viện ảnh thành công", gin.H{
+		"asset": mapper.ToMediaAssetResponse(asset),
+	})
+}
+
+func (h *MediaHandler) UpdateMediaAsset(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	assetIDStr := c.Param("id")
+	assetID, err := strconv.ParseInt(assetIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	var req request.UpdateMediaAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	asset, err := h.mediaSvc.UpdateMediaAsset(ctx, assetID, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrMediaAssetNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Cập nhật tài nguyên thư viện ảnh thành công", gin.H{
+		"asset": mapper.ToMediaAssetResponse(asset),
+	})
+}
+
+func (h *MediaHandler) DeleteMediaAsset(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	assetIDStr := c.Param("id")
+	assetID, err := strconv.ParseInt(assetIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	if err = h.mediaSvc.DeleteMediaAsset(ctx, assetID); err != nil {
+		switch err {
+		case customErr.ErrMediaAssetNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrMediaAssetInUse:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Xóa tài nguyên thư viện ảnh thành công", nil)
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/mapper"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+)
+
+type PermissionHandler struct {
+	permissionSvc service.PermissionService
+}
+
+func NewPermissionHandler(permissionSvc service.PermissionService) *PermissionHandler {
+	return &PermissionHandler{permissionSvc}
+}
+
+func (h *PermissionHandler) GetAllRolePermissions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	rolePermissions, err := h.permissionSvc.GetAllRolePermissions(ctx)
+	if err != nil {
+		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy danh sách quyền hạn thành công", gin.H{
+		"roles":       mapper.ToRolesPermissionsResponse(rolePermissions),
+		"permissions": common.AllPermissions,
+	})
+}
+
+func (h *PermissionHandler) GetRolePermissions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	role := c.Param("role")
+
+	rolePermissions, err := h.permissionSvc.GetRolePermissions(ctx, role)
+	if err != nil {
+		switch err {
+		case customErr.ErrInvalidRole:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy quyền hạn của vai trò thành công", gin.H{
+		"role": mapper.ToRolePermissionsResponse(role, rolePermissions),
+	})
+}
+
+func (h *PermissionHandler) GrantPermissions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	role := c.Param("role")
+
+	var req request.RolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	rolePermissions, err := h.permissionSvc.GrantPermissions(ctx, role, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrInvalidRole, customErr.ErrInvalidPermission, customErr.ErrAdminRoleImmutable:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Cấp quyền hạn thành công", gin.H{
+		"role": mapper.ToRolePermissionsResponse(role, rolePermissions),
+	})
+}
+
+func (h *PermissionHandler) RevokePermissions(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	role := c.Param("role")
+
+	var req request.RolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	rolePermissions, err := h.permissionSvc.RevokePermissions(ctx, role, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrInvalidRole, customErr.ErrInvalidPermission, customErr.ErrAdminRoleImmutable:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Thu hồi quyền hạn thành công", gin.H{
+		"role": mapper.ToRolePermissionsResponse(role, rolePermissions),
+	})
+}
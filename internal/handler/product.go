@@ -3,7 +3,6 @@ package handler
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -16,6 +15,7 @@ import (
 	"github.com/tienhai2808/ecom_go/internal/mapper"
 	"github.com/tienhai2808/ecom_go/internal/request"
 	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
 )
 
 type ProductHandler struct {
@@ -27,6 +27,14 @@ func NewProductHandler(productSvc service.ProductService) *ProductHandler {
 }
 
 func (h *ProductHandler) GetAllProducts(c *gin.Context) {
+	h.getProducts(c, true)
+}
+
+func (h *ProductHandler) GetAdminProducts(c *gin.Context) {
+	h.getProducts(c, false)
+}
+
+func (h *ProductHandler) getProducts(c *gin.Context, publishedOnly bool) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
@@ -36,15 +44,30 @@ func (h *ProductHandler) GetAllProducts(c *gin.Context) {
 		common.JSON(c, http.StatusBadRequest, translated, nil)
 		return
 	}
+	query.PublishedOnly = publishedOnly
 
-	products, meta, err := h.productSvc.GetAllProducts(ctx, query)
+	attributes, err := parseAttributeFilters(c)
 	if err != nil {
-		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+	query.Attributes = attributes
+
+	products, meta, facets, err := h.productSvc.GetAllProducts(ctx, query)
+	if err != nil {
+		switch err {
+		case customErr.ErrCategoryNotFound, customErr.ErrAttributeNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrInvalidPriceRange, customErr.ErrInvalidAttributeValue:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
 		return
 	}
 
 	common.JSON(c, http.StatusOK, "lấy danh sách sản phẩm thành công", gin.H{
-		"products": mapper.ToProductListResponse(products, meta),
+		"products": mapper.ToProductListResponse(products, meta, facets),
 	})
 }
 
@@ -75,10 +98,84 @@ func (h *ProductHandler) GetProductByID(c *gin.Context) {
 	})
 }
 
+func (h *ProductHandler) GetProductBySlug(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	slug := strings.TrimSpace(c.Param("slug"))
+	if slug == "" {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidRequest.Error(), nil)
+		return
+	}
+
+	product, err := h.productSvc.GetProductBySlug(ctx, slug)
+	if err != nil {
+		switch err {
+		case customErr.ErrProductNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy thông tin sản phẩm thành công", gin.H{
+		"product": mapper.ToStorefrontProductResponse(product),
+	})
+}
+
+func (h *ProductHandler) SuggestProducts(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query request.ProductSuggestQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	query.Query = strings.TrimSpace(query.Query)
+	if query.Query == "" {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidRequest.Error(), nil)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = 5
+	}
+
+	docs, didYouMean, err := h.productSvc.SuggestProducts(ctx, query)
+	if err != nil {
+		switch err {
+		case customErr.ErrSearchUnavailable:
+			common.JSON(c, http.StatusServiceUnavailable, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy gợi ý tìm kiếm thành công", gin.H{
+		"suggestions": mapper.ToProductSuggestionResponse(docs, query.Limit, didYouMean),
+	})
+}
+
 func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
 	if err := c.Request.ParseMultipartForm(32 << 20); err != nil {
 		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidRequest.Error(), nil)
 		return
@@ -116,12 +213,29 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 		}
 	}
 
+	req.Status = strings.TrimSpace(c.PostForm("status"))
+
+	publishAt, err := parsePublishTimeForm(c, "publish_at")
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidPublishTime.Error(), nil)
+		return
+	}
+	req.PublishAt = publishAt
+
+	unpublishAt, err := parsePublishTimeForm(c, "unpublish_at")
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidPublishTime.Error(), nil)
+		return
+	}
+	req.UnpublishAt = unpublishAt
+
 	req.Images = []request.CreateProductImageForm{}
 	i := 0
 	for {
 		isThumbnailKey := fmt.Sprintf("images[%d][is_thumbnail]", i)
 		sortOrderKey := fmt.Sprintf("images[%d][sort_order]", i)
-		fileKey := fmt.Sprintf("images[%d][file]", i)
+		uploadKey := fmt.Sprintf("images[%d][key]", i)
+		assetIDKey := fmt.Sprintf("images[%d][asset_id]", i)
 
 		isThumbnailStr := strings.TrimSpace(c.PostForm(isThumbnailKey))
 		if isThumbnailStr == "" {
@@ -139,35 +253,34 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 			sortOrder, _ = strconv.Atoi(sortOrderStr)
 		}
 
-		file, err := c.FormFile(fileKey)
-		if err != nil {
-			common.JSON(c, http.StatusBadRequest, fmt.Sprintf("Không tìm thấy file cho ảnh %d", i), nil)
-			return
-		}
-
-		openedFile, err := file.Open()
-		if err != nil {
-			common.JSON(c, http.StatusBadRequest, fmt.Sprintf("Không mở được file cho ảnh %d", i), nil)
-			return
-		}
-		defer openedFile.Close()
-
-		fileBytes, err := io.ReadAll(openedFile)
-		if err != nil {
-			common.JSON(c, http.StatusBadRequest, fmt.Sprintf("Đọc file cho ảnh %d thất bại", i), nil)
-			return
-		}
-
 		image := request.CreateProductImageForm{
 			IsThumbnail: &isThumbnail,
 			SortOrder:   sortOrder,
-			FileData:    fileBytes,
+			Key:         strings.TrimSpace(c.PostForm(uploadKey)),
+		}
+		if assetIDStr := strings.TrimSpace(c.PostForm(assetIDKey)); assetIDStr != "" {
+			image.AssetID, _ = strconv.ParseInt(assetIDStr, 10, 64)
 		}
 
 		req.Images = append(req.Images, image)
 		i++
 	}
 
+	if options := parseProductOptionForms(c); len(options) > 0 {
+		req.Options = options
+	}
+
+	if variants := parseProductVariantForms(c, "variants"); len(variants) > 0 {
+		req.Variants = variants
+	}
+
+	attributes, err := parseProductAttributeForms(c)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+	req.Attributes = attributes
+
 	validate := validator.New()
 	if err := validate.Struct(req); err != nil {
 		translated := common.HandleValidationError(err)
@@ -175,11 +288,15 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 		return
 	}
 
-	newProduct, err := h.productSvc.CreateProduct(ctx, &req)
+	newProduct, err := h.productSvc.CreateProduct(ctx, user, &req)
 	if err != nil {
 		switch err {
-		case customErr.ErrProductSlugAlreadyExists:
+		case customErr.ErrProductSlugAlreadyExists, customErr.ErrVariantSKUAlreadyExists:
 			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrCategoryNotFound, customErr.ErrHasMediaAssetNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrInvalidVariantOptions, customErr.ErrDuplicateVariant, customErr.ErrHasAttributeNotFound, customErr.ErrInvalidAttributeValue, customErr.ErrAttributeValueRequired, customErr.ErrUploadNotReady, customErr.ErrMediaAssetNotReady, customErr.ErrPublishAtRequired, customErr.ErrInvalidUnpublishTime:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
@@ -195,6 +312,18 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
 	productIDStr := c.Param("id")
 	productID, err := strconv.ParseInt(productIDStr, 10, 64)
 	if err != nil {
@@ -239,6 +368,22 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 		}
 	}
 
+	req.Status = strings.TrimSpace(c.PostForm("status"))
+
+	publishAt, err := parsePublishTimeForm(c, "publish_at")
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidPublishTime.Error(), nil)
+		return
+	}
+	req.PublishAt = publishAt
+
+	unpublishAt, err := parsePublishTimeForm(c, "unpublish_at")
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidPublishTime.Error(), nil)
+		return
+	}
+	req.UnpublishAt = unpublishAt
+
 	deleteImgIDsStr := form.Value["delete_image_ids"]
 	deleteImgIDs := make([]int64, 0, len(deleteImgIDsStr))
 	for _, idStr := range deleteImgIDsStr {
@@ -292,7 +437,8 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	for {
 		isThumbnailKey := fmt.Sprintf("new_images[%d][is_thumbnail]", j)
 		sortOrderKey := fmt.Sprintf("new_images[%d][sort_order]", j)
-		fileKey := fmt.Sprintf("new_images[%d][file]", j)
+		uploadKey := fmt.Sprintf("new_images[%d][key]", j)
+		assetIDKey := fmt.Sprintf("new_images[%d][asset_id]", j)
 
 		isThumbnailStr := strings.TrimSpace(c.PostForm(isThumbnailKey))
 		if isThumbnailStr == "" {
@@ -310,37 +456,44 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 			sortOrder, _ = strconv.Atoi(sortOrderStr)
 		}
 
-		file, err := c.FormFile(fileKey)
-		if err != nil {
-			common.JSON(c, http.StatusBadRequest, fmt.Sprintf("Không tìm thấy file cho ảnh %d", j), nil)
-			return
-		}
-
-		openedFile, err := file.Open()
-		if err != nil {
-			common.JSON(c, http.StatusBadRequest, fmt.Sprintf("Không mở được file cho ảnh %d", j), nil)
-			return
-		}
-		defer openedFile.Close()
-
-		fileBytes, err := io.ReadAll(openedFile)
-		if err != nil {
-			common.JSON(c, http.StatusBadRequest, fmt.Sprintf("Đọc file cho ảnh %d thất bại", j), nil)
-			return
-		}
-
 		newImg := request.CreateProductImageForm{
 			IsThumbnail: &isThumbnail,
 			SortOrder:   sortOrder,
-			FileData:    fileBytes,
+			Key:         strings.TrimSpace(c.PostForm(uploadKey)),
+		}
+		if assetIDStr := strings.TrimSpace(c.PostForm(assetIDKey)); assetIDStr != "" {
+			newImg.AssetID, _ = strconv.ParseInt(assetIDStr, 10, 64)
 		}
-
-		fmt.Println(isThumbnail)
 
 		req.NewImages = append(req.NewImages, newImg)
 		j++
 	}
 
+	req.Options = parseProductOptionForms(c)
+
+	req.NewVariants = parseProductVariantForms(c, "new_variants")
+
+	req.UpdateVariants, err = parseUpdateVariantForms(c)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	deleteVariantIDsStr := form.Value["delete_variant_ids"]
+	deleteVariantIDs := make([]int64, 0, len(deleteVariantIDsStr))
+	for _, idStr := range deleteVariantIDsStr {
+		id, _ := strconv.ParseInt(idStr, 10, 64)
+		deleteVariantIDs = append(deleteVariantIDs, id)
+	}
+
+	req.DeleteVariantIDs = deleteVariantIDs
+
+	req.Attributes, err = parseProductAttributeForms(c)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
 	validate := validator.New()
 	if err := validate.Struct(req); err != nil {
 		translated := common.HandleValidationError(err)
@@ -348,11 +501,15 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 		return
 	}
 
-	updatedProduct, err := h.productSvc.UpdateProduct(ctx, productID, &req)
+	updatedProduct, err := h.productSvc.UpdateProduct(ctx, user, productID, &req)
 	if err != nil {
 		switch err {
-		case customErr.ErrProductNotFound, customErr.ErrHasImageNotFound:
+		case customErr.ErrProductNotFound, customErr.ErrHasImageNotFound, customErr.ErrHasVariantNotFound, customErr.ErrCategoryNotFound, customErr.ErrHasMediaAssetNotFound:
 			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrVariantSKUAlreadyExists:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrInvalidVariantOptions, customErr.ErrDuplicateVariant, customErr.ErrProductHasVariants, customErr.ErrHasAttributeNotFound, customErr.ErrInvalidAttributeValue, customErr.ErrAttributeValueRequired, customErr.ErrUploadNotReady, customErr.ErrMediaAssetNotReady, customErr.ErrPublishAtRequired, customErr.ErrInvalidUnpublishTime, customErr.ErrImportQuantityBelowPurchased:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
@@ -368,6 +525,18 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
 	productIDStr := c.Param("id")
 	productID, err := strconv.ParseInt(productIDStr, 10, 64)
 	if err != nil {
@@ -375,7 +544,7 @@ func (h *ProductHandler) DeleteProduct(c *gin.Context) {
 		return
 	}
 
-	if err := h.productSvc.DeleteProduct(ctx, productID); err != nil {
+	if err := h.productSvc.DeleteProduct(ctx, user, productID); err != nil {
 		switch err {
 		case customErr.ErrProductNotFound:
 			common.JSON(c, http.StatusNotFound, err.Error(), nil)
@@ -392,6 +561,18 @@ func (h *ProductHandler) DeleteProducts(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
 	var req request.DeleteManyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		translated := common.HandleValidationError(err)
@@ -399,7 +580,7 @@ func (h *ProductHandler) DeleteProducts(c *gin.Context) {
 		return
 	}
 
-	rowsAccepted, err := h.productSvc.DeleteProducts(ctx, req)
+	rowsAccepted, err := h.productSvc.DeleteProducts(ctx, user, req)
 	if err != nil {
 		switch err {
 		case customErr.ErrHasProductNotFound:
@@ -413,3 +594,261 @@ func (h *ProductHandler) DeleteProducts(c *gin.Context) {
 	message := fmt.Sprintf("Xóa thành công %d sản phẩm", rowsAccepted)
 	common.JSON(c, http.StatusOK, message, nil)
 }
+
+func (h *ProductHandler) BulkUpdateProducts(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	var req request.BulkUpdateProductsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	result, err := h.productSvc.BulkUpdateProducts(ctx, user, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrBulkTargetConflict, customErr.ErrEmptyBulkFilter, customErr.ErrEmptyBulkChanges, customErr.ErrBulkTooManyProducts, customErr.ErrInvalidPriceRange, customErr.ErrAttributeValueRequired:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		case customErr.ErrHasProductNotFound, customErr.ErrCategoryNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrBulkUpdateHasConflicts:
+			common.JSON(c, http.StatusConflict, err.Error(), gin.H{
+				"result": mapper.ToBulkUpdateProductsResponse(result),
+			})
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	message := fmt.Sprintf("Cập nhật thành công %d sản phẩm", result.Changed)
+	if result.DryRun {
+		message = fmt.Sprintf("Có %d sản phẩm sẽ được cập nhật", result.Changed)
+	}
+	common.JSON(c, http.StatusOK, message, gin.H{
+		"result": mapper.ToBulkUpdateProductsResponse(result),
+	})
+}
+
+func (h *ProductHandler) GetDeletedProducts(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	products, err := h.productSvc.GetDeletedProducts(ctx)
+	if err != nil {
+		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy danh sách sản phẩm đã xóa thành công", gin.H{
+		"products": mapper.ToDeletedProductsResponse(products),
+	})
+}
+
+func (h *ProductHandler) GetFailedImages(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	images, err := h.productSvc.GetFailedImages(ctx)
+	if err != nil {
+		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy danh sách hình ảnh xử lý lỗi thành công", gin.H{
+		"images": mapper.ToImageStatusesResponse(images),
+	})
+}
+
+func (h *ProductHandler) RetryFailedImages(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	var req request.RetryImagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	rowsAccepted, err := h.productSvc.RetryFailedImages(ctx, user, req)
+	if err != nil {
+		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	message := fmt.Sprintf("Đã xử lý lại %d hình ảnh", rowsAccepted)
+	common.JSON(c, http.StatusOK, message, nil)
+}
+
+func (h *ProductHandler) RestoreProduct(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	productIDStr := c.Param("id")
+	productID, err := strconv.ParseInt(productIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	product, err := h.productSvc.RestoreProduct(ctx, user, productID)
+	if err != nil {
+		switch err {
+		case customErr.ErrProductNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrProductSlugAlreadyExists:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Khôi phục sản phẩm thành công", gin.H{
+		"product": mapper.ToProductResponse(product),
+	})
+}
+
+func (h *ProductHandler) GetProductHistory(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	productIDStr := c.Param("id")
+	productID, err := strconv.ParseInt(productIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	var query request.ProductHistoryPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	histories, meta, err := h.productSvc.GetProductHistory(ctx, productID, query)
+	if err != nil {
+		switch err {
+		case customErr.ErrProductNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy lịch sử thay đổi sản phẩm thành công", gin.H{
+		"history": mapper.ToProductHistoryListResponse(histories, meta),
+	})
+}
+
+func (h *ProductHandler) RevertProduct(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	productIDStr := c.Param("id")
+	productID, err := strconv.ParseInt(productIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	versionStr := c.Param("version")
+	version, err := strconv.Atoi(versionStr)
+	if err != nil || version <= 0 {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	product, err := h.productSvc.RevertProduct(ctx, user, productID, version)
+	if err != nil {
+		switch err {
+		case customErr.ErrProductNotFound, customErr.ErrProductHistoryNotFound, customErr.ErrCategoryNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrProductSlugAlreadyExists:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrRevertImagesChanged:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrAttributeValueRequired, customErr.ErrImportQuantityBelowPurchased:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Khôi phục phiên bản sản phẩm thành công", gin.H{
+		"product": mapper.ToProductResponse(product),
+	})
+}
+
+func parsePublishTimeForm(c *gin.Context, key string) (*time.Time, error) {
+	value, exists := c.GetPostForm(key)
+	if !exists {
+		return nil, nil
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return &time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
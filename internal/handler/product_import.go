@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/mapper"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+const maxImportUploadBytes = 10 << 20
+
+type ProductImportHandler struct {
+	productImportSvc service.ProductImportService
+}
+
+func NewProductImportHandler(productImportSvc service.ProductImportService) *ProductImportHandler {
+	return &ProductImportHandler{productImportSvc}
+}
+
+func (h *ProductImportHandler) CreateImportJob(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	var form request.CreateImportJobForm
+	if err := c.ShouldBind(&form); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, "Thiếu file nhập sản phẩm", nil)
+		return
+	}
+	if fileHeader.Size > maxImportUploadBytes {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrImportFileTooLarge.Error(), nil)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxImportUploadBytes+1))
+	if err != nil {
+		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	job, err := h.productImportSvc.CreateImportJob(ctx, user.ID, fileHeader.Filename, content, form.Upsert)
+	if err != nil {
+		switch err {
+		case customErr.ErrUnsupportedImportFormat, customErr.ErrInvalidImportFile, customErr.ErrMissingImportColumn, customErr.ErrImportFileTooLarge, customErr.ErrImportTooManyRows, customErr.ErrEmptyImportFile:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusAccepted, "Tạo phiên nhập sản phẩm thành công", gin.H{
+		"job": mapper.ToProductImportJobResponse(job),
+	})
+}
+
+func (h *ProductImportHandler) GetImportJobs(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query request.ImportJobPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	jobs, meta, err := h.productImportSvc.GetImportJobs(ctx, query)
+	if err != nil {
+		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy danh sách phiên nhập sản phẩm thành công", gin.H{
+		"imports": mapper.ToProductImportJobListResponse(jobs, meta),
+	})
+}
+
+func (h *ProductImportHandler) GetImportJobByID(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	jobIDStr := c.Param("id")
+	jobID, err := strconv.ParseInt(jobIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	job, err := h.productImportSvc.GetImportJobByID(ctx, jobID)
+	if err != nil {
+		switch err {
+		case customErr.ErrImportJobNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy thông tin phiên nhập sản phẩm thành công", gin.H{
+		"job": mapper.ToProductImportJobResponse(job),
+	})
+}
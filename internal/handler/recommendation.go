@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/mapper"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+)
+
+type RecommendationHandler struct {
+	recommendationSvc service.RecommendationService
+}
+
+func NewRecommendationHandler(recommendationSvc service.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{recommendationSvc}
+}
+
+func (h *RecommendationHandler) GetRelatedProducts(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	productIDStr := c.Param("id")
+	productID, err := strconv.ParseInt(productIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	var query request.RelatedProductQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	related, boughtTogether, err := h.recommendationSvc.GetRelatedProducts(ctx, productID, query)
+	if err != nil {
+		switch err {
+		case customErr.ErrProductNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy danh sách sản phẩm liên quan thành công", gin.H{
+		"recommendations": mapper.ToRelatedProductsResponse(related, boughtTogether),
+	})
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/mapper"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type ReviewHandler struct {
+	reviewSvc service.ReviewService
+}
+
+func NewReviewHandler(reviewSvc service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{reviewSvc}
+}
+
+func (h *ReviewHandler) GetProductReviews(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	productIDStr := c.Param("id")
+	productID, err := strconv.ParseInt(productIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	var query request.ReviewPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	reviews, meta, err := h.reviewSvc.GetProductReviews(ctx, productID, query)
+	if err != nil {
+		switch err {
+		case customErr.ErrProductNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy danh sách đánh giá thành công", gin.H{
+		"reviews": mapper.ToReviewListResponse(reviews, meta),
+	})
+}
+
+func (h *ReviewHandler) GetReviews(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	var query request.ReviewPaginationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	reviews, meta, err := h.reviewSvc.GetReviews(ctx, query)
+	if err != nil {
+		common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy danh sách đánh giá thành công", gin.H{
+		"reviews": mapper.ToReviewListResponse(reviews, meta),
+	})
+}
+
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	productIDStr := c.Param("id")
+	productID, err := strconv.ParseInt(productIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	if err := c.Request.ParseMultipartForm(32 << 20); err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidRequest.Error(), nil)
+		return
+	}
+
+	var req request.CreateReviewForm
+
+	if ratingStr := strings.TrimSpace(c.PostForm("rating")); ratingStr != "" {
+		if rating, err := strconv.ParseUint(ratingStr, 10, 8); err == nil {
+			req.Rating = uint8(rating)
+		}
+	}
+
+	req.Title = strings.TrimSpace(c.PostForm("title"))
+	req.Body = strings.TrimSpace(c.PostForm("body"))
+
+	req.Images = []request.CreateReviewImageForm{}
+	i := 0
+	for {
+		sortOrderKey := fmt.Sprintf("images[%d][sort_order]", i)
+		uploadKey := fmt.Sprintf("images[%d][key]", i)
+
+		sortOrderStr := strings.TrimSpace(c.PostForm(sortOrderKey))
+		if sortOrderStr == "" {
+			break
+		}
+		sortOrder, _ := strconv.Atoi(sortOrderStr)
+
+		req.Images = append(req.Images, request.CreateReviewImageForm{
+			SortOrder: sortOrder,
+			Key:       strings.TrimSpace(c.PostForm(uploadKey)),
+		})
+		i++
+	}
+
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	newReview, err := h.reviewSvc.CreateReview(ctx, user.ID, productID, &req)
+	if err != nil {
+		switch err {
+		case customErr.ErrProductNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrReviewAlreadyExists:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrReviewNotAllowed:
+			common.JSON(c, http.StatusForbidden, err.Error(), nil)
+		case customErr.ErrUploadNotReady:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusCreated, "Gửi đánh giá thành công, đánh giá sẽ hiển thị sau khi được duyệt", gin.H{
+		"review": mapper.ToReviewResponse(newReview),
+	})
+}
+
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	reviewIDStr := c.Param("id")
+	reviewID, err := strconv.ParseInt(reviewIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	var req request.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	review, err := h.reviewSvc.ModerateReview(ctx, reviewID, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrReviewNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Duyệt đánh giá thành công", gin.H{
+		"review": mapper.ToReviewResponse(review),
+	})
+}
+
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	reviewIDStr := c.Param("id")
+	reviewID, err := strconv.ParseInt(reviewIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	if err := h.reviewSvc.DeleteReview(ctx, reviewID); err != nil {
+		switch err {
+		case customErr.ErrReviewNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Xóa đánh giá thành công", nil)
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/imaging"
+	"github.com/tienhai2808/ecom_go/internal/mapper"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type UploadHandler struct {
+	uploadSvc service.UploadService
+}
+
+func NewUploadHandler(uploadSvc service.UploadService) *UploadHandler {
+	return &UploadHandler{uploadSvc}
+}
+
+func (h *UploadHandler) CreateUploadSlots(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	var req request.CreateUploadSlotsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		translated := common.HandleValidationError(err)
+		common.JSON(c, http.StatusBadRequest, translated, nil)
+		return
+	}
+
+	targets, err := h.uploadSvc.CreateUploadSlots(ctx, user.ID, req)
+	if err != nil {
+		switch err {
+		case customErr.ErrUploadTooLarge:
+			common.JSON(c, http.StatusRequestEntityTooLarge, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusCreated, "Tạo phiên tải lên thành công", gin.H{
+		"uploads": mapper.ToUploadTargetsResponse(targets),
+	})
+}
+
+func (h *UploadHandler) UploadContent(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	slotIDStr := c.Param("id")
+	slotID, err := strconv.ParseInt(slotIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	if c.Request.ContentLength <= 0 {
+		common.JSON(c, http.StatusLengthRequired, customErr.ErrUploadSizeMismatch.Error(), nil)
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, c.Request.ContentLength)
+	defer body.Close()
+
+	content, info, err := imaging.Inspect(body)
+	if err != nil {
+		switch err {
+		case customErr.ErrInvalidImage:
+			common.JSON(c, http.StatusUnsupportedMediaType, err.Error(), nil)
+		case customErr.ErrInvalidImageDimension:
+			common.JSON(c, http.StatusUnprocessableEntity, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		}
+		return
+	}
+
+	slot, err := h.uploadSvc.UploadContent(ctx, user.ID, slotID, content, c.Request.ContentLength, info.ContentType)
+	if err != nil {
+		switch err {
+		case customErr.ErrUploadNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrUploadNotReady:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrUploadExpired:
+			common.JSON(c, http.StatusGone, err.Error(), nil)
+		case customErr.ErrUploadSizeMismatch, customErr.ErrImageTypeMismatch:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Tải file lên thành công", gin.H{
+		"upload": mapper.ToUploadSlotResponse(slot),
+	})
+}
+
+func (h *UploadHandler) ConfirmUpload(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	slotIDStr := c.Param("id")
+	slotID, err := strconv.ParseInt(slotIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	slot, err := h.uploadSvc.ConfirmUpload(ctx, user.ID, slotID)
+	if err != nil {
+		switch err {
+		case customErr.ErrUploadNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrUploadNotReady:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrUploadExpired:
+			common.JSON(c, http.StatusGone, err.Error(), nil)
+		case customErr.ErrUploadTooLarge:
+			common.JSON(c, http.StatusRequestEntityTooLarge, err.Error(), nil)
+		case customErr.ErrInvalidImage:
+			common.JSON(c, http.StatusUnsupportedMediaType, err.Error(), nil)
+		case customErr.ErrInvalidImageDimension:
+			common.JSON(c, http.StatusUnprocessableEntity, err.Error(), nil)
+		case customErr.ErrUploadSizeMismatch, customErr.ErrImageTypeMismatch:
+			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Xác nhận tải file lên thành công", gin.H{
+		"upload": mapper.ToUploadSlotResponse(slot),
+	})
+}
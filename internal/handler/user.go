@@ -11,9 +11,9 @@ import (
 	"github.com/tienhai2808/ecom_go/internal/common"
 	customErr "github.com/tienhai2808/ecom_go/internal/errors"
 	"github.com/tienhai2808/ecom_go/internal/mapper"
-	"github.com/tienhai2808/ecom_go/internal/model"
 	"github.com/tienhai2808/ecom_go/internal/request"
 	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
 )
 
 type UserHandler struct {
@@ -66,6 +66,33 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 	})
 }
 
+func (h *UserHandler) GetUserAuditLogs(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		common.JSON(c, http.StatusBadRequest, customErr.ErrInvalidID.Error(), nil)
+		return
+	}
+
+	auditLogs, err := h.userSvc.GetUserAuditLogs(ctx, userID)
+	if err != nil {
+		switch err {
+		case customErr.ErrUserNotFound:
+			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		default:
+			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
+		}
+		return
+	}
+
+	common.JSON(c, http.StatusOK, "Lấy nhật ký thay đổi người dùng thành công", gin.H{
+		"audit_logs": mapper.ToAuditLogsResponse(auditLogs),
+	})
+}
+
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
@@ -78,11 +105,25 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	newUser, err := h.userSvc.CreateUser(ctx, req)
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	newUser, err := h.userSvc.CreateUser(ctx, user, req)
 	if err != nil {
 		switch err {
 		case customErr.ErrUsernameExists, customErr.ErrEmailExists:
 			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		case customErr.ErrAdminOnly:
+			common.JSON(c, http.StatusForbidden, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
@@ -114,11 +155,27 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	updatedUser, err := h.userSvc.UpdateUser(ctx, userID, &req)
+	userAny, exists := c.Get("user")
+	if !exists {
+		common.JSON(c, http.StatusUnauthorized, "Không có thông tin người dùng", nil)
+		return
+	}
+
+	user, ok := userAny.(*types.UserData)
+	if !ok {
+		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
+		return
+	}
+
+	updatedUser, err := h.userSvc.UpdateUser(ctx, user, userID, &req)
 	if err != nil {
 		switch err {
 		case customErr.ErrEmailExists, customErr.ErrUsernameExists, customErr.ErrUserNotFound, customErr.ErrProfileNotFound:
 			common.JSON(c, http.StatusBadRequest, err.Error(), nil)
+		case customErr.ErrLastAdmin, customErr.ErrDemoteSelf:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrAdminOnly:
+			common.JSON(c, http.StatusForbidden, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
@@ -147,21 +204,20 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	user, ok := userAny.(*model.User)
+	user, ok := userAny.(*types.UserData)
 	if !ok {
 		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
 		return
 	}
 
-	if reqUserID == user.ID {
-		common.JSON(c, http.StatusConflict, "Không thể xóa chính bạn", nil)
-		return
-	}
-
-	if err := h.userSvc.DeleteUser(ctx, reqUserID); err != nil {
+	if err := h.userSvc.DeleteUser(ctx, user, reqUserID); err != nil {
 		switch err {
 		case customErr.ErrUserNotFound:
 			common.JSON(c, http.StatusNotFound, err.Error(), nil)
+		case customErr.ErrUserConflict, customErr.ErrLastAdmin:
+			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrAdminOnly:
+			common.JSON(c, http.StatusForbidden, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
@@ -190,17 +246,19 @@ func (h *UserHandler) DeleteManyUsers(c *gin.Context) {
 		return
 	}
 
-	user, ok := userAny.(*model.User)
+	user, ok := userAny.(*types.UserData)
 	if !ok {
 		common.JSON(c, http.StatusInternalServerError, "Không thể chuyển đổi thông tin người dùng", nil)
 		return
 	}
 
-	rowsAccepted, err := h.userSvc.DeleteUsers(ctx, user.ID, req)
+	rowsAccepted, err := h.userSvc.DeleteUsers(ctx, user, req)
 	if err != nil {
 		switch err {
-		case customErr.ErrUserConflict:
+		case customErr.ErrUserConflict, customErr.ErrLastAdmin:
 			common.JSON(c, http.StatusConflict, err.Error(), nil)
+		case customErr.ErrAdminOnly:
+			common.JSON(c, http.StatusForbidden, err.Error(), nil)
 		default:
 			common.JSON(c, http.StatusInternalServerError, err.Error(), nil)
 		}
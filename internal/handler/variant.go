@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/request"
+)
+
+func parseProductOptionForms(c *gin.Context) []request.CreateProductOptionForm {
+	form := c.Request.MultipartForm
+
+	options := []request.CreateProductOptionForm{}
+	for i := 0; ; i++ {
+		name := strings.TrimSpace(c.PostForm(fmt.Sprintf("options[%d][name]", i)))
+		if name == "" {
+			break
+		}
+
+		values := []string{}
+		for _, value := range form.Value[fmt.Sprintf("options[%d][values]", i)] {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+
+		options = append(options, request.CreateProductOptionForm{
+			Name:   name,
+			Values: values,
+		})
+	}
+
+	return options
+}
+
+func parseProductVariantForms(c *gin.Context, prefix string) []request.CreateProductVariantForm {
+	form := c.Request.MultipartForm
+
+	variants := []request.CreateProductVariantForm{}
+	for i := 0; ; i++ {
+		key := fmt.Sprintf("%s[%d]", prefix, i)
+
+		sku := strings.TrimSpace(c.PostForm(key + "[sku]"))
+		if sku == "" {
+			break
+		}
+
+		variant := request.CreateProductVariantForm{SKU: sku}
+
+		if priceStr := strings.TrimSpace(c.PostForm(key + "[price]")); priceStr != "" {
+			if price, err := strconv.ParseFloat(priceStr, 64); err == nil {
+				variant.Price = &price
+			}
+		}
+
+		if quantityStr := strings.TrimSpace(c.PostForm(key + "[quantity]")); quantityStr != "" {
+			if quantity, err := strconv.Atoi(quantityStr); err == nil && quantity >= 0 {
+				variant.Quantity = uint(quantity)
+			}
+		}
+
+		if isActiveStr := strings.TrimSpace(c.PostForm(key + "[is_active]")); isActiveStr != "" {
+			if isActive, err := strconv.ParseBool(isActiveStr); err == nil {
+				variant.IsActive = &isActive
+			}
+		}
+
+		for _, value := range form.Value[key+"[option_values]"] {
+			variant.OptionValues = append(variant.OptionValues, strings.TrimSpace(value))
+		}
+
+		variant.Images = parseVariantImageForms(c, key)
+
+		variants = append(variants, variant)
+	}
+
+	return variants
+}
+
+func parseVariantImageForms(c *gin.Context, prefix string) []request.CreateProductImageForm {
+	images := []request.CreateProductImageForm{}
+	for j := 0; ; j++ {
+		key := fmt.Sprintf("%s[images][%d]", prefix, j)
+
+		isThumbnailStr := strings.TrimSpace(c.PostForm(key + "[is_thumbnail]"))
+		if isThumbnailStr == "" {
+			break
+		}
+		isThumbnail, _ := strconv.ParseBool(isThumbnailStr)
+
+		sortOrder := 0
+		if sortOrderStr := strings.TrimSpace(c.PostForm(key + "[sort_order]")); sortOrderStr != "" {
+			sortOrder, _ = strconv.Atoi(sortOrderStr)
+		}
+
+		assetID := int64(0)
+		if assetIDStr := strings.TrimSpace(c.PostForm(key + "[asset_id]")); assetIDStr != "" {
+			assetID, _ = strconv.ParseInt(assetIDStr, 10, 64)
+		}
+
+		images = append(images, request.CreateProductImageForm{
+			IsThumbnail: &isThumbnail,
+			SortOrder:   sortOrder,
+			Key:         strings.TrimSpace(c.PostForm(key + "[key]")),
+			AssetID:     assetID,
+		})
+	}
+
+	return images
+}
+
+func parseUpdateVariantForms(c *gin.Context) ([]request.UpdateProductVariantForm, error) {
+	variants := []request.UpdateProductVariantForm{}
+	for i := 0; ; i++ {
+		key := fmt.Sprintf("update_variants[%d]", i)
+
+		idStr := strings.TrimSpace(c.PostForm(key + "[id]"))
+		if idStr == "" {
+			break
+		}
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		variant := request.UpdateProductVariantForm{ID: id}
+
+		if sku := strings.TrimSpace(c.PostForm(key + "[sku]")); sku != "" {
+			variant.SKU = &sku
+		}
+
+		if priceStr := strings.TrimSpace(c.PostForm(key + "[price]")); priceStr != "" {
+			if price, err := strconv.ParseFloat(priceStr, 64); err == nil {
+				variant.Price = &price
+			}
+		}
+
+		if quantityStr := strings.TrimSpace(c.PostForm(key + "[quantity]")); quantityStr != "" {
+			if quantity, err := strconv.Atoi(quantityStr); err == nil && quantity >= 0 {
+				q := uint(quantity)
+				variant.Quantity = &q
+			}
+		}
+
+		if isActiveStr := strings.TrimSpace(c.PostForm(key + "[is_active]")); isActiveStr != "" {
+			if isActive, err := strconv.ParseBool(isActiveStr); err == nil {
+				variant.IsActive = &isActive
+			}
+		}
+
+		variants = append(variants, variant)
+	}
+
+	return variants, nil
+}
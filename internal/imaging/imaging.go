@@ -0,0 +1,109 @@
+package imaging
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	MaxDimension = 8000
+	MinDimension = 50
+)
+
+var allowedContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+	"image/gif":  true,
+}
+
+type Info struct {
+	ContentType string
+	Width       int
+	Height      int
+}
+
+type Variant struct {
+	Name  string
+	Width int
+}
+
+var Variants = []Variant{
+	{"thumbnail", 200},
+	{"medium", 600},
+	{"large", 1200},
+}
+
+func Inspect(r io.Reader) (io.Reader, *Info, error) {
+	br := bufio.NewReaderSize(r, 512)
+	head, err := br.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, nil, fmt.Errorf("đọc file thất bại: %w", err)
+	}
+
+	contentType := http.DetectContentType(head)
+	if !allowedContentTypes[contentType] {
+		return nil, nil, customErr.ErrInvalidImage
+	}
+
+	var buf bytes.Buffer
+	config, _, err := image.DecodeConfig(io.TeeReader(br, &buf))
+	if err != nil {
+		return nil, nil, customErr.ErrInvalidImage
+	}
+
+	if config.Width > MaxDimension || config.Height > MaxDimension || config.Width < MinDimension || config.Height < MinDimension {
+		return nil, nil, customErr.ErrInvalidImageDimension
+	}
+
+	info := &Info{
+		ContentType: contentType,
+		Width:       config.Width,
+		Height:      config.Height,
+	}
+
+	return io.MultiReader(&buf, br), info, nil
+}
+
+func Resize(src image.Image, width int) *image.NRGBA {
+	bounds := src.Bounds()
+	if bounds.Dx() <= width {
+		dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(dst, dst.Rect, src, bounds.Min, draw.Src)
+		return dst
+	}
+
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Rect, src, bounds, draw.Src, nil)
+
+	return dst
+}
+
+func PlaceholderColor(src image.Image) string {
+	small := Resize(src, 32)
+
+	var r, g, b, n int
+	for i := 0; i < len(small.Pix); i += 4 {
+		r += int(small.Pix[i])
+		g += int(small.Pix[i+1])
+		b += int(small.Pix[i+2])
+		n++
+	}
+	if n == 0 {
+		return "#ffffff"
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", r/n, g/n, b/n)
+}
@@ -0,0 +1,380 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"io"
+)
+
+const (
+	maxWebPDimension  = 16384
+	maxCodeLength     = 15
+	maxCodeLengthCode = 7
+
+	predictorTransform     = 0
+	subtractGreenTransform = 2
+	predictorMode          = 12
+	predictorBits          = 5
+)
+
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+func EncodeWebP(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= 0 || height <= 0 || width > maxWebPDimension || height > maxWebPDimension {
+		return fmt.Errorf("kích thước ảnh %dx%d không hợp lệ cho WebP", width, height)
+	}
+
+	nrgba, ok := img.(*image.NRGBA)
+	if !ok || nrgba.Rect.Min != (image.Point{}) {
+		nrgba = image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(nrgba, nrgba.Rect, img, bounds.Min, draw.Src)
+	}
+
+	pix := make([]uint8, width*height*4)
+	hasAlpha := false
+	for y := 0; y < height; y++ {
+		row := nrgba.Pix[y*nrgba.Stride : y*nrgba.Stride+width*4]
+		copy(pix[y*width*4:], row)
+		for x := 3; x < len(row); x += 4 {
+			if row[x] != 0xff {
+				hasAlpha = true
+			}
+		}
+	}
+
+	// Subtract green rồi dự đoán mỗi điểm ảnh theo clamp(L + T - TL) trước khi mã hóa phần dư.
+	for i := 0; i < len(pix); i += 4 {
+		pix[i] -= pix[i+1]
+		pix[i+2] -= pix[i+1]
+	}
+	residual := predictResidual(pix, width, height)
+
+	bw := &bitWriter{}
+	bw.writeBits(0x2f, 8)
+	bw.writeBits(uint32(width-1), 14)
+	bw.writeBits(uint32(height-1), 14)
+	if hasAlpha {
+		bw.writeBits(1, 1)
+	} else {
+		bw.writeBits(0, 1)
+	}
+	bw.writeBits(0, 3)
+
+	bw.writeBits(1, 1)
+	bw.writeBits(subtractGreenTransform, 2)
+
+	blocksX := (width + 1<<predictorBits - 1) >> predictorBits
+	blocksY := (height + 1<<predictorBits - 1) >> predictorBits
+	modes := make([]uint8, blocksX*blocksY*4)
+	for i := 0; i < len(modes); i += 4 {
+		modes[i+1] = predictorMode
+		modes[i+3] = 0xff
+	}
+	bw.writeBits(1, 1)
+	bw.writeBits(predictorTransform, 2)
+	bw.writeBits(predictorBits-2, 3)
+	bw.writeBits(0, 1)
+	writeImageData(bw, modes)
+
+	bw.writeBits(0, 1)
+	bw.writeBits(0, 1)
+	bw.writeBits(0, 1)
+	writeImageData(bw, residual)
+
+	payload := bw.bytes()
+	padding := len(payload) & 1
+
+	header := make([]byte, 20)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(12+len(payload)+padding))
+	copy(header[8:12], "WEBP")
+	copy(header[12:16], "VP8L")
+	binary.LittleEndian.PutUint32(header[16:20], uint32(len(payload)))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(payload); err != nil {
+		return err
+	}
+	if padding == 1 {
+		if _, err := w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func predictResidual(pix []uint8, width, height int) []uint8 {
+	residual := make([]uint8, len(pix))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := (y*width + x) * 4
+			for c := 0; c < 4; c++ {
+				var predicted uint8
+				switch {
+				case x == 0 && y == 0:
+					if c == 3 {
+						predicted = 0xff
+					}
+				case y == 0:
+					predicted = pix[i-4+c]
+				case x == 0:
+					predicted = pix[i-width*4+c]
+				default:
+					left := int(pix[i-4+c])
+					top := int(pix[i-width*4+c])
+					topLeft := int(pix[i-width*4-4+c])
+					predicted = uint8(min(max(left+top-topLeft, 0), 255))
+				}
+				residual[i+c] = pix[i+c] - predicted
+			}
+		}
+	}
+
+	return residual
+}
+
+func writeImageData(bw *bitWriter, pix []uint8) {
+	var counts [4][256]int
+	for i := 0; i < len(pix); i += 4 {
+		counts[0][pix[i+1]]++
+		counts[1][pix[i]]++
+		counts[2][pix[i+2]]++
+		counts[3][pix[i+3]]++
+	}
+
+	green := make([]int, 280)
+	copy(green, counts[0][:])
+
+	var codes [4][]uint32
+	var lengths [4][]int
+	for i, alphabet := range [][]int{green, counts[1][:], counts[2][:], counts[3][:]} {
+		lengths[i], codes[i] = writePrefixCode(bw, alphabet)
+	}
+	writePrefixCode(bw, make([]int, 40))
+
+	for i := 0; i < len(pix); i += 4 {
+		for c, v := range [4]uint8{pix[i+1], pix[i], pix[i+2], pix[i+3]} {
+			bw.writeBits(codes[c][v], uint(lengths[c][v]))
+		}
+	}
+}
+
+func writePrefixCode(bw *bitWriter, counts []int) ([]int, []uint32) {
+	symbols := make([]int, 0, 2)
+	for symbol, count := range counts {
+		if count > 0 {
+			symbols = append(symbols, symbol)
+			if len(symbols) > 2 {
+				break
+			}
+		}
+	}
+
+	lengths := make([]int, len(counts))
+	if len(symbols) <= 2 && (len(symbols) == 0 || symbols[len(symbols)-1] < 256) {
+		if len(symbols) == 0 {
+			symbols = append(symbols, 0)
+		}
+
+		bw.writeBits(1, 1)
+		bw.writeBits(uint32(len(symbols)-1), 1)
+		if symbols[0] <= 1 {
+			bw.writeBits(0, 1)
+			bw.writeBits(uint32(symbols[0]), 1)
+		} else {
+			bw.writeBits(1, 1)
+			bw.writeBits(uint32(symbols[0]), 8)
+		}
+		if len(symbols) == 2 {
+			bw.writeBits(uint32(symbols[1]), 8)
+			lengths[symbols[0]] = 1
+			lengths[symbols[1]] = 1
+		}
+
+		return lengths, canonicalCodes(lengths)
+	}
+
+	lengths = huffmanLengths(counts, maxCodeLength)
+
+	var lengthCounts [19]int
+	for _, length := range lengths {
+		lengthCounts[length]++
+	}
+	lengthCodeLengths := huffmanLengths(lengthCounts[:], maxCodeLengthCode)
+	lengthCodes := canonicalCodes(lengthCodeLengths)
+
+	bw.writeBits(0, 1)
+	bw.writeBits(uint32(len(codeLengthCodeOrder)-4), 4)
+	for _, symbol := range codeLengthCodeOrder {
+		bw.writeBits(uint32(lengthCodeLengths[symbol]), 3)
+	}
+	bw.writeBits(0, 1)
+	for _, length := range lengths {
+		bw.writeBits(lengthCodes[length], uint(lengthCodeLengths[length]))
+	}
+
+	return lengths, canonicalCodes(lengths)
+}
+
+func huffmanLengths(counts []int, maxLength int) []int {
+	weights := make([]int, len(counts))
+	used := 0
+	for symbol, count := range counts {
+		if count > 0 {
+			weights[symbol] = count
+			used++
+		}
+	}
+
+	// Mã Huffman cần ít nhất hai ký hiệu để đầy đủ, thêm một ký hiệu giả nếu chỉ có một.
+	if used < 2 {
+		for symbol := range weights {
+			if weights[symbol] == 0 {
+				weights[symbol] = 1
+				used++
+				if used == 2 {
+					break
+				}
+			}
+		}
+	}
+
+	for {
+		lengths := buildHuffmanLengths(weights)
+
+		longest := 0
+		for _, length := range lengths {
+			longest = max(longest, length)
+		}
+		if longest <= maxLength {
+			return lengths
+		}
+
+		for symbol, weight := range weights {
+			if weight > 0 {
+				weights[symbol] = (weight + 1) / 2
+			}
+		}
+	}
+}
+
+func buildHuffmanLengths(weights []int) []int {
+	type node struct {
+		weight int
+		symbol int
+		left   int
+		right  int
+	}
+
+	nodes := make([]node, 0, len(weights)*2)
+	active := make([]int, 0, len(weights))
+	for symbol, weight := range weights {
+		if weight > 0 {
+			nodes = append(nodes, node{weight, symbol, -1, -1})
+			active = append(active, len(nodes)-1)
+		}
+	}
+
+	popMin := func() int {
+		best := 0
+		for i := 1; i < len(active); i++ {
+			if nodes[active[i]].weight < nodes[active[best]].weight {
+				best = i
+			}
+		}
+		idx := active[best]
+		active = append(active[:best], active[best+1:]...)
+		return idx
+	}
+
+	for len(active) > 1 {
+		left := popMin()
+		right := popMin()
+		nodes = append(nodes, node{nodes[left].weight + nodes[right].weight, -1, left, right})
+		active = append(active, len(nodes)-1)
+	}
+
+	lengths := make([]int, len(weights))
+	var walk func(idx, depth int)
+	walk = func(idx, depth int) {
+		if nodes[idx].symbol >= 0 {
+			lengths[nodes[idx].symbol] = depth
+			return
+		}
+		walk(nodes[idx].left, depth+1)
+		walk(nodes[idx].right, depth+1)
+	}
+	walk(active[0], 0)
+
+	return lengths
+}
+
+func canonicalCodes(lengths []int) []uint32 {
+	var lengthCounts [maxCodeLength + 1]uint32
+	for _, length := range lengths {
+		if length > 0 {
+			lengthCounts[length]++
+		}
+	}
+
+	var nextCode [maxCodeLength + 2]uint32
+	code := uint32(0)
+	for length := 1; length <= maxCodeLength; length++ {
+		code = (code + lengthCounts[length-1]) << 1
+		nextCode[length] = code
+	}
+
+	codes := make([]uint32, len(lengths))
+	for symbol, length := range lengths {
+		if length == 0 {
+			continue
+		}
+		codes[symbol] = reverseBits(nextCode[length], length)
+		nextCode[length]++
+	}
+
+	return codes
+}
+
+func reverseBits(code uint32, length int) uint32 {
+	reversed := uint32(0)
+	for i := 0; i < length; i++ {
+		reversed = reversed<<1 | code&1
+		code >>= 1
+	}
+
+	return reversed
+}
+
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+func (w *bitWriter) writeBits(value uint32, n uint) {
+	w.acc |= uint64(value) << w.nbits
+	w.nbits += n
+	for w.nbits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.nbits -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.nbits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc = 0
+		w.nbits = 0
+	}
+
+	return w.buf
+}
@@ -0,0 +1,87 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+func testImage(width, height int, alpha bool) *image.NRGBA {
+	rng := rand.New(rand.NewSource(int64(width*height + 1)))
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			a := uint8(0xff)
+			if alpha {
+				a = uint8(rng.Intn(256))
+			}
+			img.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(x*7 + rng.Intn(8)),
+				G: uint8(y*5 + rng.Intn(8)),
+				B: uint8((x + y) * 3),
+				A: a,
+			})
+		}
+	}
+
+	return img
+}
+
+func TestEncodeWebPRoundTrip(t *testing.T) {
+	cases := []struct {
+		name          string
+		width, height int
+		alpha         bool
+	}{
+		{"single pixel", 1, 1, false},
+		{"opaque", 37, 23, false},
+		{"alpha", 64, 65, true},
+		{"wide", 300, 2, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := testImage(tc.width, tc.height, tc.alpha)
+
+			var buf bytes.Buffer
+			if err := EncodeWebP(&buf, src); err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+
+			decoded, err := webp.Decode(&buf)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if decoded.Bounds() != src.Bounds() {
+				t.Fatalf("bounds = %v, want %v", decoded.Bounds(), src.Bounds())
+			}
+
+			for y := 0; y < tc.height; y++ {
+				for x := 0; x < tc.width; x++ {
+					want := src.NRGBAAt(x, y)
+					got := color.NRGBAModel.Convert(decoded.At(x, y)).(color.NRGBA)
+					if want.A == 0 {
+						if got.A != 0 {
+							t.Fatalf("pixel (%d,%d) = %v, want transparent", x, y, got)
+						}
+						continue
+					}
+					if got != want {
+						t.Fatalf("pixel (%d,%d) = %v, want %v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeWebPRejectsInvalidDimensions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeWebP(&buf, image.NewNRGBA(image.Rect(0, 0, 0, 10))); err == nil {
+		t.Fatalf("encode must reject an empty image")
+	}
+}
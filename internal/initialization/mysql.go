@@ -4,10 +4,12 @@ import (
 	"database/sql"
 	"fmt"
 	
+	"github.com/tienhai2808/ecom_go/internal/common"
 	"github.com/tienhai2808/ecom_go/internal/config"
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var allModels = []any{
@@ -17,14 +19,32 @@ var allModels = []any{
 	&model.Product{},
 	&model.Category{},
 	&model.Product{},
+	&model.ProductOption{},
+	&model.ProductOptionValue{},
+	&model.ProductVariant{},
+	&model.CategoryAttribute{},
+	&model.ProductAttributeValue{},
 	&model.Image{},
 	&model.Inventory{},
 	&model.Cart{},
 	&model.CartItem{},
 	&model.Order{},
 	&model.OrderItem{},
+	&model.Review{},
+	&model.ReviewImage{},
+	&model.ProductCoPurchase{},
+	&model.UploadSlot{},
+	&model.MediaAsset{},
+	&model.MediaTag{},
+	&model.ProductImportJob{},
+	&model.ProductHistory{},
+	&model.RolePermission{},
+	&model.SeedMarker{},
+	&model.AuditLog{},
 }
 
+const seedMarkerRolePermissions = "role_permissions"
+
 type DB struct {
 	Gorm *gorm.DB
 	sql  *sql.DB
@@ -48,6 +68,26 @@ func InitMySQL(cfg *config.Config) (*DB, error) {
 		return nil, fmt.Errorf("chuyển dịch DB thất bại: %w", err)
 	}
 
+	if err = seedRolePermissions(gDB); err != nil {
+		return nil, fmt.Errorf("khởi tạo quyền hạn mặc định thất bại: %w", err)
+	}
+
+	if err = seedAddedRolePermissions(gDB); err != nil {
+		return nil, fmt.Errorf("bổ sung quyền hạn mặc định mới thất bại: %w", err)
+	}
+
+	if err = backfillCategoryPaths(gDB); err != nil {
+		return nil, fmt.Errorf("khởi tạo đường dẫn danh mục sản phẩm thất bại: %w", err)
+	}
+
+	if err = backfillImageStatuses(gDB); err != nil {
+		return nil, fmt.Errorf("khởi tạo trạng thái hình ảnh thất bại: %w", err)
+	}
+
+	if err = backfillProductStatuses(gDB); err != nil {
+		return nil, fmt.Errorf("khởi tạo trạng thái đăng bán sản phẩm thất bại: %w", err)
+	}
+
 	sqlDB, err := gDB.DB()
 	if err != nil {
 		return nil, fmt.Errorf("không lấy được sql.DB: %w", err)
@@ -64,5 +104,133 @@ func (d *DB) Close() {
 }
 
 func runAutoMigrations(db *gorm.DB) error {
+	if err := dropSlugUniqueIndexes(db, &model.Product{}, "products"); err != nil {
+		return err
+	}
+	if err := dropSlugUniqueIndexes(db, &model.Category{}, "categories"); err != nil {
+		return err
+	}
+
 	return db.AutoMigrate(allModels...)
 }
+
+func dropSlugUniqueIndexes(db *gorm.DB, value any, table string) error {
+	if !db.Migrator().HasTable(value) {
+		return nil
+	}
+
+	var indexNames []string
+	if err := db.Raw(`SELECT INDEX_NAME FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ? AND NON_UNIQUE = 0 AND INDEX_NAME <> 'PRIMARY'`,
+		table, "slug").Scan(&indexNames).Error; err != nil {
+		return err
+	}
+
+	for _, name := range indexNames {
+		if err := db.Migrator().DropIndex(value, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func seedRolePermissions(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var marker model.SeedMarker
+		if err := tx.Where("name = ?", seedMarkerRolePermissions).Limit(1).Find(&marker).Error; err != nil {
+			return err
+		}
+		if marker.Name != "" {
+			return nil
+		}
+
+		var count int64
+		if err := tx.Model(&model.RolePermission{}).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count == 0 {
+			rolePermissions := []*model.RolePermission{}
+			for role, permissions := range common.DefaultRolePermissions {
+				for _, permission := range permissions {
+					rolePermissions = append(rolePermissions, &model.RolePermission{
+						Role:       role,
+						Permission: permission,
+					})
+				}
+			}
+
+			if err := tx.Create(rolePermissions).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&model.SeedMarker{Name: seedMarkerRolePermissions}).Error
+	})
+}
+
+func seedAddedRolePermissions(db *gorm.DB) error {
+	for role, permissions := range common.AddedRolePermissions {
+		for _, permission := range permissions {
+			if err := seedRolePermission(db, role, permission); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func seedRolePermission(db *gorm.DB, role, permission string) error {
+	markerName := fmt.Sprintf("%s:%s:%s", seedMarkerRolePermissions, role, permission)
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var marker model.SeedMarker
+		if err := tx.Where("name = ?", markerName).Limit(1).Find(&marker).Error; err != nil {
+			return err
+		}
+		if marker.Name != "" {
+			return nil
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.RolePermission{
+			Role:       role,
+			Permission: permission,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&model.SeedMarker{Name: markerName}).Error
+	})
+}
+
+func backfillCategoryPaths(db *gorm.DB) error {
+	return db.Model(&model.Category{}).Unscoped().
+		Where("path = '' AND parent_id IS NULL").
+		Updates(map[string]any{
+			"path":  gorm.Expr("CONCAT('/', id, '/')"),
+			"depth": 0,
+		}).Error
+}
+
+func backfillImageStatuses(db *gorm.DB) error {
+	if err := db.Model(&model.Image{}).
+		Where("status = ? AND url <> ''", common.ImageStatusPending).
+		Update("status", common.ImageStatusReady).Error; err != nil {
+		return err
+	}
+
+	return db.Model(&model.ReviewImage{}).
+		Where("status = ? AND url <> ''", common.ImageStatusPending).
+		Update("status", common.ImageStatusReady).Error
+}
+
+func backfillProductStatuses(db *gorm.DB) error {
+	return db.Model(&model.Product{}).Unscoped().
+		Where("status = ? AND is_active = ?", common.ProductStatusDraft, true).
+		Updates(map[string]any{
+			"status":     common.ProductStatusPublished,
+			"publish_at": gorm.Expr("COALESCE(publish_at, created_at)"),
+		}).Error
+}
@@ -0,0 +1,43 @@
+package initialization
+
+import (
+	"fmt"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/storage"
+)
+
+func InitStorage(cfg *config.Config) (storage.Storage, error) {
+	switch cfg.Storage.Driver {
+	case common.StorageDriverLocal:
+		dir := cfg.Storage.Local.Dir
+		if dir == "" {
+			dir = "uploads"
+		}
+
+		return storage.NewLocalStorage(dir, cfg.Storage.Local.BaseURL), nil
+	case common.StorageDriverS3:
+		client, err := minio.New(cfg.Storage.S3.Endpoint, &minio.Options{
+			Creds:  credentials.NewStaticV4(cfg.Storage.S3.AccessKey, cfg.Storage.S3.SecretKey, ""),
+			Secure: cfg.Storage.S3.UseSSL,
+			Region: cfg.Storage.S3.Region,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("khởi tạo S3 thất bại: %w", err)
+		}
+
+		return storage.NewS3Storage(client, cfg.Storage.S3.Bucket, cfg.Storage.S3.PublicURL), nil
+	case "", common.StorageDriverCloudinary:
+		cld, err := InitCloudinary(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return storage.NewCloudinaryStorage(cld), nil
+	}
+
+	return nil, fmt.Errorf("driver lưu trữ %s không được hỗ trợ", cfg.Storage.Driver)
+}
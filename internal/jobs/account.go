@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/service"
+)
+
+func StartAnonymizeAccountsJob(accountSvc service.AccountService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		count, err := accountSvc.AnonymizeExpiredAccounts(ctx)
+		cancel()
+
+		if err != nil {
+			log.Printf("Ẩn danh tài khoản hết hạn thất bại: %v", err)
+			continue
+		}
+
+		if count > 0 {
+			log.Printf("Đã ẩn danh %d tài khoản hết thời gian chờ xóa", count)
+		}
+	}
+}
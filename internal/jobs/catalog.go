@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/service"
+)
+
+func StartPurgeTrashJob(productSvc service.ProductService, categorySvc service.CategoryService, retention, interval time.Duration) {
+	if retention <= 0 {
+		retention = 30 * 24 * time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		before := time.Now().Add(-retention)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		products, err := productSvc.PurgeDeletedProducts(ctx, before)
+		if err != nil {
+			log.Printf("Xóa vĩnh viễn sản phẩm trong thùng rác thất bại: %v", err)
+		} else if products > 0 {
+			log.Printf("Đã xóa vĩnh viễn %d sản phẩm trong thùng rác", products)
+		}
+
+		categories, err := categorySvc.PurgeDeletedCategories(ctx, before)
+		if err != nil {
+			log.Printf("Xóa vĩnh viễn danh mục sản phẩm trong thùng rác thất bại: %v", err)
+		} else if categories > 0 {
+			log.Printf("Đã xóa vĩnh viễn %d danh mục sản phẩm trong thùng rác", categories)
+		}
+		cancel()
+	}
+}
+
+func StartPublishScheduleJob(productSvc service.ProductService, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		count, err := productSvc.PublishScheduledProducts(ctx)
+		cancel()
+
+		if err != nil {
+			log.Printf("Cập nhật trạng thái đăng bán sản phẩm theo lịch thất bại: %v", err)
+			continue
+		}
+
+		if count > 0 {
+			log.Printf("Đã cập nhật trạng thái đăng bán cho %d sản phẩm theo lịch", count)
+		}
+	}
+}
+
+func RebuildSuggestions(productSvc service.ProductService) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if err := productSvc.RebuildSuggestions(ctx); err != nil {
+		log.Printf("Khởi tạo chỉ mục gợi ý tìm kiếm thất bại: %v", err)
+	}
+}
+
+func EnsureProductIndex(productIndexSvc service.ProductIndexService) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	if err := productIndexSvc.EnsureProductIndex(ctx); err != nil {
+		log.Printf("Khởi tạo chỉ mục sản phẩm thất bại: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/service"
+)
+
+func StartFeedJob(catalogExportSvc service.CatalogExportService, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		items, err := catalogExportSvc.GenerateFeeds(ctx)
+		if err != nil {
+			log.Printf("Tạo nguồn dữ liệu sản phẩm và sitemap thất bại: %v", err)
+		} else {
+			log.Printf("Đã tạo nguồn dữ liệu cho %d sản phẩm", items)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/service"
+)
+
+func StartImageReaperJob(productSvc service.ProductService, uploadSvc service.UploadService, staleAfter, interval time.Duration) {
+	if staleAfter <= 0 {
+		staleAfter = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		before := time.Now().Add(-staleAfter)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		images, err := productSvc.FailStaleImages(ctx, before)
+		if err != nil {
+			log.Printf("Đánh dấu hình ảnh xử lý quá hạn thất bại: %v", err)
+		} else if images > 0 {
+			log.Printf("Đã đánh dấu %d hình ảnh xử lý quá hạn là lỗi", images)
+		}
+
+		uploads, err := uploadSvc.ReapExpiredUploads(ctx, before)
+		if err != nil {
+			log.Printf("Dọn dẹp file tải lên không được sử dụng thất bại: %v", err)
+		} else if uploads > 0 {
+			log.Printf("Đã dọn dẹp %d file tải lên không được sử dụng", uploads)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/service"
+)
+
+func StartCoPurchaseJob(recommendationSvc service.RecommendationService, interval time.Duration) {
+	if interval <= 0 {
+		interval = 6 * time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		pairs, err := recommendationSvc.RebuildCoPurchases(ctx)
+		if err != nil {
+			log.Printf("Tính toán sản phẩm thường được mua cùng thất bại: %v", err)
+		} else {
+			log.Printf("Đã cập nhật %d cặp sản phẩm thường được mua cùng", pairs)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,49 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+func ToAccountDeletionResponse(user *model.User) *response.AccountDeletionResponse {
+	return &response.AccountDeletionResponse{
+		DeactivatedAt:       *user.DeactivatedAt,
+		DeletionScheduledAt: *user.DeletionScheduledAt,
+	}
+}
+
+func ToAccountExportResponse(user *model.User, addrs []*model.Address, cart *model.Cart, orders []*model.Order, reviews []*model.Review, auditLogs []*model.AuditLog, slots []*model.UploadSlot, assets []*model.MediaAsset) *response.AccountExportResponse {
+	addrsResp := make([]*response.AddressResponse, 0, len(addrs))
+	for _, addr := range addrs {
+		addrsResp = append(addrsResp, ToAddressResponse(addr))
+	}
+
+	var cartResp *response.CartResponse
+	if cart != nil {
+		cartResp = ToCartResponse(cart)
+	}
+
+	slotsResp := make([]*response.UploadSlotResponse, 0, len(slots))
+	for _, slot := range slots {
+		slotsResp = append(slotsResp, ToUploadSlotResponse(slot))
+	}
+
+	assetsResp := make([]*response.MediaAssetResponse, 0, len(assets))
+	for _, asset := range assets {
+		assetsResp = append(assetsResp, ToMediaAssetResponse(asset))
+	}
+
+	return &response.AccountExportResponse{
+		ExportedAt:  time.Now(),
+		User:        ToUserResponse(user),
+		Addresses:   addrsResp,
+		Cart:        cartResp,
+		Orders:      ToOrdersResponse(orders),
+		Reviews:     ToReviewsResponse(reviews),
+		AuditLogs:   ToAuditLogsResponse(auditLogs),
+		Uploads:     slotsResp,
+		MediaAssets: assetsResp,
+	}
+}
@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"encoding/json"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+func ToAuditLogResponse(auditLog *model.AuditLog) *response.AuditLogResponse {
+	changes := json.RawMessage("null")
+	if auditLog.Changes != "" {
+		changes = json.RawMessage(auditLog.Changes)
+	}
+
+	return &response.AuditLogResponse{
+		ID:         auditLog.ID,
+		ActorID:    auditLog.ActorID,
+		Action:     auditLog.Action,
+		EntityType: auditLog.EntityType,
+		EntityID:   auditLog.EntityID,
+		Changes:    changes,
+		CreatedAt:  auditLog.CreatedAt,
+	}
+}
+
+func ToAuditLogsResponse(auditLogs []*model.AuditLog) []*response.AuditLogResponse {
+	if len(auditLogs) == 0 {
+		return make([]*response.AuditLogResponse, 0)
+	}
+
+	auditLogsResp := make([]*response.AuditLogResponse, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		auditLogsResp = append(auditLogsResp, ToAuditLogResponse(auditLog))
+	}
+
+	return auditLogsResp
+}
@@ -7,20 +7,21 @@ import (
 
 func ToCartResponse(cart *model.Cart) *response.CartResponse {
 	return &response.CartResponse{
-		ID: cart.ID,
+		ID:            cart.ID,
 		TotalQuantity: cart.TotalQuantity,
-		TotalPrice: cart.TotalPrice,
-		CartItems: ToCartItemsResponse(cart.CartItems),
+		TotalPrice:    cart.TotalPrice,
+		CartItems:     ToCartItemsResponse(cart.CartItems),
 	}
 }
 
 func ToCartItemResponse(cartItem *model.CartItem) *response.CartItemResponse {
 	return &response.CartItemResponse{
-		ID: cartItem.ID,
-		UnitPrice: cartItem.UnitPrice,
-		Quantity: cartItem.Quantity,
+		ID:         cartItem.ID,
+		UnitPrice:  cartItem.UnitPrice,
+		Quantity:   cartItem.Quantity,
 		TotalPrice: cartItem.TotalPrice,
-		Product: ToSimpleProductResponse(cartItem.Product),
+		Product:    ToSimpleProductResponse(cartItem.Product),
+		Variant:    ToBaseVariantResponse(cartItem.Variant),
 	}
 }
 
@@ -31,8 +32,11 @@ func ToCartItemsResponse(cIts []*model.CartItem) []*response.CartItemResponse {
 
 	cItsResp := make([]*response.CartItemResponse, 0, len(cIts))
 	for _, cIt := range cIts {
+		if cIt.Product == nil {
+			continue
+		}
 		cItsResp = append(cItsResp, ToCartItemResponse(cIt))
 	}
 
 	return cItsResp
-}
\ No newline at end of file
+}
@@ -10,12 +10,19 @@ func ToCategoryResponse(category *model.Category) *response.CategoryResponse {
 		ID:        category.ID,
 		Name:      category.Name,
 		Slug:      category.Slug,
+		ParentID:  category.ParentID,
+		Depth:     category.Depth,
+		Image:     ToMediaImageResponse(category.ImageAsset),
 		CreatedAt: category.CreatedAt,
 		UpdatedAt: category.UpdatedAt,
 	}
 }
 
 func ToBaseCategoryResponse(category *model.Category) *response.BaseCategoryResponse {
+	if category == nil {
+		return nil
+	}
+
 	return &response.BaseCategoryResponse{
 		ID:   category.ID,
 		Name: category.Name,
@@ -23,15 +30,89 @@ func ToBaseCategoryResponse(category *model.Category) *response.BaseCategoryResp
 	}
 }
 
-func ToCategoriesResponse(ctgs []*model.Category) []*response.BaseCategoryResponse {
+func ToCategoryTreeResponse(ctgs []*model.Category) []*response.CategoryTreeResponse {
 	if len(ctgs) == 0 {
-		return make([]*response.BaseCategoryResponse, 0)
+		return make([]*response.CategoryTreeResponse, 0)
 	}
 
-	ctgsResp := make([]*response.BaseCategoryResponse, 0, len(ctgs))
+	ctgsResp := make([]*response.CategoryTreeResponse, 0, len(ctgs))
 	for _, ctg := range ctgs {
-		ctgsResp = append(ctgsResp, ToBaseCategoryResponse(ctg))
+		ctgsResp = append(ctgsResp, &response.CategoryTreeResponse{
+			ID:       ctg.ID,
+			Name:     ctg.Name,
+			Slug:     ctg.Slug,
+			Depth:    ctg.Depth,
+			Image:    ToMediaImageResponse(ctg.ImageAsset),
+			Children: ToCategoryTreeResponse(ctg.Children),
+		})
 	}
 
 	return ctgsResp
 }
+
+func ToProductCategoryResponse(category *model.Category) *response.ProductCategoryResponse {
+	if category == nil {
+		return nil
+	}
+
+	breadcrumb := make([]*response.BaseCategoryResponse, 0, len(category.Ancestors)+1)
+	for _, ancestor := range category.Ancestors {
+		breadcrumb = append(breadcrumb, ToBaseCategoryResponse(ancestor))
+	}
+	breadcrumb = append(breadcrumb, ToBaseCategoryResponse(category))
+
+	return &response.ProductCategoryResponse{
+		ID:         category.ID,
+		Name:       category.Name,
+		Slug:       category.Slug,
+		Breadcrumb: breadcrumb,
+	}
+}
+
+func ToDeletedCategoriesResponse(ctgs []*model.Category) []*response.DeletedCategoryResponse {
+	if len(ctgs) == 0 {
+		return make([]*response.DeletedCategoryResponse, 0)
+	}
+
+	ctgsResp := make([]*response.DeletedCategoryResponse, 0, len(ctgs))
+	for _, ctg := range ctgs {
+		ctgsResp = append(ctgsResp, &response.DeletedCategoryResponse{
+			ID:        ctg.ID,
+			Name:      ctg.Name,
+			Slug:      ctg.Slug,
+			DeletedAt: ctg.DeletedAt.Time,
+		})
+	}
+
+	return ctgsResp
+}
+
+func ToCategoryAttributeResponse(attribute *model.CategoryAttribute) *response.CategoryAttributeResponse {
+	options := attribute.Options
+	if options == nil {
+		options = make([]string, 0)
+	}
+
+	return &response.CategoryAttributeResponse{
+		ID:         attribute.ID,
+		Name:       attribute.Name,
+		Type:       attribute.Type,
+		Unit:       attribute.Unit,
+		Options:    options,
+		IsRequired: attribute.IsRequired,
+		Position:   attribute.Position,
+	}
+}
+
+func ToCategoryAttributesResponse(attributes []*model.CategoryAttribute) []*response.CategoryAttributeResponse {
+	if len(attributes) == 0 {
+		return make([]*response.CategoryAttributeResponse, 0)
+	}
+
+	attributesResp := make([]*response.CategoryAttributeResponse, 0, len(attributes))
+	for _, attribute := range attributes {
+		attributesResp = append(attributesResp, ToCategoryAttributeResponse(attribute))
+	}
+
+	return attributesResp
+}
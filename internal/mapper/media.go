@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+func ToMediaAssetResponse(asset *model.MediaAsset) *response.MediaAssetResponse {
+	return &response.MediaAssetResponse{
+		ID:               asset.ID,
+		Key:              asset.Key,
+		FileName:         asset.FileName,
+		ContentType:      asset.ContentType,
+		Url:              asset.Url,
+		Srcset:           asset.Srcset,
+		Width:            asset.Width,
+		Height:           asset.Height,
+		PlaceholderColor: asset.PlaceholderColor,
+		Status:           asset.Status,
+		Tags:             asset.TagNames(),
+		CreatedAt:        asset.CreatedAt,
+		UpdatedAt:        asset.UpdatedAt,
+	}
+}
+
+func ToMediaAssetListResponse(assets []*model.MediaAsset, meta *response.MetaResponse) *response.MediaAssetListResponse {
+	assetsResp := make([]*response.MediaAssetResponse, 0, len(assets))
+	for _, asset := range assets {
+		assetsResp = append(assetsResp, ToMediaAssetResponse(asset))
+	}
+
+	return &response.MediaAssetListResponse{
+		Assets: assetsResp,
+		Meta:   meta,
+	}
+}
+
+func ToMediaImageResponse(asset *model.MediaAsset) *response.MediaImageResponse {
+	if asset == nil || asset.Status != common.ImageStatusReady {
+		return nil
+	}
+
+	return &response.MediaImageResponse{
+		ID:               asset.ID,
+		Url:              asset.Url,
+		Srcset:           asset.Srcset,
+		Width:            asset.Width,
+		Height:           asset.Height,
+		PlaceholderColor: asset.PlaceholderColor,
+	}
+}
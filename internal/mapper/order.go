@@ -0,0 +1,44 @@
+package mapper
+
+import (
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+func ToOrderResponse(order *model.Order) *response.OrderResponse {
+	itemsResp := make([]*response.OrderItemResponse, 0, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		itemsResp = append(itemsResp, &response.OrderItemResponse{
+			ID:         item.ID,
+			ProductID:  item.ProductID,
+			VariantID:  item.VariantID,
+			SKU:        item.SKU,
+			UnitPrice:  item.UnitPrice,
+			Quantity:   item.Quantity,
+			TotalPrice: item.TotalPrice,
+		})
+	}
+
+	return &response.OrderResponse{
+		ID:            order.ID,
+		FullName:      order.FullName,
+		PhoneNumber:   order.PhoneNumber,
+		Address:       order.Address,
+		Commune:       order.Commune,
+		Province:      order.Province,
+		TotalPrice:    order.TotalPrice,
+		TotalQuantity: order.TotalQuantity,
+		PaymentMethod: order.PaymentMethod,
+		Status:        order.Status,
+		Items:         itemsResp,
+	}
+}
+
+func ToOrdersResponse(orders []*model.Order) []*response.OrderResponse {
+	ordersResp := make([]*response.OrderResponse, 0, len(orders))
+	for _, order := range orders {
+		ordersResp = append(ordersResp, ToOrderResponse(order))
+	}
+
+	return ordersResp
+}
@@ -0,0 +1,44 @@
+package mapper
+
+import (
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+func ToPermissionNames(rolePermissions []*model.RolePermission) []string {
+	permissions := make([]string, 0, len(rolePermissions))
+	for _, rp := range rolePermissions {
+		permissions = append(permissions, rp.Permission)
+	}
+
+	return permissions
+}
+
+func ToRolePermissionsResponse(role string, rolePermissions []*model.RolePermission) *response.RolePermissionsResponse {
+	if role == common.RoleAdmin {
+		return &response.RolePermissionsResponse{
+			Role:        role,
+			Permissions: common.AllPermissions,
+		}
+	}
+
+	return &response.RolePermissionsResponse{
+		Role:        role,
+		Permissions: ToPermissionNames(rolePermissions),
+	}
+}
+
+func ToRolesPermissionsResponse(rolePermissions []*model.RolePermission) []*response.RolePermissionsResponse {
+	grouped := make(map[string][]*model.RolePermission, len(common.AllRoles))
+	for _, rp := range rolePermissions {
+		grouped[rp.Role] = append(grouped[rp.Role], rp)
+	}
+
+	rolesResp := make([]*response.RolePermissionsResponse, 0, len(common.AllRoles))
+	for _, role := range common.AllRoles {
+		rolesResp = append(rolesResp, ToRolePermissionsResponse(role, grouped[role]))
+	}
+
+	return rolesResp
+}
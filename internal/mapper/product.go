@@ -1,23 +1,37 @@
 package mapper
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"github.com/tienhai2808/ecom_go/internal/response"
+	"github.com/tienhai2808/ecom_go/internal/types"
 )
 
 func ToProductResponse(product *model.Product) *response.ProductResponse {
 	return &response.ProductResponse{
-		ID:          product.ID,
-		Name:        product.Name,
-		Slug:        product.Slug,
-		Description: product.Description,
-		Price:       product.Price,
-		IsActive:    product.IsActive,
-		CreatedAt:   product.CreatedAt,
-		UpdatedAt:   product.UpdatedAt,
-		Category:    ToBaseCategoryResponse(product.Category),
-		Inventory:   ToInventoryResponse(product.Inventory),
-		Images:      ToImagesResponse(product.Images),
+		ID:             product.ID,
+		Name:           product.Name,
+		Slug:           product.Slug,
+		Description:    product.Description,
+		Price:          product.Price,
+		IsActive:       product.IsActive,
+		Status:         product.Status,
+		PublishAt:      product.PublishAt,
+		UnpublishAt:    product.UnpublishAt,
+		RatingAverage:  product.RatingAverage,
+		RatingCount:    product.RatingCount,
+		CreatedAt:      product.CreatedAt,
+		UpdatedAt:      product.UpdatedAt,
+		Category:       ToProductCategoryResponse(product.Category),
+		Inventory:      ToInventoryResponse(product.Inventory),
+		Images:         ToImagesResponse(product.Images),
+		Options:        ToProductOptionsResponse(product.Options),
+		Variants:       ToProductVariantsResponse(product.Variants, product.Price, product.Images),
+		Specifications: ToSpecificationsResponse(product.Attributes),
 	}
 }
 
@@ -28,7 +42,10 @@ func ToBaseProductResponse(product *model.Product) *response.BaseProductResponse
 		Slug: product.Slug,
 		Price: product.Price,
 		IsActive: product.IsActive,
-		Thumbnail: product.Images[0].Url,
+		Status: product.Status,
+		RatingAverage: product.RatingAverage,
+		RatingCount: product.RatingCount,
+		Thumbnail: thumbnailURL(product.Images),
 	}
 }
 
@@ -38,7 +55,7 @@ func ToSimpleProductResponse(product *model.Product) *response.SimpleProductResp
 		Name: product.Name,
 		Slug: product.Slug,
 		Category: ToBaseCategoryResponse(product.Category),
-		Thumbnail: product.Images[0].Url,
+		Thumbnail: thumbnailURL(product.Images),
 	}
 }
 
@@ -55,14 +72,154 @@ func ToBaseProductsResponse(prds []*model.Product) []*response.BaseProductRespon
 	return prdsResp
 }
 
-func ToProductListResponse(prds []*model.Product, meta *response.MetaResponse) *response.ProductListResponse {
+func ToProductListResponse(prds []*model.Product, meta *response.MetaResponse, facets *response.ProductFacetsResponse) *response.ProductListResponse {
 	return &response.ProductListResponse{
 		Products: ToBaseProductsResponse(prds),
-		Meta: meta,
+		Meta:     meta,
+		Facets:   facets,
+	}
+}
+
+func ToProductSuggestionResponse(docs []*types.SuggestionDocument, limit int, didYouMean string) *response.ProductSuggestionResponse {
+	resp := &response.ProductSuggestionResponse{
+		Products:   []*response.SuggestionItemResponse{},
+		Categories: []*response.SuggestionItemResponse{},
+	}
+	if didYouMean != "" {
+		resp.DidYouMean = &didYouMean
+	}
+
+	for _, doc := range docs {
+		item := &response.SuggestionItemResponse{
+			ID:   doc.ID,
+			Name: doc.Name,
+			Slug: doc.Slug,
+		}
+
+		switch doc.Type {
+		case common.SuggestionTypeProduct:
+			if len(resp.Products) < limit {
+				resp.Products = append(resp.Products, item)
+			}
+		case common.SuggestionTypeCategory:
+			if len(resp.Categories) < limit {
+				resp.Categories = append(resp.Categories, item)
+			}
+		}
+	}
+
+	return resp
+}
+
+func ToProductFacetsResponse(result *types.ProductSearchResult, ctgs []*model.Category, attributes []*model.CategoryAttribute) *response.ProductFacetsResponse {
+	ctgMap := make(map[int64]*model.Category, len(ctgs))
+	for _, ctg := range ctgs {
+		ctgMap[ctg.ID] = ctg
+	}
+
+	ctgsResp := make([]*response.CategoryFacetResponse, 0, len(result.CategoryCounts))
+	for _, count := range result.CategoryCounts {
+		ctg, ok := ctgMap[count.CategoryID]
+		if !ok {
+			continue
+		}
+
+		ctgsResp = append(ctgsResp, &response.CategoryFacetResponse{
+			ID:    ctg.ID,
+			Name:  ctg.Name,
+			Slug:  ctg.Slug,
+			Count: count.Count,
+		})
+	}
+
+	bucketsResp := make([]*response.PriceBucketResponse, 0, len(result.PriceBuckets))
+	for _, bucket := range result.PriceBuckets {
+		bucketsResp = append(bucketsResp, &response.PriceBucketResponse{
+			From:  bucket.From,
+			To:    bucket.To,
+			Count: bucket.Count,
+		})
+	}
+
+	valuesMap := make(map[int64][]*response.AttributeFacetValueResponse)
+	for _, count := range result.AttributeCounts {
+		valuesMap[count.AttributeID] = append(valuesMap[count.AttributeID], &response.AttributeFacetValueResponse{
+			Value: count.Value,
+			Count: count.Count,
+		})
+	}
+
+	attributesResp := make([]*response.AttributeFacetResponse, 0, len(attributes))
+	for _, attribute := range attributes {
+		values, ok := valuesMap[attribute.ID]
+		if !ok {
+			continue
+		}
+
+		attributesResp = append(attributesResp, &response.AttributeFacetResponse{
+			ID:     attribute.ID,
+			Name:   attribute.Name,
+			Type:   attribute.Type,
+			Unit:   attribute.Unit,
+			Values: values,
+		})
+	}
+
+	return &response.ProductFacetsResponse{
+		Categories:     ctgsResp,
+		PriceHistogram: bucketsResp,
+		PriceMin:       result.PriceMin,
+		PriceMax:       result.PriceMax,
+		Attributes:     attributesResp,
+	}
+}
+
+func ToDeletedProductsResponse(prds []*model.Product) []*response.DeletedProductResponse {
+	if len(prds) == 0 {
+		return make([]*response.DeletedProductResponse, 0)
 	}
+
+	prdsResp := make([]*response.DeletedProductResponse, 0, len(prds))
+	for _, prd := range prds {
+		prdsResp = append(prdsResp, &response.DeletedProductResponse{
+			ID:        prd.ID,
+			Name:      prd.Name,
+			Slug:      prd.Slug,
+			Price:     prd.Price,
+			DeletedAt: prd.DeletedAt.Time,
+		})
+	}
+
+	return prdsResp
+}
+
+func ToImageStatusesResponse(imgs []*model.Image) []*response.ImageStatusResponse {
+	if len(imgs) == 0 {
+		return make([]*response.ImageStatusResponse, 0)
+	}
+
+	imgsResp := make([]*response.ImageStatusResponse, 0, len(imgs))
+	for _, img := range imgs {
+		imgsResp = append(imgsResp, &response.ImageStatusResponse{
+			ID:        img.ID,
+			ProductID: img.ProductID,
+			VariantID: img.VariantID,
+			Key:       img.PublicID,
+			Status:    img.Status,
+			SortOrder: img.SortOrder,
+			CreatedAt: img.CreatedAt,
+			UpdatedAt: img.UpdatedAt,
+		})
+	}
+
+	return imgsResp
 }
 
 func ToInventoryResponse(inv *model.Inventory) *response.InventoryResponse {
+	if inv == nil {
+		return nil
+	}
+
 	return &response.InventoryResponse{
 		ID:        inv.ID,
 		Quantity:  inv.Quantity,
@@ -73,11 +230,20 @@ func ToInventoryResponse(inv *model.Inventory) *response.InventoryResponse {
 }
 
 func ToImageResponse(img *model.Image) *response.ImageResponse {
+	srcset := make(map[string]string, len(img.Srcset))
+	for name, url := range img.Srcset {
+		srcset[name] = url
+	}
+
 	return &response.ImageResponse{
-		ID:          img.ID,
-		Url:         img.Url,
-		IsThumbnail: img.IsThumbnail,
-		SortOrder:   img.SortOrder,
+		ID:               img.ID,
+		Url:              img.Url,
+		Srcset:           srcset,
+		Width:            img.Width,
+		Height:           img.Height,
+		PlaceholderColor: img.PlaceholderColor,
+		IsThumbnail:      img.IsThumbnail,
+		SortOrder:        img.SortOrder,
 	}
 }
 
@@ -88,8 +254,270 @@ func ToImagesResponse(imgs []*model.Image) []*response.ImageResponse {
 
 	imgsResp := make([]*response.ImageResponse, 0, len(imgs))
 	for _, img := range imgs {
+		if img.Status != common.ImageStatusReady {
+			continue
+		}
 		imgsResp = append(imgsResp, ToImageResponse(img))
 	}
 
 	return imgsResp
 }
+
+func thumbnailURL(imgs []*model.Image) string {
+	url := ""
+	for _, img := range imgs {
+		if img.Status != common.ImageStatusReady {
+			continue
+		}
+		if img.IsThumbnail {
+			return img.Url
+		}
+		if url == "" {
+			url = img.Url
+		}
+	}
+
+	return url
+}
+
+func ToProductOptionsResponse(opts []*model.ProductOption) []*response.ProductOptionResponse {
+	if len(opts) == 0 {
+		return make([]*response.ProductOptionResponse, 0)
+	}
+
+	sort.Slice(opts, func(i, j int) bool {
+		return opts[i].Position < opts[j].Position
+	})
+
+	optsResp := make([]*response.ProductOptionResponse, 0, len(opts))
+	for _, opt := range opts {
+		sort.Slice(opt.Values, func(i, j int) bool {
+			return opt.Values[i].Position < opt.Values[j].Position
+		})
+
+		valuesResp := make([]*response.ProductOptionValueResponse, 0, len(opt.Values))
+		for _, val := range opt.Values {
+			valuesResp = append(valuesResp, &response.ProductOptionValueResponse{
+				ID:    val.ID,
+				Value: val.Value,
+			})
+		}
+
+		optsResp = append(optsResp, &response.ProductOptionResponse{
+			ID:     opt.ID,
+			Name:   opt.Name,
+			Values: valuesResp,
+		})
+	}
+
+	return optsResp
+}
+
+func ToProductVariantResponse(variant *model.ProductVariant, basePrice float64, imgs []*model.Image) *response.ProductVariantResponse {
+	variantImgs := make([]*model.Image, 0)
+	for _, img := range imgs {
+		if img.VariantID != nil && *img.VariantID == variant.ID {
+			variantImgs = append(variantImgs, img)
+		}
+	}
+
+	return &response.ProductVariantResponse{
+		ID:            variant.ID,
+		SKU:           variant.SKU,
+		Price:         variant.EffectivePrice(basePrice),
+		PriceOverride: variant.Price,
+		IsActive:      variant.IsActive,
+		Options:       ToVariantOptionsMap(variant.OptionValues),
+		Inventory:     ToInventoryResponse(variant.Inventory),
+		Images:        ToImagesResponse(variantImgs),
+	}
+}
+
+func ToProductVariantsResponse(variants []*model.ProductVariant, basePrice float64, imgs []*model.Image) []*response.ProductVariantResponse {
+	if len(variants) == 0 {
+		return make([]*response.ProductVariantResponse, 0)
+	}
+
+	variantsResp := make([]*response.ProductVariantResponse, 0, len(variants))
+	for _, variant := range variants {
+		variantsResp = append(variantsResp, ToProductVariantResponse(variant, basePrice, imgs))
+	}
+
+	return variantsResp
+}
+
+func ToBaseVariantResponse(variant *model.ProductVariant) *response.BaseVariantResponse {
+	if variant == nil {
+		return nil
+	}
+
+	return &response.BaseVariantResponse{
+		ID:      variant.ID,
+		SKU:     variant.SKU,
+		Options: ToVariantOptionsMap(variant.OptionValues),
+	}
+}
+
+func ToVariantOptionsMap(values []*model.ProductOptionValue) map[string]string {
+	options := make(map[string]string, len(values))
+	for _, val := range values {
+		if val.Option != nil {
+			options[val.Option.Name] = val.Value
+		}
+	}
+
+	return options
+}
+
+func ToSpecificationsResponse(values []*model.ProductAttributeValue) []*response.SpecificationResponse {
+	specsValues := make([]*model.ProductAttributeValue, 0, len(values))
+	for _, value := range values {
+		if value.Attribute != nil {
+			specsValues = append(specsValues, value)
+		}
+	}
+
+	sort.SliceStable(specsValues, func(i, j int) bool {
+		if specsValues[i].Attribute.Position != specsValues[j].Attribute.Position {
+			return specsValues[i].Attribute.Position < specsValues[j].Attribute.Position
+		}
+		return specsValues[i].Attribute.ID < specsValues[j].Attribute.ID
+	})
+
+	specsResp := make([]*response.SpecificationResponse, 0, len(specsValues))
+	for _, value := range specsValues {
+		attribute := value.Attribute
+
+		var typedValue any = value.Value
+		display := value.Value
+		switch attribute.Type {
+		case common.AttributeTypeNumber:
+			if number, err := strconv.ParseFloat(value.Value, 64); err == nil {
+				typedValue = number
+			}
+			if attribute.Unit != nil && *attribute.Unit != "" {
+				display = fmt.Sprintf("%s %s", value.Value, *attribute.Unit)
+			}
+		case common.AttributeTypeBoolean:
+			boolean, _ := strconv.ParseBool(value.Value)
+			typedValue = boolean
+			display = "Không"
+			if boolean {
+				display = "Có"
+			}
+		}
+
+		specsResp = append(specsResp, &response.SpecificationResponse{
+			AttributeID: attribute.ID,
+			Name:        attribute.Name,
+			Type:        attribute.Type,
+			Value:       typedValue,
+			Unit:        attribute.Unit,
+			Display:     display,
+		})
+	}
+
+	return specsResp
+}
+
+func ToStorefrontProductResponse(product *model.Product) *response.StorefrontProductResponse {
+	productImgs := make([]*model.Image, 0, len(product.Images))
+	for _, img := range product.Images {
+		if img.VariantID == nil {
+			productImgs = append(productImgs, img)
+		}
+	}
+
+	effectivePrice := product.Price
+	variantsResp := make([]*response.StorefrontVariantResponse, 0, len(product.Variants))
+	for i, variant := range product.Variants {
+		price := variant.EffectivePrice(product.Price)
+		if i == 0 || price < effectivePrice {
+			effectivePrice = price
+		}
+
+		variantImgs := make([]*model.Image, 0)
+		for _, img := range product.Images {
+			if img.VariantID != nil && *img.VariantID == variant.ID {
+				variantImgs = append(variantImgs, img)
+			}
+		}
+
+		stockStatus := ToStockStatus(variant.Inventory)
+		variantsResp = append(variantsResp, &response.StorefrontVariantResponse{
+			ID:          variant.ID,
+			SKU:         variant.SKU,
+			Price:       price,
+			InStock:     stockStatus != common.StockStatusOutOfStock,
+			StockStatus: stockStatus,
+			Options:     ToVariantOptionsMap(variant.OptionValues),
+			Images:      ToImagesResponse(variantImgs),
+		})
+	}
+
+	stockStatus := ToStockStatus(product.Inventory)
+
+	return &response.StorefrontProductResponse{
+		ID:             product.ID,
+		Name:           product.Name,
+		Slug:           product.Slug,
+		Description:    product.Description,
+		Price:          product.Price,
+		EffectivePrice: effectivePrice,
+		InStock:        stockStatus != common.StockStatusOutOfStock,
+		StockStatus:    stockStatus,
+		RatingAverage:  product.RatingAverage,
+		RatingCount:    product.RatingCount,
+		Category:       ToProductCategoryResponse(product.Category),
+		Images:         ToImagesResponse(productImgs),
+		Options:        ToProductOptionsResponse(product.Options),
+		Variants:       variantsResp,
+		Specifications: ToSpecificationsResponse(product.Attributes),
+	}
+}
+
+func ToStockStatus(inv *model.Inventory) string {
+	switch {
+	case inv == nil || inv.Stock == 0:
+		return common.StockStatusOutOfStock
+	case inv.Stock <= 5:
+		return common.StockStatusLowStock
+	default:
+		return common.StockStatusInStock
+	}
+}
+
+func ToRelatedProductsResponse(related, boughtTogether []*model.Product) *response.RelatedProductsResponse {
+	return &response.RelatedProductsResponse{
+		Related:                  ToBaseProductsResponse(related),
+		FrequentlyBoughtTogether: ToBaseProductsResponse(boughtTogether),
+	}
+}
+
+func ToBulkUpdateProductsResponse(result *types.BulkUpdateResult) *response.BulkUpdateProductsResponse {
+	productsResp := make([]*response.BulkProductChangeResponse, 0, len(result.Products))
+	for _, product := range result.Products {
+		changesResp := make([]*response.BulkFieldChangeResponse, 0, len(product.Changes))
+		for _, change := range product.Changes {
+			changesResp = append(changesResp, &response.BulkFieldChangeResponse{
+				Field: change.Field,
+				From:  change.From,
+				To:    change.To,
+			})
+		}
+
+		productsResp = append(productsResp, &response.BulkProductChangeResponse{
+			ProductID: product.ProductID,
+			Name:      product.Name,
+			Changes:   changesResp,
+			Errors:    product.Errors,
+		})
+	}
+
+	return &response.BulkUpdateProductsResponse{
+		DryRun:   result.DryRun,
+		Matched:  result.Matched,
+		Changed:  result.Changed,
+		Products: productsResp,
+	}
+}
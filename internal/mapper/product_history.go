@@ -0,0 +1,105 @@
+package mapper
+
+import (
+	"reflect"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+func ToProductSnapshotResponse(snapshot *model.ProductSnapshot) *response.ProductSnapshotResponse {
+	if snapshot == nil {
+		return nil
+	}
+
+	imagesResp := make([]*response.ImageSnapshotResponse, 0, len(snapshot.Images))
+	for _, img := range snapshot.Images {
+		imagesResp = append(imagesResp, &response.ImageSnapshotResponse{
+			ID:          img.ID,
+			Url:         img.Url,
+			IsThumbnail: img.IsThumbnail,
+			SortOrder:   img.SortOrder,
+			Status:      img.Status,
+		})
+	}
+
+	snapshotResp := &response.ProductSnapshotResponse{
+		Name:        snapshot.Name,
+		Slug:        snapshot.Slug,
+		Price:       snapshot.Price,
+		Description: snapshot.Description,
+		IsActive:    snapshot.IsActive,
+		Status:      snapshot.Status,
+		PublishAt:   snapshot.PublishAt,
+		UnpublishAt: snapshot.UnpublishAt,
+		CategoryID:  snapshot.CategoryID,
+		DeletedAt:   snapshot.DeletedAt,
+		Images:      imagesResp,
+	}
+	if snapshot.Inventory != nil {
+		snapshotResp.Inventory = &response.InventorySnapshotResponse{
+			Quantity:  snapshot.Inventory.Quantity,
+			Purchased: snapshot.Inventory.Purchased,
+			Stock:     snapshot.Inventory.Stock,
+		}
+	}
+
+	return snapshotResp
+}
+
+func ToProductHistoryResponse(history *model.ProductHistory) *response.ProductHistoryResponse {
+	before := ToProductSnapshotResponse(history.Before)
+	after := ToProductSnapshotResponse(history.After)
+
+	return &response.ProductHistoryResponse{
+		ID:            history.ID,
+		Version:       history.Version,
+		Action:        history.Action,
+		Before:        before,
+		After:         after,
+		Changes:       toProductSnapshotChanges(before, after),
+		ActorID:       history.ActorID,
+		ActorUsername: history.ActorUsername,
+		CreatedAt:     history.CreatedAt,
+	}
+}
+
+func ToProductHistoryListResponse(histories []*model.ProductHistory, meta *response.MetaResponse) *response.ProductHistoryListResponse {
+	historiesResp := make([]*response.ProductHistoryResponse, 0, len(histories))
+	for _, history := range histories {
+		historiesResp = append(historiesResp, ToProductHistoryResponse(history))
+	}
+
+	return &response.ProductHistoryListResponse{
+		Histories: historiesResp,
+		Meta:      meta,
+	}
+}
+
+func toProductSnapshotChanges(before, after *response.ProductSnapshotResponse) []*response.BulkFieldChangeResponse {
+	changes := make([]*response.BulkFieldChangeResponse, 0)
+	if before == nil || after == nil {
+		return changes
+	}
+
+	addChange := func(field string, from, to any) {
+		if !reflect.DeepEqual(from, to) {
+			changes = append(changes, &response.BulkFieldChangeResponse{Field: field, From: from, To: to})
+		}
+	}
+
+	addChange("name", before.Name, after.Name)
+	addChange("slug", before.Slug, after.Slug)
+	addChange("price", before.Price, after.Price)
+	addChange("description", before.Description, after.Description)
+	addChange("is_active", before.IsActive, after.IsActive)
+	addChange("status", before.Status, after.Status)
+	addChange("publish_at", before.PublishAt, after.PublishAt)
+	addChange("unpublish_at", before.UnpublishAt, after.UnpublishAt)
+	addChange("category_id", before.CategoryID, after.CategoryID)
+	addChange("deleted_at", before.DeletedAt, after.DeletedAt)
+	addChange("inventory", before.Inventory, after.Inventory)
+	addChange("images", before.Images, after.Images)
+
+	return changes
+}
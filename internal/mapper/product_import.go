@@ -0,0 +1,59 @@
+package mapper
+
+import (
+	"math"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+func ToProductImportJobResponse(job *model.ProductImportJob) *response.ProductImportJobResponse {
+	var progress float64
+	if job.TotalRows > 0 {
+		progress = math.Round(float64(job.ProcessedRows)/float64(job.TotalRows)*10000) / 100
+	}
+
+	var rowErrors []*response.ImportRowErrorResponse
+	if len(job.RowErrors) > 0 {
+		rowErrors = make([]*response.ImportRowErrorResponse, 0, len(job.RowErrors))
+		for _, rowErr := range job.RowErrors {
+			rowErrors = append(rowErrors, &response.ImportRowErrorResponse{
+				Row:    rowErr.Row,
+				Slug:   rowErr.Slug,
+				Errors: rowErr.Errors,
+			})
+		}
+	}
+
+	return &response.ProductImportJobResponse{
+		ID:            job.ID,
+		FileName:      job.FileName,
+		Format:        job.Format,
+		Upsert:        job.Upsert,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		CreatedCount:  job.CreatedCount,
+		UpdatedCount:  job.UpdatedCount,
+		FailedCount:   job.FailedCount,
+		Progress:      progress,
+		Message:       job.Message,
+		RowErrors:     rowErrors,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+		CreatedAt:     job.CreatedAt,
+		UserID:        job.UserID,
+	}
+}
+
+func ToProductImportJobListResponse(jobs []*model.ProductImportJob, meta *response.MetaResponse) *response.ProductImportJobListResponse {
+	jobsResp := make([]*response.ProductImportJobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		jobsResp = append(jobsResp, ToProductImportJobResponse(job))
+	}
+
+	return &response.ProductImportJobListResponse{
+		Jobs: jobsResp,
+		Meta: meta,
+	}
+}
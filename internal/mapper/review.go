@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+func ToReviewResponse(review *model.Review) *response.ReviewResponse {
+	var user *response.ReviewUserResponse
+	if review.User != nil {
+		user = &response.ReviewUserResponse{
+			ID:       review.User.ID,
+			Username: review.User.Username,
+		}
+	}
+
+	return &response.ReviewResponse{
+		ID:        review.ID,
+		Rating:    review.Rating,
+		Title:     review.Title,
+		Body:      review.Body,
+		Status:    review.Status,
+		ProductID: review.ProductID,
+		User:      user,
+		Images:    ToReviewImagesResponse(review.Images),
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+}
+
+func ToReviewImagesResponse(images []*model.ReviewImage) []*response.ReviewImageResponse {
+	imagesResp := make([]*response.ReviewImageResponse, 0, len(images))
+	for _, image := range images {
+		if image.Status != common.ImageStatusReady {
+			continue
+		}
+
+		imagesResp = append(imagesResp, &response.ReviewImageResponse{
+			ID:        image.ID,
+			Url:       image.Url,
+			SortOrder: image.SortOrder,
+		})
+	}
+
+	return imagesResp
+}
+
+func ToReviewsResponse(reviews []*model.Review) []*response.ReviewResponse {
+	if len(reviews) == 0 {
+		return make([]*response.ReviewResponse, 0)
+	}
+
+	reviewsResp := make([]*response.ReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		reviewsResp = append(reviewsResp, ToReviewResponse(review))
+	}
+
+	return reviewsResp
+}
+
+func ToReviewListResponse(reviews []*model.Review, meta *response.MetaResponse) *response.ReviewListResponse {
+	return &response.ReviewListResponse{
+		Reviews: ToReviewsResponse(reviews),
+		Meta:    meta,
+	}
+}
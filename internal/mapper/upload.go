@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/response"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+func ToUploadSlotResponse(slot *model.UploadSlot) *response.UploadSlotResponse {
+	return &response.UploadSlotResponse{
+		ID:          slot.ID,
+		Key:         slot.Key,
+		FileName:    slot.FileName,
+		ContentType: slot.ContentType,
+		Size:        slot.Size,
+		Status:      slot.Status,
+		ExpiresAt:   slot.ExpiresAt,
+	}
+}
+
+func ToUploadTargetsResponse(targets []*types.UploadTarget) []*response.UploadSlotResponse {
+	slotsResp := make([]*response.UploadSlotResponse, 0, len(targets))
+	for _, target := range targets {
+		slotResp := ToUploadSlotResponse(target.Slot)
+		slotResp.Method = target.Method
+		slotResp.UploadURL = target.URL
+		slotResp.Headers = target.Headers
+		slotsResp = append(slotsResp, slotResp)
+	}
+
+	return slotsResp
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+type CategoryAttribute struct {
+	ID         int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	Name       string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_category_attribute_name" json:"name"`
+	Type       string    `gorm:"type:enum('text','number','enum','boolean');not null" json:"type"`
+	Unit       *string   `gorm:"type:varchar(20)" json:"unit"`
+	Options    []string  `gorm:"type:json;serializer:json" json:"options"`
+	IsRequired bool      `gorm:"type:boolean;not null;default:false" json:"is_required"`
+	Position   int       `gorm:"type:int;not null;default:0" json:"position"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	CategoryID int64     `gorm:"type:bigint;not null;uniqueIndex:idx_category_attribute_name" json:"category_id"`
+
+	Category *Category `gorm:"foreignKey:CategoryID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"category"`
+}
+
+type ProductAttributeValue struct {
+	ID          int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	Value       string `gorm:"type:varchar(255);not null" json:"value"`
+	ProductID   int64  `gorm:"type:bigint;not null;uniqueIndex:idx_product_attribute" json:"product_id"`
+	AttributeID int64  `gorm:"type:bigint;not null;uniqueIndex:idx_product_attribute" json:"attribute_id"`
+
+	Product   *Product           `gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	Attribute *CategoryAttribute `gorm:"foreignKey:AttributeID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"attribute"`
+}
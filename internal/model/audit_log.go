@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type AuditLog struct {
+	ID         int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	ActorID    int64     `gorm:"type:bigint;not null;index" json:"actor_id"`
+	Action     string    `gorm:"type:varchar(50);not null" json:"action"`
+	EntityType string    `gorm:"type:varchar(50);not null;index:idx_audit_entity" json:"entity_type"`
+	EntityID   int64     `gorm:"type:bigint;not null;index:idx_audit_entity" json:"entity_id"`
+	Changes    string    `gorm:"type:text" json:"changes"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
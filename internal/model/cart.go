@@ -17,11 +17,13 @@ type CartItem struct {
 	TotalPrice float64 `gorm:"type:decimal(10,2);not null" json:"total_price"`
 	CartID     int64   `gorm:"type:bigint;not null" json:"cart_id"`
 	ProductID  int64   `gorm:"type:bigint;not null" json:"product_id"`
+	VariantID  *int64  `gorm:"type:bigint" json:"variant_id"`
 
-	Cart    *Cart    `gorm:"foreignKey:CartID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"cart"`
-	Product *Product `gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	Cart    *Cart           `gorm:"foreignKey:CartID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"cart"`
+	Product *Product        `gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	Variant *ProductVariant `gorm:"foreignKey:VariantID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"variant"`
 }
 
 func (m *CartItem) SetTotalPrice() {
 	m.TotalPrice = m.UnitPrice * float64(m.Quantity)
-}
\ No newline at end of file
+}
@@ -1,13 +1,51 @@
 package model
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Category struct {
-	ID        int64     `gorm:"type:bigint;primaryKey" json:"id"`
-	Name      string    `gorm:"type:varchar(150);not null" json:"name"`
-	Slug      string    `gorm:"type:varchar(150);not null;unique" json:"slug"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID           int64          `gorm:"type:bigint;primaryKey" json:"id"`
+	Name         string         `gorm:"type:varchar(150);not null" json:"name"`
+	Slug         string         `gorm:"type:varchar(150);not null;index" json:"slug"`
+	Path         string         `gorm:"type:varchar(2048);not null;default:''" json:"path"`
+	Depth        int            `gorm:"type:int;not null;default:0" json:"depth"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	LiveSlug     *string        `gorm:"type:varchar(150) GENERATED ALWAYS AS (IF(deleted_at IS NULL, slug, NULL)) STORED;uniqueIndex;->" json:"-"`
+	ParentID     *int64         `gorm:"type:bigint;index" json:"parent_id"`
+	ImageAssetID *int64         `gorm:"type:bigint;index" json:"image_asset_id"`
+
+	Parent     *Category            `gorm:"foreignKey:ParentID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"parent"`
+	Children   []*Category          `gorm:"foreignKey:ParentID" json:"children"`
+	Ancestors  []*Category          `gorm:"-" json:"ancestors"`
+	Products   []*Product           `gorm:"foreignKey:CategoryID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"products"`
+	Attributes []*CategoryAttribute `gorm:"foreignKey:CategoryID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"attributes"`
+	ImageAsset *MediaAsset          `gorm:"foreignKey:ImageAssetID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"image_asset"`
+}
+
+func (m *Category) AncestorIDs() []int64 {
+	ids := []int64{}
+	for _, part := range strings.Split(strings.Trim(m.Path, "/"), "/") {
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || id == m.ID {
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func CategoryPath(parent *Category, id int64) string {
+	if parent == nil {
+		return "/" + strconv.FormatInt(id, 10) + "/"
+	}
 
-	Products []*Product `gorm:"foreignKey:CategoryID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"products"`
+	return parent.Path + strconv.FormatInt(id, 10) + "/"
 }
@@ -1,12 +1,57 @@
 package model
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
 type Image struct {
-	ID          int64  `gorm:"type:bigint;primaryKey" json:"id"`
-	Url         string `gorm:"type:varchar(255)" json:"url"`
-	PublicID    string `gorm:"type:varchar(50)" json:"public_id"`
-	IsThumbnail bool   `gorm:"type:boolean;not null" json:"is_thumbnail"`
-	SortOrder   int    `gorm:"type:int;not null" json:"sort_order"`
-	ProductID   int64  `gorm:"type:bigint;not null" json:"product_id"`
-
-	Product *Product `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	ID               int64       `gorm:"type:bigint;primaryKey" json:"id"`
+	Url              string      `gorm:"type:varchar(255)" json:"url"`
+	PublicID         string      `gorm:"type:varchar(255)" json:"public_id"`
+	IsThumbnail      bool        `gorm:"type:boolean;not null" json:"is_thumbnail"`
+	SortOrder        int         `gorm:"type:int;not null" json:"sort_order"`
+	Width            int         `gorm:"type:int;not null;default:0" json:"width"`
+	Height           int         `gorm:"type:int;not null;default:0" json:"height"`
+	PlaceholderColor string      `gorm:"type:varchar(7)" json:"placeholder_color"`
+	Srcset           ImageSrcset `gorm:"type:json" json:"srcset"`
+	Status           string      `gorm:"type:enum('pending','ready','failed');default:'pending';not null;index" json:"status"`
+	CreatedAt        time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+	ProductID        int64       `gorm:"type:bigint;not null" json:"product_id"`
+	VariantID        *int64      `gorm:"type:bigint;index" json:"variant_id"`
+	AssetID          *int64      `gorm:"type:bigint;index" json:"asset_id"`
+
+	Product *Product        `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	Variant *ProductVariant `gorm:"foreignKey:VariantID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"variant"`
+	Asset   *MediaAsset     `gorm:"foreignKey:AssetID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"asset"`
+}
+
+type ImageSrcset map[string]string
+
+func (s ImageSrcset) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(s)
+}
+
+func (s *ImageSrcset) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("không thể chuyển đổi %T sang ImageSrcset", value)
+	}
+
+	return json.Unmarshal(data, s)
 }
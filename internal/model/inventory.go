@@ -1,17 +1,19 @@
 package model
 
 type Inventory struct {
-	ID        int64 `gorm:"type:int;primaryKey" json:"id"`
-	Quantity  uint  `gorm:"type:int;not null" json:"quantity"`
-	Purchased uint  `gorm:"type:int;not null" json:"purchased"`
-	Stock     uint  `gorm:"type:int;not null" json:"stock"`
-	IsStock   bool  `gorm:"type:boolean;not null" json:"is_stock"`
-	ProductID int64 `gorm:"type:int;not null;unique" json:"product_id"`
+	ID        int64  `gorm:"type:int;primaryKey" json:"id"`
+	Quantity  uint   `gorm:"type:int;not null" json:"quantity"`
+	Purchased uint   `gorm:"type:int;not null" json:"purchased"`
+	Stock     uint   `gorm:"type:int;not null" json:"stock"`
+	IsStock   bool   `gorm:"type:boolean;not null" json:"is_stock"`
+	ProductID *int64 `gorm:"type:bigint;unique" json:"product_id"`
+	VariantID *int64 `gorm:"type:bigint;unique" json:"variant_id"`
 
-	Product *Product `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	Product *Product        `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	Variant *ProductVariant `gorm:"foreignKey:VariantID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"variant"`
 }
 
-func(m *Inventory) SetStock() {
+func (m *Inventory) SetStock() {
 	m.Stock = m.Quantity - m.Purchased
 	if m.Stock <= 5 {
 		m.IsStock = false
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+type MediaAsset struct {
+	ID               int64       `gorm:"type:bigint;primaryKey" json:"id"`
+	Key              string      `gorm:"type:varchar(255);not null;uniqueIndex" json:"key"`
+	FileName         string      `gorm:"type:varchar(255);not null;index" json:"file_name"`
+	ContentType      string      `gorm:"type:varchar(100);not null" json:"content_type"`
+	Url              string      `gorm:"type:varchar(255)" json:"url"`
+	Width            int         `gorm:"type:int;not null;default:0" json:"width"`
+	Height           int         `gorm:"type:int;not null;default:0" json:"height"`
+	PlaceholderColor string      `gorm:"type:varchar(7)" json:"placeholder_color"`
+	Srcset           ImageSrcset `gorm:"type:json" json:"srcset"`
+	Status           string      `gorm:"type:enum('pending','ready','failed');default:'pending';not null;index" json:"status"`
+	CreatedAt        time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+	UserID           int64       `gorm:"type:bigint;not null;index" json:"user_id"`
+
+	User *User       `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+	Tags []*MediaTag `gorm:"foreignKey:AssetID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"tags"`
+}
+
+type MediaTag struct {
+	AssetID int64  `gorm:"type:bigint;primaryKey" json:"asset_id"`
+	Tag     string `gorm:"type:varchar(50);primaryKey;index" json:"tag"`
+}
+
+func (m *MediaAsset) TagNames() []string {
+	tags := make([]string, 0, len(m.Tags))
+	for _, tag := range m.Tags {
+		tags = append(tags, tag.Tag)
+	}
+
+	return tags
+}
@@ -10,8 +10,10 @@ type Order struct {
 	TotalPrice    float64 `gorm:"type:decimal(10,2);not null" json:"total_price"`
 	TotalQuantity uint    `gorm:"type:int;not null" json:"total_quantity"`
 	PaymentMethod string  `gorm:"type:enum('cod','bank','e-wallet');not null" json:"payment_method"`
-	Status        string  `gorm:"type:enum('pending','confirmed','shipped');not null" json:"status"`
+	Status        string  `gorm:"type:enum('pending','confirmed','shipped','delivered');not null" json:"status"`
+	UserID        *int64  `gorm:"type:bigint;index" json:"user_id"`
 
+	User       *User        `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"user"`
 	OrderItems []*OrderItem `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"order_items"`
 }
 
@@ -22,7 +24,10 @@ type OrderItem struct {
 	TotalPrice float64 `gorm:"type:decimal(10,2);not null" json:"total_price"`
 	ProductID  int64   `gorm:"type:bigint;not null" json:"product_id"`
 	OrderID    int64   `gorm:"type:bigint;not null" json:"order_id"`
+	VariantID  *int64  `gorm:"type:bigint" json:"variant_id"`
+	SKU        string  `gorm:"type:varchar(100)" json:"sku"`
 
-	Order   *Order   `gorm:"foreignKey:OrderID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"order"`
-	Product *Product `gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	Order   *Order          `gorm:"foreignKey:OrderID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"order"`
+	Product *Product        `gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	Variant *ProductVariant `gorm:"foreignKey:VariantID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"variant"`
 }
@@ -0,0 +1,9 @@
+package model
+
+import "time"
+
+type RolePermission struct {
+	Role       string    `gorm:"type:varchar(50);primaryKey" json:"role"`
+	Permission string    `gorm:"type:varchar(100);primaryKey" json:"permission"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -1,21 +1,36 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Product struct {
-	ID          int64     `gorm:"type:bigint;primaryKey" json:"id"`
-	Name        string    `gorm:"type:varchar(255);not null" json:"name"`
-	Slug        string    `gorm:"type:varchar(255);not null;unique" json:"brand"`
-	Price       float64   `gorm:"type:decimal(10,2);not null" json:"price"`
-	Description string    `gorm:"type:text" json:"description"`
-	IsActive    bool      `gorm:"type:boolean;not null" json:"is_active"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	CategoryID  int64     `gorm:"type:bigint" json:"category_id"`
+	ID            int64          `gorm:"type:bigint;primaryKey" json:"id"`
+	Name          string         `gorm:"type:varchar(255);not null" json:"name"`
+	Slug          string         `gorm:"type:varchar(255);not null;index" json:"slug"`
+	Price         float64        `gorm:"type:decimal(10,2);not null" json:"price"`
+	Description   string         `gorm:"type:text" json:"description"`
+	IsActive      bool           `gorm:"type:boolean;not null" json:"is_active"`
+	Status        string         `gorm:"type:varchar(20);not null;default:draft;index" json:"status"`
+	PublishAt     *time.Time     `gorm:"index" json:"publish_at"`
+	UnpublishAt   *time.Time     `gorm:"index" json:"unpublish_at"`
+	RatingAverage float64        `gorm:"type:decimal(3,2);not null;default:0" json:"rating_average"`
+	RatingCount   uint           `gorm:"type:int;not null;default:0" json:"rating_count"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	LiveSlug      *string        `gorm:"type:varchar(255) GENERATED ALWAYS AS (IF(deleted_at IS NULL, slug, NULL)) STORED;uniqueIndex;->" json:"-"`
+	CategoryID    int64          `gorm:"type:bigint" json:"category_id"`
 
-	Category  *Category    `gorm:"foreignKey:CategoryID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"category"`
-	Images    []*Image     `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"images"`
-	Inventory *Inventory   `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE;OnDelete:CASCADE" json:"inventory"`
-	CartItems []*CartItem  `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"cart_items"`
-	Orders    []*OrderItem `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"order_items"`
+	Category   *Category                `gorm:"foreignKey:CategoryID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"category"`
+	Images     []*Image                 `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"images"`
+	Inventory  *Inventory               `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE;OnDelete:CASCADE" json:"inventory"`
+	Options    []*ProductOption         `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"options"`
+	Variants   []*ProductVariant        `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"variants"`
+	Attributes []*ProductAttributeValue `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"attributes"`
+	Reviews    []*Review                `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"reviews"`
+	CartItems  []*CartItem              `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"cart_items"`
+	Orders     []*OrderItem             `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"order_items"`
 }
@@ -0,0 +1,86 @@
+package model
+
+import "time"
+
+type ProductHistory struct {
+	ID            int64            `gorm:"type:bigint;primaryKey" json:"id"`
+	ProductID     int64            `gorm:"type:bigint;not null;uniqueIndex:idx_product_history_version" json:"product_id"`
+	Version       int              `gorm:"type:int;not null;uniqueIndex:idx_product_history_version" json:"version"`
+	Action        string           `gorm:"type:varchar(50);not null" json:"action"`
+	Before        *ProductSnapshot `gorm:"type:json;serializer:json" json:"before"`
+	After         *ProductSnapshot `gorm:"type:json;serializer:json" json:"after"`
+	ActorID       *int64           `gorm:"type:bigint;index" json:"actor_id"`
+	ActorUsername string           `gorm:"type:varchar(50)" json:"actor_username"`
+	CreatedAt     time.Time        `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type ProductSnapshot struct {
+	Name        string             `json:"name"`
+	Slug        string             `json:"slug"`
+	Price       float64            `json:"price"`
+	Description string             `json:"description"`
+	IsActive    bool               `json:"is_active"`
+	Status      string             `json:"status"`
+	PublishAt   *time.Time         `json:"publish_at"`
+	UnpublishAt *time.Time         `json:"unpublish_at"`
+	CategoryID  int64              `json:"category_id"`
+	DeletedAt   *time.Time         `json:"deleted_at,omitempty"`
+	Inventory   *InventorySnapshot `json:"inventory"`
+	Images      []*ImageSnapshot   `json:"images"`
+}
+
+type InventorySnapshot struct {
+	Quantity  uint `json:"quantity"`
+	Purchased uint `json:"purchased"`
+	Stock     uint `json:"stock"`
+}
+
+type ImageSnapshot struct {
+	ID          int64  `json:"id"`
+	PublicID    string `json:"public_id"`
+	Url         string `json:"url"`
+	IsThumbnail bool   `json:"is_thumbnail"`
+	SortOrder   int    `json:"sort_order"`
+	Status      string `json:"status,omitempty"`
+}
+
+func NewProductSnapshot(product *Product) *ProductSnapshot {
+	snapshot := &ProductSnapshot{
+		Name:        product.Name,
+		Slug:        product.Slug,
+		Price:       product.Price,
+		Description: product.Description,
+		IsActive:    product.IsActive,
+		Status:      product.Status,
+		PublishAt:   product.PublishAt,
+		UnpublishAt: product.UnpublishAt,
+		CategoryID:  product.CategoryID,
+		Images:      make([]*ImageSnapshot, 0, len(product.Images)),
+	}
+	if product.DeletedAt.Valid {
+		deletedAt := product.DeletedAt.Time
+		snapshot.DeletedAt = &deletedAt
+	}
+	if product.Inventory != nil {
+		snapshot.Inventory = &InventorySnapshot{
+			Quantity:  product.Inventory.Quantity,
+			Purchased: product.Inventory.Purchased,
+			Stock:     product.Inventory.Stock,
+		}
+	}
+	for _, img := range product.Images {
+		if img.VariantID != nil {
+			continue
+		}
+		snapshot.Images = append(snapshot.Images, &ImageSnapshot{
+			ID:          img.ID,
+			PublicID:    img.PublicID,
+			Url:         img.Url,
+			IsThumbnail: img.IsThumbnail,
+			SortOrder:   img.SortOrder,
+			Status:      img.Status,
+		})
+	}
+
+	return snapshot
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type ProductImportJob struct {
+	ID            int64           `gorm:"type:bigint;primaryKey" json:"id"`
+	FileName      string          `gorm:"type:varchar(255);not null" json:"file_name"`
+	Format        string          `gorm:"type:enum('csv','json');not null" json:"format"`
+	Upsert        bool            `gorm:"type:boolean;not null;default:false" json:"upsert"`
+	Status        string          `gorm:"type:enum('pending','running','completed','failed');default:'pending';not null;index" json:"status"`
+	TotalRows     int             `gorm:"type:int;not null;default:0" json:"total_rows"`
+	ProcessedRows int             `gorm:"type:int;not null;default:0" json:"processed_rows"`
+	CreatedCount  int             `gorm:"type:int;not null;default:0" json:"created_count"`
+	UpdatedCount  int             `gorm:"type:int;not null;default:0" json:"updated_count"`
+	FailedCount   int             `gorm:"type:int;not null;default:0" json:"failed_count"`
+	RowErrors     ImportRowErrors `gorm:"type:json" json:"row_errors"`
+	Message       string          `gorm:"type:varchar(500)" json:"message"`
+	Payload       []byte          `gorm:"type:mediumblob" json:"-"`
+	StartedAt     *time.Time      `json:"started_at"`
+	FinishedAt    *time.Time      `json:"finished_at"`
+	CreatedAt     time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	UserID        int64           `gorm:"type:bigint;not null;index" json:"user_id"`
+
+	User *User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+}
+
+type ImportRowError struct {
+	Row    int      `json:"row"`
+	Slug   string   `json:"slug"`
+	Errors []string `json:"errors"`
+}
+
+type ImportRowErrors []*ImportRowError
+
+func (e ImportRowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(e)
+}
+
+func (e *ImportRowErrors) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("không thể chuyển đổi %T sang ImportRowErrors", value)
+	}
+
+	return json.Unmarshal(data, e)
+}
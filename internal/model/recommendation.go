@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type ProductCoPurchase struct {
+	ProductID        int64     `gorm:"type:bigint;primaryKey" json:"product_id"`
+	RelatedProductID int64     `gorm:"type:bigint;primaryKey;index" json:"related_product_id"`
+	Count            uint      `gorm:"type:int;not null" json:"count"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Product        *Product `gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	RelatedProduct *Product `gorm:"foreignKey:RelatedProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"related_product"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type Review struct {
+	ID        int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	Rating    uint8     `gorm:"type:tinyint;not null" json:"rating"`
+	Title     string    `gorm:"type:varchar(150);not null" json:"title"`
+	Body      string    `gorm:"type:text" json:"body"`
+	Status    string    `gorm:"type:enum('pending','approved','rejected');default:'pending';not null;index" json:"status"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ProductID int64     `gorm:"type:bigint;not null;uniqueIndex:idx_review_product_user" json:"product_id"`
+	UserID    int64     `gorm:"type:bigint;not null;uniqueIndex:idx_review_product_user" json:"user_id"`
+
+	Product *Product       `gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	User    *User          `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+	Images  []*ReviewImage `gorm:"foreignKey:ReviewID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"images"`
+}
+
+type ReviewImage struct {
+	ID        int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	Url       string `gorm:"type:varchar(255)" json:"url"`
+	PublicID  string `gorm:"type:varchar(255)" json:"public_id"`
+	SortOrder int    `gorm:"type:int;not null" json:"sort_order"`
+	Status    string `gorm:"type:enum('pending','ready','failed');default:'pending';not null;index" json:"status"`
+	ReviewID  int64  `gorm:"type:bigint;not null;index" json:"review_id"`
+
+	Review *Review `gorm:"foreignKey:ReviewID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"review"`
+}
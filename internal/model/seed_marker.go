@@ -0,0 +1,8 @@
+package model
+
+import "time"
+
+type SeedMarker struct {
+	Name      string    `gorm:"type:varchar(150);primaryKey" json:"name"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type UploadSlot struct {
+	ID          int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	Key         string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"key"`
+	FileName    string    `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType string    `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size        int64     `gorm:"type:bigint;not null" json:"size"`
+	Status      string    `gorm:"type:enum('pending','uploaded','attached');default:'pending';not null;index" json:"status"`
+	ExpiresAt   time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	UserID      int64     `gorm:"type:bigint;not null;index" json:"user_id"`
+
+	User *User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user"`
+}
@@ -6,11 +6,15 @@ type User struct {
 	ID        int64     `gorm:"type:bigint;primaryKey" json:"id"`
 	Username  string    `gorm:"type:varchar(50);not null;unique" json:"username"`
 	Email     string    `gorm:"type:varchar(255);not null;unique" json:"email"`
-	Role      string    `gorm:"type:enum('user','admin');default:'user';not null" json:"role"`
+	Role      string    `gorm:"type:enum('user','admin','contributor','staff');default:'user';not null" json:"role"`
 	Password  string    `gorm:"type:varchar(512);not null" json:"password"`
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 
+	DeactivatedAt       *time.Time `gorm:"type:datetime" json:"deactivated_at"`
+	DeletionScheduledAt *time.Time `gorm:"type:datetime;index" json:"deletion_scheduled_at"`
+	AnonymizedAt        *time.Time `gorm:"type:datetime" json:"anonymized_at"`
+
 	Profile   *Profile   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"profile"`
 	Cart      *Cart      `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"cart"`
 	Addresses []*Address `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"addresses"`
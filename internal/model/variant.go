@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+type ProductOption struct {
+	ID        int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	Name      string `gorm:"type:varchar(50);not null" json:"name"`
+	Position  int    `gorm:"type:int;not null" json:"position"`
+	ProductID int64  `gorm:"type:bigint;not null;index" json:"product_id"`
+
+	Product *Product              `gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	Values  []*ProductOptionValue `gorm:"foreignKey:OptionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"values"`
+}
+
+type ProductOptionValue struct {
+	ID       int64  `gorm:"type:bigint;primaryKey" json:"id"`
+	Value    string `gorm:"type:varchar(100);not null" json:"value"`
+	Position int    `gorm:"type:int;not null" json:"position"`
+	OptionID int64  `gorm:"type:bigint;not null;index" json:"option_id"`
+
+	Option *ProductOption `gorm:"foreignKey:OptionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"option"`
+}
+
+type ProductVariant struct {
+	ID        int64     `gorm:"type:bigint;primaryKey" json:"id"`
+	SKU       string    `gorm:"type:varchar(100);not null;unique" json:"sku"`
+	Price     *float64  `gorm:"type:decimal(10,2)" json:"price"`
+	IsActive  bool      `gorm:"type:boolean;not null" json:"is_active"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ProductID int64     `gorm:"type:bigint;not null;index" json:"product_id"`
+
+	Product      *Product              `gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"product"`
+	OptionValues []*ProductOptionValue `gorm:"many2many:product_variant_option_values;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"option_values"`
+	Inventory    *Inventory            `gorm:"foreignKey:VariantID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"inventory"`
+	Images       []*Image              `gorm:"foreignKey:VariantID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"images"`
+}
+
+func (m *ProductVariant) EffectivePrice(basePrice float64) float64 {
+	if m.Price != nil {
+		return *m.Price
+	}
+
+	return basePrice
+}
@@ -3,6 +3,10 @@ package rabbitmq
 import "github.com/rabbitmq/amqp091-go"
 
 func ConsumeMessage(ch *amqp091.Channel, queueName, exchange, routingKey string, handler func([]byte) error) error {
+	return ConsumeMessageWithFailure(ch, queueName, exchange, routingKey, handler, nil)
+}
+
+func ConsumeMessageWithFailure(ch *amqp091.Channel, queueName, exchange, routingKey string, handler func([]byte) error, onFailure func([]byte, error)) error {
 	if _, err := ch.QueueDeclare(queueName, true, false, false, false, nil); err != nil {
 		return err
 	}
@@ -27,7 +31,9 @@ func ConsumeMessage(ch *amqp091.Channel, queueName, exchange, routingKey string,
 	for i := range 5 {
 		go func(workerID int) {
 			for msg := range msgs {
-				processWithRetry(msg.Body, handler, workerID)
+				if err := processWithRetry(msg.Body, handler, workerID); err != nil && onFailure != nil {
+					onFailure(msg.Body, err)
+				}
 			}
 		}(i)
 	}
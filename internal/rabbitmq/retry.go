@@ -6,16 +6,17 @@ import (
 	"time"
 )
 
-func processWithRetry(body []byte, handler func([]byte) error, workerID int) {
+func processWithRetry(body []byte, handler func([]byte) error, workerID int) error {
 	maxAttempts := 5
 	initialInterval := 1000 * time.Millisecond
 	multiplier := 2.0
 	maxInterval := 10000 * time.Millisecond
 
+	var err error
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		err := handler(body)
+		err = handler(body)
 		if err == nil {
-			return
+			return nil
 		}
 		log.Printf("Công việc %d: Lượt %d/%d thất bại: %v", workerID, attempt, maxAttempts, err)
 
@@ -28,4 +29,6 @@ func processWithRetry(body []byte, handler func([]byte) error, workerID int) {
 		}
 	}
 	log.Printf("Công việc %d: Gửi tin nhắn thất bại sau %d lượt", workerID, maxAttempts)
+
+	return err
 }
\ No newline at end of file
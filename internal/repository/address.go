@@ -24,5 +24,7 @@ type AddressRepository interface {
 
 	UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error
 
+	UpdateAllByUserIDTx(ctx context.Context, tx *gorm.DB, userID int64, updateData map[string]any) error
+
 	DeleteTx(ctx context.Context, tx *gorm.DB, id int64) error
 }
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"gorm.io/gorm"
+)
+
+type AttributeRepository interface {
+	Create(ctx context.Context, attribute *model.CategoryAttribute) error
+
+	FindByID(ctx context.Context, id int64) (*model.CategoryAttribute, error)
+
+	FindAllByCategoryID(ctx context.Context, categoryID int64) ([]*model.CategoryAttribute, error)
+
+	FindAllByCategoryIDTx(ctx context.Context, tx *gorm.DB, categoryID int64) ([]*model.CategoryAttribute, error)
+
+	FindAllByID(ctx context.Context, ids []int64) ([]*model.CategoryAttribute, error)
+
+	Update(ctx context.Context, id int64, updateData map[string]any) error
+
+	Delete(ctx context.Context, id int64) error
+
+	CreateValuesTx(ctx context.Context, tx *gorm.DB, values []*model.ProductAttributeValue) error
+
+	DeleteValuesByProductIDTx(ctx context.Context, tx *gorm.DB, productID int64) error
+
+	DeleteValuesByProductIDsTx(ctx context.Context, tx *gorm.DB, productIDs []int64) error
+}
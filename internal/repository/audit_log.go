@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, auditLog *model.AuditLog) error
+
+	FindAllByEntity(ctx context.Context, entityType string, entityID int64) ([]*model.AuditLog, error)
+
+	FindAllByUserID(ctx context.Context, userID int64) ([]*model.AuditLog, error)
+}
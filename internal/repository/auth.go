@@ -22,4 +22,8 @@ type AuthRepository interface {
 	AddResetPasswordData(ctx context.Context, token, email string, ttl time.Duration) error
 
 	GetResetPasswordData(ctx context.Context, token string) (string, error)
+
+	AddChangeEmailData(ctx context.Context, token string, data types.ChangeEmailData, ttl time.Duration) error
+
+	GetChangeEmailData(ctx context.Context, token string) (*types.ChangeEmailData, error)
 }
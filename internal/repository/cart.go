@@ -16,7 +16,7 @@ type CartRepository interface {
 
 	FindCartByUserIDTx(ctx context.Context, tx *gorm.DB, userID int64) (*model.Cart, error)
 
-	FindCartItemByCartIDAndProductIDTx(ctx context.Context, tx *gorm.DB, cartID, productID int64) (*model.CartItem, error)
+	FindCartItemByCartIDAndProductIDTx(ctx context.Context, tx *gorm.DB, cartID, productID int64, variantID *int64) (*model.CartItem, error)
 
 	CreateCartItemTx(ctx context.Context, tx *gorm.DB, cartItem *model.CartItem) error
 
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"gorm.io/gorm"
@@ -16,6 +17,8 @@ type CategoryRepository interface {
 
 	FindAllByID(ctx context.Context, ids []int64) ([]*model.Category, error)
 
+	FindAllBySlugs(ctx context.Context, slugs []string) ([]*model.Category, error)
+
 	FindByIDTx(ctx context.Context, tx *gorm.DB, id int64) (*model.Category, error)
 
 	Update(ctx context.Context, id int64, updateData map[string]any) error
@@ -23,4 +26,20 @@ type CategoryRepository interface {
 	Delete(ctx context.Context, id int64) error
 
 	DeleteAllByID(ctx context.Context, ids []int64) (int64, error)
+
+	FindAllDeleted(ctx context.Context) ([]*model.Category, error)
+
+	FindAllDeletedBefore(ctx context.Context, before time.Time) ([]*model.Category, error)
+
+	Restore(ctx context.Context, id int64) error
+
+	PurgeAllByID(ctx context.Context, ids []int64) (int64, error)
+
+	FindAllDescendantIDs(ctx context.Context, path string) ([]int64, error)
+
+	CountChildrenOutside(ctx context.Context, ids []int64) (int64, error)
+
+	UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error
+
+	UpdateSubtreePathTx(ctx context.Context, tx *gorm.DB, oldPath, newPath string, depthDelta int) error
 }
\ No newline at end of file
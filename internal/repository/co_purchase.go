@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type CoPurchaseRepository interface {
+	FindAllRelatedIDs(ctx context.Context, productIDs []int64, limit int) ([]int64, error)
+
+	DeleteAllTx(ctx context.Context, tx *gorm.DB) error
+
+	CreateAllFromOrdersTx(ctx context.Context, tx *gorm.DB, statuses []string) (int64, error)
+}
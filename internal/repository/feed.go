@@ -0,0 +1,9 @@
+package repository
+
+import "context"
+
+type FeedRepository interface {
+	Save(ctx context.Context, name string, content []byte) error
+
+	FindByName(ctx context.Context, name string) ([]byte, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"gorm.io/gorm"
@@ -12,6 +13,8 @@ type ImageRepository interface {
 
 	CreateAllTx(ctx context.Context, tx *gorm.DB, images []*model.Image) error
 
+	FindByID(ctx context.Context, id int64) (*model.Image, error)
+
 	Update(ctx context.Context, id int64, updateData map[string]any) error
 
 	UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error
@@ -19,4 +22,12 @@ type ImageRepository interface {
 	FindAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) ([]*model.Image, error)
 
 	DeleteAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) error
+
+	FindAllByStatus(ctx context.Context, status string) ([]*model.Image, error)
+
+	FindAllByIDAndStatus(ctx context.Context, ids []int64, status string) ([]*model.Image, error)
+
+	UpdateAllStatusByIDTx(ctx context.Context, tx *gorm.DB, ids []int64, status string) (int64, error)
+
+	UpdateStatusStaleBefore(ctx context.Context, from, to string, before time.Time) (int64, error)
 }
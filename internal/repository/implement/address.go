@@ -76,6 +76,10 @@ func (r *addressRepositoryImpl) UpdateTx(ctx context.Context, tx *gorm.DB, id in
 	return tx.WithContext(ctx).Model(&model.Address{}).Where("id = ?", id).Updates(updateData).Error
 }
 
+func (r *addressRepositoryImpl) UpdateAllByUserIDTx(ctx context.Context, tx *gorm.DB, userID int64, updateData map[string]any) error {
+	return tx.WithContext(ctx).Model(&model.Address{}).Where("user_id = ?", userID).Updates(updateData).Error
+}
+
 func (r *addressRepositoryImpl) DeleteTx(ctx context.Context, tx *gorm.DB, id int64) error {
 	return tx.WithContext(ctx).Where("id = ?", id).Delete(&model.Address{}).Error
 }
@@ -0,0 +1,99 @@
+package implement
+
+import (
+	"context"
+	"errors"
+
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"gorm.io/gorm"
+)
+
+type attributeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAttributeRepository(db *gorm.DB) repository.AttributeRepository {
+	return &attributeRepositoryImpl{db}
+}
+
+func (r *attributeRepositoryImpl) Create(ctx context.Context, attribute *model.CategoryAttribute) error {
+	return r.db.WithContext(ctx).Create(attribute).Error
+}
+
+func (r *attributeRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.CategoryAttribute, error) {
+	var attribute model.CategoryAttribute
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&attribute).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &attribute, nil
+}
+
+func (r *attributeRepositoryImpl) FindAllByCategoryID(ctx context.Context, categoryID int64) ([]*model.CategoryAttribute, error) {
+	return r.findAllByCategoryID(r.db.WithContext(ctx), categoryID)
+}
+
+func (r *attributeRepositoryImpl) FindAllByCategoryIDTx(ctx context.Context, tx *gorm.DB, categoryID int64) ([]*model.CategoryAttribute, error) {
+	return r.findAllByCategoryID(tx.WithContext(ctx), categoryID)
+}
+
+func (r *attributeRepositoryImpl) findAllByCategoryID(db *gorm.DB, categoryID int64) ([]*model.CategoryAttribute, error) {
+	var attributes []*model.CategoryAttribute
+	if err := db.Where("category_id = ?", categoryID).Order("position ASC, id ASC").Find(&attributes).Error; err != nil {
+		return nil, err
+	}
+
+	return attributes, nil
+}
+
+func (r *attributeRepositoryImpl) FindAllByID(ctx context.Context, ids []int64) ([]*model.CategoryAttribute, error) {
+	var attributes []*model.CategoryAttribute
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("position ASC, id ASC").Find(&attributes).Error; err != nil {
+		return nil, err
+	}
+
+	return attributes, nil
+}
+
+func (r *attributeRepositoryImpl) Update(ctx context.Context, id int64, updateData map[string]any) error {
+	result := r.db.WithContext(ctx).Model(&model.CategoryAttribute{}).Where("id = ?", id).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrAttributeNotFound
+	}
+
+	return nil
+}
+
+func (r *attributeRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.CategoryAttribute{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrAttributeNotFound
+	}
+
+	return nil
+}
+
+func (r *attributeRepositoryImpl) CreateValuesTx(ctx context.Context, tx *gorm.DB, values []*model.ProductAttributeValue) error {
+	return tx.WithContext(ctx).Create(&values).Error
+}
+
+func (r *attributeRepositoryImpl) DeleteValuesByProductIDTx(ctx context.Context, tx *gorm.DB, productID int64) error {
+	return tx.WithContext(ctx).Where("product_id = ?", productID).Delete(&model.ProductAttributeValue{}).Error
+}
+
+func (r *attributeRepositoryImpl) DeleteValuesByProductIDsTx(ctx context.Context, tx *gorm.DB, productIDs []int64) error {
+	return tx.WithContext(ctx).Where("product_id IN ?", productIDs).Delete(&model.ProductAttributeValue{}).Error
+}
@@ -0,0 +1,43 @@
+package implement
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"gorm.io/gorm"
+)
+
+type auditLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) repository.AuditLogRepository {
+	return &auditLogRepositoryImpl{db}
+}
+
+func (r *auditLogRepositoryImpl) CreateTx(ctx context.Context, tx *gorm.DB, auditLog *model.AuditLog) error {
+	return tx.WithContext(ctx).Create(auditLog).Error
+}
+
+func (r *auditLogRepositoryImpl) FindAllByEntity(ctx context.Context, entityType string, entityID int64) ([]*model.AuditLog, error) {
+	var auditLogs []*model.AuditLog
+	if err := r.db.WithContext(ctx).Where("entity_type = ? AND entity_id = ?", entityType, entityID).Order("created_at DESC").Find(&auditLogs).Error; err != nil {
+		return nil, err
+	}
+
+	return auditLogs, nil
+}
+
+func (r *auditLogRepositoryImpl) FindAllByUserID(ctx context.Context, userID int64) ([]*model.AuditLog, error) {
+	var auditLogs []*model.AuditLog
+	if err := r.db.WithContext(ctx).
+		Where("actor_id = ? OR (entity_type = ? AND entity_id = ?)", userID, common.AuditEntityUser, userID).
+		Order("created_at DESC").
+		Find(&auditLogs).Error; err != nil {
+		return nil, err
+	}
+
+	return auditLogs, nil
+}
@@ -136,3 +136,36 @@ func (r *authRepositoryImpl) GetResetPasswordData(ctx context.Context, token str
 
 	return email, nil
 }
+
+func (r *authRepositoryImpl) AddChangeEmailData(ctx context.Context, token string, data types.ChangeEmailData, ttl time.Duration) error {
+	changeDataJSON, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("mã hóa dữ liệu thay đổi email thất bại: %w", err)
+	}
+
+	redisKey := fmt.Sprintf("%s:change-email:%s", r.cfg.App.Name, token)
+
+	if err = r.rdb.Set(ctx, redisKey, changeDataJSON, ttl).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *authRepositoryImpl) GetChangeEmailData(ctx context.Context, token string) (*types.ChangeEmailData, error) {
+	redisKey := fmt.Sprintf("%s:change-email:%s", r.cfg.App.Name, token)
+
+	changeDataJSON, err := r.rdb.Get(ctx, redisKey).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("lấy dữ liệu từ redis thất bại: %w", err)
+	}
+
+	var changeData types.ChangeEmailData
+	if err = json.Unmarshal([]byte(changeDataJSON), &changeData); err != nil {
+		return nil, fmt.Errorf("giải mã dữ liệu thay đổi email thất bại: %w", err)
+	}
+
+	return &changeData, nil
+}
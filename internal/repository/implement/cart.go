@@ -81,6 +81,7 @@ func (r *cartRepositoryImpl) FindCartByUserIDWithDetails(ctx context.Context, us
 		Preload("CartItems.Product").
 		Preload("CartItems.Product.Category").
 		Preload("CartItems.Product.Images", "is_thumbnail = true").
+		Preload("CartItems.Variant.OptionValues.Option").
 		Where("user_id = ?", userID).First(&cart).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -110,6 +111,7 @@ func (r *cartRepositoryImpl) FindCartByIDWithDetails(ctx context.Context, cartID
 		Preload("CartItems.Product").
 		Preload("CartItems.Product.Category").
 		Preload("CartItems.Product.Images", "is_thumbnail = true").
+		Preload("CartItems.Variant.OptionValues.Option").
 		Where("id = ?", cartID).First(&cart).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -120,9 +122,17 @@ func (r *cartRepositoryImpl) FindCartByIDWithDetails(ctx context.Context, cartID
 	return &cart, nil
 }
 
-func (r *cartRepositoryImpl) FindCartItemByCartIDAndProductIDTx(ctx context.Context, tx *gorm.DB, cartID, productID int64) (*model.CartItem, error) {
+func (r *cartRepositoryImpl) FindCartItemByCartIDAndProductIDTx(ctx context.Context, tx *gorm.DB, cartID, productID int64, variantID *int64) (*model.CartItem, error) {
 	var cartItem model.CartItem
-	if err := tx.WithContext(ctx).Where("cart_id = ? AND product_id = ?", cartID, productID).First(&cartItem).Error; err != nil {
+
+	query := tx.WithContext(ctx).Where("cart_id = ? AND product_id = ?", cartID, productID)
+	if variantID != nil {
+		query = query.Where("variant_id = ?", *variantID)
+	} else {
+		query = query.Where("variant_id IS NULL")
+	}
+
+	if err := query.First(&cartItem).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -3,6 +3,7 @@ package implement
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/tienhai2808/ecom_go/internal/model"
 	customErr "github.com/tienhai2808/ecom_go/internal/errors"
@@ -24,7 +25,7 @@ func (r *categoryRepositoryImpl) Create(ctx context.Context, category *model.Cat
 
 func (r *categoryRepositoryImpl) FindAll(ctx context.Context) ([]*model.Category, error) {
 	var categories []*model.Category
-	if err := r.db.WithContext(ctx).Order("created_at DESC").Find(&categories).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("ImageAsset").Order("created_at DESC").Find(&categories).Error; err != nil {
 		return nil, err
 	}
 
@@ -44,9 +45,18 @@ func (r *categoryRepositoryImpl) FindAllByID(ctx context.Context, ids []int64) (
 	return categories, nil
 }
 
+func (r *categoryRepositoryImpl) FindAllBySlugs(ctx context.Context, slugs []string) ([]*model.Category, error) {
+	var categories []*model.Category
+	if err := r.db.WithContext(ctx).Where("slug IN ?", slugs).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
 func (r *categoryRepositoryImpl) FindByIDTx(ctx context.Context, tx *gorm.DB, id int64) (*model.Category, error) {
 	var category model.Category
-	if err := tx.WithContext(ctx).Where("id = ?", id).First(&category).Error; err != nil {
+	if err := tx.WithContext(ctx).Preload("ImageAsset").Where("id = ?", id).First(&category).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -88,3 +98,81 @@ func (r *categoryRepositoryImpl) DeleteAllByID(ctx context.Context, ids []int64)
 
 	return result.RowsAffected, nil
 }
+
+func (r *categoryRepositoryImpl) FindAllDeleted(ctx context.Context) ([]*model.Category, error) {
+	var categories []*model.Category
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *categoryRepositoryImpl) FindAllDeletedBefore(ctx context.Context, before time.Time) ([]*model.Category, error) {
+	var categories []*model.Category
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL AND deleted_at <= ?", before).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *categoryRepositoryImpl) Restore(ctx context.Context, id int64) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&model.Category{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return customErr.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+func (r *categoryRepositoryImpl) PurgeAllByID(ctx context.Context, ids []int64) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("id IN ?", ids).Delete(&model.Category{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *categoryRepositoryImpl) FindAllDescendantIDs(ctx context.Context, path string) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Model(&model.Category{}).Where("path LIKE ?", path+"%").Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *categoryRepositoryImpl) CountChildrenOutside(ctx context.Context, ids []int64) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.Category{}).Where("parent_id IN ? AND id NOT IN ?", ids, ids).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *categoryRepositoryImpl) UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error {
+	result := tx.WithContext(ctx).Unscoped().Model(&model.Category{}).Where("id = ?", id).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return customErr.ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+func (r *categoryRepositoryImpl) UpdateSubtreePathTx(ctx context.Context, tx *gorm.DB, oldPath, newPath string, depthDelta int) error {
+	return tx.WithContext(ctx).Unscoped().Model(&model.Category{}).
+		Where("path LIKE ?", oldPath+"%").
+		Updates(map[string]any{
+			"path":  gorm.Expr("CONCAT(?, SUBSTRING(path, ?))", newPath, len(oldPath)+1),
+			"depth": gorm.Expr("depth + ?", depthDelta),
+		}).Error
+}
@@ -0,0 +1,50 @@
+package implement
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"gorm.io/gorm"
+)
+
+type coPurchaseRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCoPurchaseRepository(db *gorm.DB) repository.CoPurchaseRepository {
+	return &coPurchaseRepositoryImpl{db}
+}
+
+func (r *coPurchaseRepositoryImpl) FindAllRelatedIDs(ctx context.Context, productIDs []int64, limit int) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Model(&model.ProductCoPurchase{}).
+		Where("product_id IN ? AND related_product_id NOT IN ?", productIDs, productIDs).
+		Group("related_product_id").
+		Order("SUM(count) DESC").
+		Limit(limit).
+		Pluck("related_product_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *coPurchaseRepositoryImpl) DeleteAllTx(ctx context.Context, tx *gorm.DB) error {
+	return tx.WithContext(ctx).Where("1 = 1").Delete(&model.ProductCoPurchase{}).Error
+}
+
+func (r *coPurchaseRepositoryImpl) CreateAllFromOrdersTx(ctx context.Context, tx *gorm.DB, statuses []string) (int64, error) {
+	result := tx.WithContext(ctx).Exec(`INSERT INTO product_co_purchases (product_id, related_product_id, count, updated_at)
+		SELECT a.product_id, b.product_id, COUNT(DISTINCT a.order_id), NOW()
+		FROM order_items a
+		JOIN order_items b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+		JOIN orders ON orders.id = a.order_id
+		WHERE orders.status IN ?
+		GROUP BY a.product_id, b.product_id`, statuses)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,45 @@
+package implement
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+)
+
+type feedRepositoryImpl struct {
+	rdb *redis.Client
+	cfg *config.Config
+}
+
+func NewFeedRepository(rdb *redis.Client, cfg *config.Config) repository.FeedRepository {
+	return &feedRepositoryImpl{
+		rdb,
+		cfg,
+	}
+}
+
+func (r *feedRepositoryImpl) Save(ctx context.Context, name string, content []byte) error {
+	redisKey := fmt.Sprintf("%s:feed:%s", r.cfg.App.Name, name)
+
+	if err := r.rdb.Set(ctx, redisKey, content, 0).Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *feedRepositoryImpl) FindByName(ctx context.Context, name string) ([]byte, error) {
+	redisKey := fmt.Sprintf("%s:feed:%s", r.cfg.App.Name, name)
+
+	content, err := r.rdb.Get(ctx, redisKey).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("lấy dữ liệu từ redis thất bại: %w", err)
+	}
+
+	return content, nil
+}
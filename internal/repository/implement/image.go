@@ -2,6 +2,9 @@ package implement
 
 import (
 	"context"
+	"errors"
+	"time"
+
 	customErr "github.com/tienhai2808/ecom_go/internal/errors"
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"github.com/tienhai2808/ecom_go/internal/repository"
@@ -25,6 +28,18 @@ func (r *imageRepositoryImpl) CreateAllTx(ctx context.Context, tx *gorm.DB, imag
 	return tx.WithContext(ctx).Create(images).Error
 }
 
+func (r *imageRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.Image, error) {
+	var image model.Image
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&image).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &image, nil
+}
+
 func (r *imageRepositoryImpl) Update(ctx context.Context, id int64, updateData map[string]any) error {
 	result := r.db.WithContext(ctx).Model(&model.Image{}).Where("id = ?", id).Updates(updateData)
 	if result.Error != nil {
@@ -53,4 +68,40 @@ func (r *imageRepositoryImpl) FindAllByIDTx(ctx context.Context, tx *gorm.DB, id
 
 func (r *imageRepositoryImpl) DeleteAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) error {
 	return tx.WithContext(ctx).Where("id IN ?", ids).Delete(&model.Image{}).Error
-}
\ No newline at end of file
+}
+
+func (r *imageRepositoryImpl) FindAllByStatus(ctx context.Context, status string) ([]*model.Image, error) {
+	var images []*model.Image
+	if err := r.db.WithContext(ctx).Where("status = ?", status).Order("updated_at DESC").Find(&images).Error; err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (r *imageRepositoryImpl) FindAllByIDAndStatus(ctx context.Context, ids []int64, status string) ([]*model.Image, error) {
+	var images []*model.Image
+	if err := r.db.WithContext(ctx).Where("id IN ? AND status = ?", ids, status).Find(&images).Error; err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (r *imageRepositoryImpl) UpdateAllStatusByIDTx(ctx context.Context, tx *gorm.DB, ids []int64, status string) (int64, error) {
+	result := tx.WithContext(ctx).Model(&model.Image{}).Where("id IN ?", ids).Update("status", status)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *imageRepositoryImpl) UpdateStatusStaleBefore(ctx context.Context, from, to string, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&model.Image{}).Where("status = ? AND updated_at <= ?", from, before).Update("status", to)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -19,3 +19,20 @@ func NewInventoryRepository(db *gorm.DB) repository.InventoryRepository {
 func (r *inventoryRepositoryImpl) UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error {
 	return tx.WithContext(ctx).Model(&model.Inventory{}).Where("id = ?", id).Updates(updateData).Error
 }
+
+func (r *inventoryRepositoryImpl) UpdateAllByProductIDTx(ctx context.Context, tx *gorm.DB, productIDs []int64, updateData map[string]any) error {
+	return tx.WithContext(ctx).Model(&model.Inventory{}).Where("product_id IN ?", productIDs).Updates(updateData).Error
+}
+
+func (r *inventoryRepositoryImpl) SumVariantQuantityByProductIDTx(ctx context.Context, tx *gorm.DB, productID int64) (uint, error) {
+	var total uint
+	if err := tx.WithContext(ctx).Model(&model.Inventory{}).
+		Joins("JOIN product_variants ON product_variants.id = inventories.variant_id").
+		Where("product_variants.product_id = ?", productID).
+		Select("COALESCE(SUM(inventories.quantity), 0)").
+		Scan(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
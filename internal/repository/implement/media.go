@@ -0,0 +1,166 @@
+package implement
+
+import (
+	"context"
+	"errors"
+
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mediaRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) repository.MediaRepository {
+	return &mediaRepositoryImpl{db}
+}
+
+func (r *mediaRepositoryImpl) FindAll(ctx context.Context, query request.MediaPaginationQuery) ([]*model.MediaAsset, int64, error) {
+	db := r.db.WithContext(ctx).Model(&model.MediaAsset{})
+	if query.Search != "" {
+		keyword := "%" + query.Search + "%"
+		db = db.Where("file_name LIKE ? OR id IN (?)", keyword, r.db.Model(&model.MediaTag{}).Select("asset_id").Where("tag LIKE ?", keyword))
+	}
+	for _, tag := range query.Tags {
+		db = db.Where("id IN (?)", r.db.Model(&model.MediaTag{}).Select("asset_id").Where("tag = ?", tag))
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var assets []*model.MediaAsset
+	if err := db.
+		Preload("Tags").
+		Order("created_at DESC").
+		Offset(int((query.Page - 1) * query.Limit)).
+		Limit(int(query.Limit)).
+		Find(&assets).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return assets, total, nil
+}
+
+func (r *mediaRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.MediaAsset, error) {
+	var asset model.MediaAsset
+	if err := r.db.WithContext(ctx).Preload("Tags").Where("id = ?", id).First(&asset).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &asset, nil
+}
+
+func (r *mediaRepositoryImpl) FindAllByUserID(ctx context.Context, userID int64) ([]*model.MediaAsset, error) {
+	var assets []*model.MediaAsset
+	if err := r.db.WithContext(ctx).Preload("Tags").Where("user_id = ?", userID).Order("created_at DESC").Find(&assets).Error; err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+func (r *mediaRepositoryImpl) FindAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) ([]*model.MediaAsset, error) {
+	var assets []*model.MediaAsset
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "SHARE"}).Where("id IN ?", ids).Find(&assets).Error; err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+func (r *mediaRepositoryImpl) CreateTx(ctx context.Context, tx *gorm.DB, asset *model.MediaAsset) error {
+	return tx.WithContext(ctx).Create(asset).Error
+}
+
+func (r *mediaRepositoryImpl) Update(ctx context.Context, id int64, updateData map[string]any) error {
+	result := r.db.WithContext(ctx).Model(&model.MediaAsset{}).Where("id = ?", id).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrMediaAssetNotFound
+	}
+
+	return nil
+}
+
+func (r *mediaRepositoryImpl) UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error {
+	return tx.WithContext(ctx).Model(&model.MediaAsset{}).Where("id = ?", id).Updates(updateData).Error
+}
+
+func (r *mediaRepositoryImpl) ReplaceTagsTx(ctx context.Context, tx *gorm.DB, id int64, tags []string) error {
+	if err := tx.WithContext(ctx).Where("asset_id = ?", id).Delete(&model.MediaTag{}).Error; err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	mediaTags := make([]*model.MediaTag, 0, len(tags))
+	for _, tag := range tags {
+		mediaTags = append(mediaTags, &model.MediaTag{
+			AssetID: id,
+			Tag:     tag,
+		})
+	}
+
+	return tx.WithContext(ctx).Create(mediaTags).Error
+}
+
+func (r *mediaRepositoryImpl) CountUsagesTx(ctx context.Context, tx *gorm.DB, id int64) (int64, error) {
+	var images int64
+	if err := tx.WithContext(ctx).Model(&model.Image{}).Where("asset_id = ?", id).Count(&images).Error; err != nil {
+		return 0, err
+	}
+
+	var categories int64
+	if err := tx.WithContext(ctx).Unscoped().Model(&model.Category{}).Where("image_asset_id = ?", id).Count(&categories).Error; err != nil {
+		return 0, err
+	}
+
+	return images + categories, nil
+}
+
+func (r *mediaRepositoryImpl) DeleteTx(ctx context.Context, tx *gorm.DB, id int64) error {
+	result := tx.WithContext(ctx).Where("id = ?", id).Delete(&model.MediaAsset{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrMediaAssetNotFound
+	}
+
+	return nil
+}
+
+func (r *mediaRepositoryImpl) IsKeyReferenced(ctx context.Context, key string) (bool, error) {
+	var assets int64
+	if err := r.db.WithContext(ctx).Model(&model.MediaAsset{}).Where("`key` = ?", key).Count(&assets).Error; err != nil {
+		return false, err
+	}
+	if assets > 0 {
+		return true, nil
+	}
+
+	var images int64
+	if err := r.db.WithContext(ctx).Model(&model.Image{}).Where("public_id = ?", key).Count(&images).Error; err != nil {
+		return false, err
+	}
+
+	return images > 0, nil
+}
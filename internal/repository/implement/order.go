@@ -0,0 +1,40 @@
+package implement
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"gorm.io/gorm"
+)
+
+type orderRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) repository.OrderRepository {
+	return &orderRepositoryImpl{db}
+}
+
+func (r *orderRepositoryImpl) ExistsDeliveredItem(ctx context.Context, userID, productID int64) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.user_id = ? AND orders.status = ? AND order_items.product_id = ?", userID, common.OrderStatusDelivered, productID).
+		Limit(1).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *orderRepositoryImpl) FindAllByUserIDWithItems(ctx context.Context, userID int64) ([]*model.Order, error) {
+	var orders []*model.Order
+	if err := r.db.WithContext(ctx).Preload("OrderItems").Where("user_id = ?", userID).Order("id DESC").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
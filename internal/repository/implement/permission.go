@@ -0,0 +1,49 @@
+package implement
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type permissionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPermissionRepository(db *gorm.DB) repository.PermissionRepository {
+	return &permissionRepositoryImpl{db}
+}
+
+func (r *permissionRepositoryImpl) FindAll(ctx context.Context) ([]*model.RolePermission, error) {
+	var rolePermissions []*model.RolePermission
+	if err := r.db.WithContext(ctx).Order("role ASC, permission ASC").Find(&rolePermissions).Error; err != nil {
+		return nil, err
+	}
+
+	return rolePermissions, nil
+}
+
+func (r *permissionRepositoryImpl) FindAllByRole(ctx context.Context, role string) ([]*model.RolePermission, error) {
+	var rolePermissions []*model.RolePermission
+	if err := r.db.WithContext(ctx).Where("role = ?", role).Order("permission ASC").Find(&rolePermissions).Error; err != nil {
+		return nil, err
+	}
+
+	return rolePermissions, nil
+}
+
+func (r *permissionRepositoryImpl) CreateAll(ctx context.Context, rolePermissions []*model.RolePermission) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(rolePermissions).Error
+}
+
+func (r *permissionRepositoryImpl) DeleteAllByRoleAndPermissions(ctx context.Context, role string, permissions []string) (int64, error) {
+	result := r.db.WithContext(ctx).Where("role = ? AND permission IN ?", role, permissions).Delete(&model.RolePermission{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
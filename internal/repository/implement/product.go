@@ -2,32 +2,34 @@ package implement
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
+	"time"
 
-	"github.com/elastic/go-elasticsearch/v8"
-	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
-	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
-	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+	"github.com/tienhai2808/ecom_go/internal/common"
 	customErr "github.com/tienhai2808/ecom_go/internal/errors"
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"github.com/tienhai2808/ecom_go/internal/repository"
 	"github.com/tienhai2808/ecom_go/internal/request"
-	internalType "github.com/tienhai2808/ecom_go/internal/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var productDetailPreloads = []string{
+	"Category",
+	"Inventory",
+	"Images",
+	"Options.Values",
+	"Variants.Inventory",
+	"Variants.OptionValues.Option",
+	"Attributes.Attribute",
+}
+
 type productRepositoryImpl struct {
 	db *gorm.DB
-	es *elasticsearch.TypedClient
 }
 
-func NewProductRepository(db *gorm.DB, es *elasticsearch.TypedClient) repository.ProductRepository {
-	return &productRepositoryImpl{
-		db,
-		es,
-	}
+func NewProductRepository(db *gorm.DB) repository.ProductRepository {
+	return &productRepositoryImpl{db}
 }
 
 func (r *productRepositoryImpl) FindAll(ctx context.Context) ([]*model.Product, error) {
@@ -39,76 +41,59 @@ func (r *productRepositoryImpl) FindAll(ctx context.Context) ([]*model.Product,
 	return products, nil
 }
 
-func (r *productRepositoryImpl) Search(ctx context.Context, query request.ProductPaginationQuery) (*internalType.ProductSearchResult, error) {
-	if query.Page == 0 {
-		query.Page = 1
-	}
-	if query.Limit == 0 {
-		query.Limit = 10
-	}
-
-	from := int((query.Page - 1) * query.Limit)
-	size := int(query.Limit)
-
-	esQuery := buildQuery(query)
-	sort := buildSort(query)
-
-	req := &search.Request{
-		Query: esQuery,
-		From:  &from,
-		Size:  &size,
-		Sort:  sort,
-	}
-
-	res, err := r.es.Search().
-		Index("mysql_server.ecom_go.products").
-		Request(req).
-		Do(ctx)
-
-	if err != nil {
-		return nil, fmt.Errorf("tìm kiếm thất bại: %w", err)
-	}
+func (r *productRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.Product, error) {
+	return findByIDBase(ctx, r.db, id)
+}
 
-	productIDs := make([]int64, 0, len(res.Hits.Hits))
-	for _, hit := range res.Hits.Hits {
-		var doc struct {
-			Payload struct {
-				After struct {
-					ID int64 `json:"id"`
-				} `json:"after"`
-			} `json:"payload"`
-		}
+func (r *productRepositoryImpl) FindByIDWithDetails(ctx context.Context, id int64) (*model.Product, error) {
+	return findByIDBase(ctx, r.db, id, productDetailPreloads...)
+}
 
-		if err := json.Unmarshal(hit.Source_, &doc); err != nil {
-			return nil, fmt.Errorf("lỗi giải mã document: %w", err)
+func (r *productRepositoryImpl) FindActiveBySlugWithDetails(ctx context.Context, slug string) (*model.Product, error) {
+	var product model.Product
+	if err := r.db.WithContext(ctx).
+		Preload("Category").
+		Preload("Inventory").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
+		Preload("Options.Values").
+		Preload("Variants", "is_active = ?", true).
+		Preload("Variants.Inventory").
+		Preload("Variants.OptionValues.Option").
+		Preload("Attributes.Attribute").
+		Where("slug = ? AND is_active = ?", slug, true).
+		Where("unpublish_at IS NULL OR unpublish_at > ?", time.Now()).
+		First(&product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
 		}
-		productIDs = append(productIDs, doc.Payload.After.ID)
+		return nil, err
 	}
 
-	total := res.Hits.Total.Value
-	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
-
-	return &internalType.ProductSearchResult{
-		IDs:        productIDs,
-		Total:      total,
-		Page:       query.Page,
-		Limit:      query.Limit,
-		TotalPages: totalPages,
-		HasPrev:    query.Page > 1,
-		HasNext:    int64(query.Page) < totalPages,
-	}, nil
+	return &product, nil
 }
 
-func (r *productRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.Product, error) {
-	return findByIDBase(ctx, r.db, id)
+func (r *productRepositoryImpl) FindByIDWithDetailsTx(ctx context.Context, tx *gorm.DB, id int64) (*model.Product, error) {
+	return findByIDBase(ctx, tx, id, productDetailPreloads...)
 }
 
-func (r *productRepositoryImpl) FindByIDWithDetails(ctx context.Context, id int64) (*model.Product, error) {
-	return findByIDBase(ctx, r.db, id, "Category", "Inventory", "Images")
-}
+func (r *productRepositoryImpl) FindBySlugForImportTx(ctx context.Context, tx *gorm.DB, slug string) (*model.Product, error) {
+	var product model.Product
+	if err := tx.WithContext(ctx).
+		Preload("Inventory").
+		Preload("Images", "variant_id IS NULL").
+		Preload("Variants").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("slug = ?", slug).
+		First(&product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
 
-func (r *productRepositoryImpl) FindByIDWithDetailsTx(ctx context.Context, tx *gorm.DB, id int64) (*model.Product, error) {
-	return findByIDBase(ctx, tx, id, "Category", "Inventory", "Images")
+	return &product, nil
 }
 
 func (r *productRepositoryImpl) FindByIDWithImages(ctx context.Context, id int64) (*model.Product, error) {
@@ -135,7 +120,7 @@ func (r *productRepositoryImpl) FindAllByIDWithThumbnail(ctx context.Context, id
 
 func (r *productRepositoryImpl) FindAllByIDWithCategoryAndThumbnail(ctx context.Context, ids []int64) ([]*model.Product, error) {
 	var products []*model.Product
-	if err := r.db.WithContext(ctx).Preload("Category").Preload("Images", "is_thumbnail = true").Where("id IN ?", ids).Find(&products).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Category").Preload("Images", "is_thumbnail = true AND status = ?", common.ImageStatusReady).Where("id IN ?", ids).Find(&products).Error; err != nil {
 		return nil, err
 	}
 
@@ -146,12 +131,109 @@ func (r *productRepositoryImpl) Create(ctx context.Context, product *model.Produ
 	return r.db.WithContext(ctx).Create(product).Error
 }
 
+func (r *productRepositoryImpl) CreateTx(ctx context.Context, tx *gorm.DB, product *model.Product) error {
+	return tx.WithContext(ctx).Create(product).Error
+}
+
 func (r *productRepositoryImpl) UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error {
 	return tx.WithContext(ctx).Model(&model.Product{}).Where("id = ?", id).Updates(updateData).Error
 }
 
-func (r *productRepositoryImpl) Delete(ctx context.Context, id int64) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.Product{})
+func (r *productRepositoryImpl) FindAllForBulkUpdateTx(ctx context.Context, tx *gorm.DB, ids []int64, filter *request.BulkProductFilter, limit int) ([]*model.Product, error) {
+	query := tx.WithContext(ctx).
+		Preload("Inventory").
+		Preload("Images", "variant_id IS NULL").
+		Preload("Variants").
+		Clauses(clause.Locking{Strength: "UPDATE"})
+
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	if filter != nil {
+		if len(filter.FilterCategoryIDs) > 0 {
+			query = query.Where("category_id IN ?", filter.FilterCategoryIDs)
+		}
+		if filter.IsActive != nil {
+			query = query.Where("is_active = ?", *filter.IsActive)
+		}
+		if filter.Search != "" {
+			query = query.Where("name LIKE ?", "%"+filter.Search+"%")
+		}
+		if filter.MinPrice != nil {
+			query = query.Where("price >= ?", *filter.MinPrice)
+		}
+		if filter.MaxPrice != nil {
+			query = query.Where("price <= ?", *filter.MaxPrice)
+		}
+	}
+
+	var products []*model.Product
+	if err := query.Order("id ASC").Limit(limit).Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (r *productRepositoryImpl) FindAllDueForPublishTx(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]*model.Product, error) {
+	var products []*model.Product
+	if err := tx.WithContext(ctx).
+		Preload("Inventory").
+		Preload("Images", "variant_id IS NULL").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("(status = ? AND publish_at <= ?) OR (status = ? AND unpublish_at <= ?)", common.ProductStatusScheduled, now, common.ProductStatusPublished, now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (r *productRepositoryImpl) UpdateAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64, updateData map[string]any) (int64, error) {
+	result := tx.WithContext(ctx).Model(&model.Product{}).Where("id IN ?", ids).Updates(updateData)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *productRepositoryImpl) UpdateRatingTx(ctx context.Context, tx *gorm.DB, id int64) error {
+	var stats struct {
+		Average float64
+		Count   uint
+	}
+	if err := tx.WithContext(ctx).Model(&model.Review{}).
+		Select("COALESCE(AVG(rating), 0) AS average, COUNT(*) AS count").
+		Where("product_id = ? AND status = ?", id, common.ReviewStatusApproved).
+		Scan(&stats).Error; err != nil {
+		return err
+	}
+
+	return tx.WithContext(ctx).Model(&model.Product{}).Where("id = ?", id).UpdateColumns(map[string]any{
+		"rating_average": stats.Average,
+		"rating_count":   stats.Count,
+	}).Error
+}
+
+func (r *productRepositoryImpl) FindAllByIDForHistoryTx(ctx context.Context, tx *gorm.DB, ids []int64) ([]*model.Product, error) {
+	var products []*model.Product
+	if err := tx.WithContext(ctx).Unscoped().
+		Preload("Inventory").
+		Preload("Images").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (r *productRepositoryImpl) DeleteTx(ctx context.Context, tx *gorm.DB, id int64) error {
+	result := tx.WithContext(ctx).Where("id = ?", id).Delete(&model.Product{})
 	if result.Error != nil {
 		return result.Error
 	}
@@ -163,8 +245,8 @@ func (r *productRepositoryImpl) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (r *productRepositoryImpl) DeleteAllByID(ctx context.Context, ids []int64) (int64, error) {
-	result := r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&model.Product{})
+func (r *productRepositoryImpl) DeleteAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) (int64, error) {
+	result := tx.WithContext(ctx).Where("id IN ?", ids).Delete(&model.Product{})
 	if result.Error != nil {
 		return 0, result.Error
 	}
@@ -172,120 +254,133 @@ func (r *productRepositoryImpl) DeleteAllByID(ctx context.Context, ids []int64)
 	return result.RowsAffected, nil
 }
 
-func findByIDBase(ctx context.Context, tx *gorm.DB, id int64, preloads ...string) (*model.Product, error) {
-	var product model.Product
-
-	query := tx.WithContext(ctx)
-	for _, preload := range preloads {
-		query = query.Preload(preload)
-	}
-
-	if err := query.Where("id = ?", id).First(&product).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil
-		}
+func (r *productRepositoryImpl) FindAllDeleted(ctx context.Context) ([]*model.Product, error) {
+	var products []*model.Product
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&products).Error; err != nil {
 		return nil, err
 	}
 
-	return &product, nil
+	return products, nil
 }
 
-func getThumbnail(db *gorm.DB) *gorm.DB {
-	return db.Where("is_thumbnail = true")
+func (r *productRepositoryImpl) FindAllDeletedBeforeWithImages(ctx context.Context, before time.Time) ([]*model.Product, error) {
+	var products []*model.Product
+	if err := r.db.WithContext(ctx).Unscoped().Preload("Images").Where("deleted_at IS NOT NULL AND deleted_at <= ?", before).Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return products, nil
 }
 
-func buildQuery(query request.ProductPaginationQuery) *types.Query {
-	var mustQueries []types.Query
+func (r *productRepositoryImpl) RestoreTx(ctx context.Context, tx *gorm.DB, id int64) error {
+	result := tx.WithContext(ctx).Unscoped().Model(&model.Product{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
 
-	if query.Search != "" {
-		mustQueries = append(mustQueries, types.Query{
-			MultiMatch: &types.MultiMatchQuery{
-				Query:  query.Search,
-				Fields: []string{"payload.after.name^3", "payload.after.description"},
-			},
-		})
+	if result.RowsAffected == 0 {
+		return customErr.ErrProductNotFound
 	}
 
-	if query.IsActive != nil {
-		activeValue := 0
-		if *query.IsActive {
-			activeValue = 1
-		}
-		mustQueries = append(mustQueries, types.Query{
-			Term: map[string]types.TermQuery{
-				"payload.after.is_active": {Value: activeValue},
-			},
-		})
+	return nil
+}
+
+func (r *productRepositoryImpl) PurgeAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) (int64, error) {
+	result := tx.WithContext(ctx).Unscoped().Where("id IN ?", ids).Delete(&model.Product{})
+	if result.Error != nil {
+		return 0, result.Error
 	}
 
-	if query.CategoryID != 0 {
-		mustQueries = append(mustQueries, types.Query{
-			Term: map[string]types.TermQuery{
-				"payload.after.category_id": {Value: query.CategoryID},
-			},
-		})
+	return result.RowsAffected, nil
+}
+
+func (r *productRepositoryImpl) FindAllForIndex(ctx context.Context, ids []int64) ([]*model.Product, error) {
+	var products []*model.Product
+	if err := r.db.WithContext(ctx).
+		Preload("Category").
+		Preload("Inventory").
+		Preload("Images", "is_thumbnail = true AND status = ?", common.ImageStatusReady).
+		Preload("Variants", "is_active = true").
+		Preload("Attributes").
+		Where("id IN ?", ids).
+		Find(&products).Error; err != nil {
+		return nil, err
 	}
 
-	if len(mustQueries) == 0 {
-		return &types.Query{
-			MatchAll: &types.MatchAllQuery{},
-		}
+	return products, nil
+}
+
+func (r *productRepositoryImpl) FindAllForExport(ctx context.Context, afterID int64, limit int, activeOnly bool) ([]*model.Product, error) {
+	query := r.db.WithContext(ctx).
+		Preload("Category").
+		Preload("Inventory").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Where("variant_id IS NULL AND status = ?", common.ImageStatusReady).Order("is_thumbnail DESC, sort_order ASC")
+		}).
+		Where("id > ?", afterID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
 	}
 
-	if len(mustQueries) == 1 {
-		return &mustQueries[0]
+	var products []*model.Product
+	if err := query.Order("id ASC").Limit(limit).Find(&products).Error; err != nil {
+		return nil, err
 	}
 
-	return &types.Query{
-		Bool: &types.BoolQuery{
-			Must: mustQueries,
-		},
+	return products, nil
+}
+
+func (r *productRepositoryImpl) FindAllIDsAfter(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Model(&model.Product{}).Where("id > ?", afterID).Order("id ASC").Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return nil, err
 	}
+
+	return ids, nil
 }
 
-func buildSort(query request.ProductPaginationQuery) []types.SortCombinations {
-	if query.Sort == "" {
-		if query.Search != "" {
-			return []types.SortCombinations{
-				types.SortOptions{
-					SortOptions: map[string]types.FieldSort{
-						"_score": {Order: &sortorder.Desc},
-					},
-				},
-			}
-		}
+func (r *productRepositoryImpl) FindAllIDsByCategoryIDs(ctx context.Context, categoryIDs []int64) ([]int64, error) {
+	var ids []int64
+	if err := r.db.WithContext(ctx).Model(&model.Product{}).Where("category_id IN ?", categoryIDs).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
 
-		return []types.SortCombinations{
-			types.SortOptions{
-				SortOptions: map[string]types.FieldSort{
-					"payload.after.created_at": {Order: &sortorder.Desc},
-				},
-			},
-		}
+	return ids, nil
+}
+
+func (r *productRepositoryImpl) FindAllSimilarIDs(ctx context.Context, product *model.Product, excludeIDs []int64, limit int) ([]int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.Product{}).
+		Where("category_id = ? AND is_active = ? AND id <> ?", product.CategoryID, true, product.ID)
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
 	}
 
-	order := sortorder.Asc
-	if query.Order == "desc" {
-		order = sortorder.Desc
+	var ids []int64
+	if err := query.Order(gorm.Expr("ABS(price - ?) ASC", product.Price)).Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return nil, err
 	}
 
-	sortField := ""
-	switch query.Sort {
-	case "name":
-		sortField = "payload.after.name.keyword"
-	case "price":
-		sortField = "payload.after.price"
-	case "created_at":
-		sortField = "payload.after.created_at"
-	case "updated_at":
-		sortField = "payload.after.updated_at"
+	return ids, nil
+}
+
+func findByIDBase(ctx context.Context, tx *gorm.DB, id int64, preloads ...string) (*model.Product, error) {
+	var product model.Product
+
+	query := tx.WithContext(ctx)
+	for _, preload := range preloads {
+		query = query.Preload(preload)
 	}
 
-	return []types.SortCombinations{
-		types.SortOptions{
-			SortOptions: map[string]types.FieldSort{
-				sortField: {Order: &order},
-			},
-		},
+	if err := query.Where("id = ?", id).First(&product).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
 	}
+
+	return &product, nil
+}
+
+func getThumbnail(db *gorm.DB) *gorm.DB {
+	return db.Where("is_thumbnail = true")
 }
@@ -0,0 +1,63 @@
+package implement
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type productHistoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewProductHistoryRepository(db *gorm.DB) repository.ProductHistoryRepository {
+	return &productHistoryRepositoryImpl{db}
+}
+
+func (r *productHistoryRepositoryImpl) CreateTx(ctx context.Context, tx *gorm.DB, history *model.ProductHistory) error {
+	var version int
+	if err := tx.WithContext(ctx).Model(&model.ProductHistory{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ?", history.ProductID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&version).Error; err != nil {
+		return err
+	}
+	history.Version = version + 1
+
+	return tx.WithContext(ctx).Create(history).Error
+}
+
+func (r *productHistoryRepositoryImpl) FindAllByProductID(ctx context.Context, productID int64, query request.ProductHistoryPaginationQuery) ([]*model.ProductHistory, int64, error) {
+	db := r.db.WithContext(ctx).Model(&model.ProductHistory{}).Where("product_id = ?", productID)
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var histories []*model.ProductHistory
+	offset := int((query.Page - 1) * query.Limit)
+	if err := db.Order("version DESC").Offset(offset).Limit(int(query.Limit)).Find(&histories).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return histories, total, nil
+}
+
+func (r *productHistoryRepositoryImpl) FindByProductIDAndVersionTx(ctx context.Context, tx *gorm.DB, productID int64, version int) (*model.ProductHistory, error) {
+	var history model.ProductHistory
+	if err := tx.WithContext(ctx).Where("product_id = ? AND version = ?", productID, version).First(&history).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &history, nil
+}
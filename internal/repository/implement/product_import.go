@@ -0,0 +1,85 @@
+package implement
+
+import (
+	"context"
+	"errors"
+
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"gorm.io/gorm"
+)
+
+type productImportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewProductImportRepository(db *gorm.DB) repository.ProductImportRepository {
+	return &productImportRepositoryImpl{db}
+}
+
+func (r *productImportRepositoryImpl) Create(ctx context.Context, job *model.ProductImportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *productImportRepositoryImpl) FindAll(ctx context.Context, query request.ImportJobPaginationQuery) ([]*model.ProductImportJob, int64, error) {
+	db := r.db.WithContext(ctx).Model(&model.ProductImportJob{})
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var jobs []*model.ProductImportJob
+	if err := db.
+		Omit("payload", "row_errors").
+		Order("created_at DESC").
+		Offset(int((query.Page - 1) * query.Limit)).
+		Limit(int(query.Limit)).
+		Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+func (r *productImportRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.ProductImportJob, error) {
+	var job model.ProductImportJob
+	if err := r.db.WithContext(ctx).Omit("payload").Where("id = ?", id).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *productImportRepositoryImpl) FindByIDWithPayload(ctx context.Context, id int64) (*model.ProductImportJob, error) {
+	var job model.ProductImportJob
+	if err := r.db.WithContext(ctx).Preload("User").Where("id = ?", id).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *productImportRepositoryImpl) Update(ctx context.Context, id int64, updateData map[string]any) error {
+	result := r.db.WithContext(ctx).Model(&model.ProductImportJob{}).Where("id = ?", id).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrImportJobNotFound
+	}
+
+	return nil
+}
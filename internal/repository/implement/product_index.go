@@ -0,0 +1,284 @@
+package implement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	internalType "github.com/tienhai2808/ecom_go/internal/types"
+)
+
+const productIndexVersion = 4
+
+const productIndexMapping = `{
+	"settings": {
+		"analysis": {
+			"analyzer": {
+				"folding": {
+					"type": "custom",
+					"tokenizer": "standard",
+					"filter": ["lowercase", "asciifolding"]
+				}
+			}
+		}
+	},
+	"mappings": {
+		"_meta": {"version": 4},
+		"dynamic": "strict",
+		"properties": {
+			"id": {"type": "long"},
+			"name": {
+				"type": "text",
+				"analyzer": "folding",
+				"fields": {
+					"keyword": {"type": "keyword"}
+				}
+			},
+			"slug": {"type": "keyword"},
+			"description": {"type": "text", "analyzer": "folding"},
+			"price": {"type": "double"},
+			"is_active": {"type": "boolean"},
+			"status": {"type": "keyword"},
+			"publish_at": {"type": "date"},
+			"unpublish_at": {"type": "date"},
+			"category_id": {"type": "long"},
+			"category_name": {
+				"type": "text",
+				"analyzer": "folding",
+				"fields": {
+					"keyword": {"type": "keyword"}
+				}
+			},
+			"attributes": {"type": "keyword"},
+			"thumbnail": {"type": "keyword", "index": false},
+			"stock": {"type": "integer"},
+			"rating_average": {"type": "float"},
+			"rating_count": {"type": "integer"},
+			"created_at": {"type": "date"},
+			"updated_at": {"type": "date"}
+		}
+	}
+}`
+
+type productIndexRepositoryImpl struct {
+	es *elasticsearch.TypedClient
+}
+
+func NewProductIndexRepository(es *elasticsearch.TypedClient) repository.ProductIndexRepository {
+	return &productIndexRepositoryImpl{es}
+}
+
+func (r *productIndexRepositoryImpl) AliasExists(ctx context.Context) (bool, error) {
+	if r.es == nil {
+		return false, customErr.ErrSearchUnavailable
+	}
+
+	return r.es.Indices.ExistsAlias(common.IndexProducts).Do(ctx)
+}
+
+func (r *productIndexRepositoryImpl) MappingUpToDate(ctx context.Context) (bool, error) {
+	if r.es == nil {
+		return false, customErr.ErrSearchUnavailable
+	}
+
+	res, err := r.es.Indices.GetMapping().Index(common.IndexProducts).Do(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, record := range res {
+		raw, ok := record.Mappings.Meta_["version"]
+		if !ok {
+			return false, nil
+		}
+
+		var version int
+		if err = json.Unmarshal(raw, &version); err != nil {
+			return false, nil
+		}
+		if version < productIndexVersion {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (r *productIndexRepositoryImpl) CreateIndex(ctx context.Context, index string) error {
+	if r.es == nil {
+		return customErr.ErrSearchUnavailable
+	}
+
+	_, err := r.es.Indices.Create(index).Raw(strings.NewReader(productIndexMapping)).Do(ctx)
+	return err
+}
+
+func (r *productIndexRepositoryImpl) IndexAll(ctx context.Context, index string, docs []*internalType.ProductDocument) error {
+	if r.es == nil {
+		return customErr.ErrSearchUnavailable
+	}
+
+	if len(docs) == 0 {
+		return nil
+	}
+
+	bulk := r.es.Bulk().Index(index)
+	for _, doc := range docs {
+		id := strconv.FormatInt(doc.ID, 10)
+		if err := bulk.IndexOp(types.IndexOperation{Id_: &id}, doc); err != nil {
+			return err
+		}
+	}
+
+	res, err := bulk.Do(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Errors {
+		return fmt.Errorf("một số document không được đánh chỉ mục")
+	}
+
+	return nil
+}
+
+func (r *productIndexRepositoryImpl) DeleteAll(ctx context.Context, index string, ids []int64) error {
+	if r.es == nil {
+		return customErr.ErrSearchUnavailable
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	bulk := r.es.Bulk().Index(index)
+	for _, id := range ids {
+		docID := strconv.FormatInt(id, 10)
+		if err := bulk.DeleteOp(types.DeleteOperation{Id_: &docID}); err != nil {
+			return err
+		}
+	}
+
+	_, err := bulk.Do(ctx)
+	return err
+}
+
+func (r *productIndexRepositoryImpl) MoreLikeThis(ctx context.Context, productID, categoryID int64, price float64, limit int) ([]int64, error) {
+	if r.es == nil {
+		return nil, customErr.ErrSearchUnavailable
+	}
+
+	index := common.IndexProducts
+	docID := strconv.FormatInt(productID, 10)
+	minTermFreq := 1
+	minDocFreq := 1
+	maxQueryTerms := 25
+	categoryBoost := float32(2)
+	minPrice := types.Float64(price * 0.7)
+	maxPrice := types.Float64(price * 1.3)
+
+	req := &search.Request{
+		Query: &types.Query{
+			Bool: &types.BoolQuery{
+				Must: []types.Query{
+					{MoreLikeThis: &types.MoreLikeThisQuery{
+						Fields:        []string{"name", "description", "category_name"},
+						Like:          []types.Like{types.LikeDocument{Index_: &index, Id_: &docID}},
+						MinTermFreq:   &minTermFreq,
+						MinDocFreq:    &minDocFreq,
+						MaxQueryTerms: &maxQueryTerms,
+					}},
+				},
+				Filter: []types.Query{
+					{Term: map[string]types.TermQuery{"is_active": {Value: true}}},
+				},
+				Should: []types.Query{
+					{Term: map[string]types.TermQuery{"category_id": {Value: categoryID, Boost: &categoryBoost}}},
+					{Range: map[string]types.RangeQuery{"price": types.NumberRangeQuery{Gte: &minPrice, Lte: &maxPrice}}},
+				},
+			},
+		},
+		Size: &limit,
+	}
+
+	res, err := r.es.Search().Index(index).Request(req).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tìm kiếm sản phẩm liên quan thất bại: %w", err)
+	}
+
+	ids := make([]int64, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		var doc internalType.ProductDocument
+		if err := json.Unmarshal(hit.Source_, &doc); err != nil {
+			return nil, fmt.Errorf("lỗi giải mã document: %w", err)
+		}
+		ids = append(ids, doc.ID)
+	}
+
+	return ids, nil
+}
+
+func (r *productIndexRepositoryImpl) SwapAlias(ctx context.Context, index string) ([]string, error) {
+	if r.es == nil {
+		return nil, customErr.ErrSearchUnavailable
+	}
+
+	if _, err := r.es.Indices.Refresh().Index(index).Do(ctx); err != nil {
+		return nil, err
+	}
+
+	exists, err := r.AliasExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alias := common.IndexProducts
+	oldIndices := []string{}
+	if exists {
+		res, err := r.es.Indices.GetAlias().Name(alias).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for name := range res {
+			if name != index {
+				oldIndices = append(oldIndices, name)
+			}
+		}
+	}
+
+	actions := make([]types.IndicesAction, 0, len(oldIndices)+1)
+	for _, name := range oldIndices {
+		actions = append(actions, types.IndicesAction{
+			Remove: &types.RemoveAction{Index: &name, Alias: &alias},
+		})
+	}
+	actions = append(actions, types.IndicesAction{
+		Add: &types.AddAction{Index: &index, Alias: &alias},
+	})
+
+	if _, err = r.es.Indices.UpdateAliases().Actions(actions...).Do(ctx); err != nil {
+		return nil, err
+	}
+
+	return oldIndices, nil
+}
+
+func (r *productIndexRepositoryImpl) DeleteIndices(ctx context.Context, indices []string) error {
+	if r.es == nil {
+		return customErr.ErrSearchUnavailable
+	}
+
+	if len(indices) == 0 {
+		return nil
+	}
+
+	_, err := r.es.Indices.Delete(strings.Join(indices, ",")).Do(ctx)
+	return err
+}
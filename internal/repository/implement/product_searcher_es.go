@@ -0,0 +1,516 @@
+package implement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	internalType "github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type esProductSearcherImpl struct {
+	es *elasticsearch.TypedClient
+}
+
+func NewESProductSearcher(es *elasticsearch.TypedClient) repository.ProductSearcher {
+	return &esProductSearcherImpl{es}
+}
+
+func (r *esProductSearcherImpl) Search(ctx context.Context, query request.ProductPaginationQuery) (*internalType.ProductSearchResult, error) {
+	if r.es == nil {
+		return nil, customErr.ErrSearchUnavailable
+	}
+
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	from := int((query.Page - 1) * query.Limit)
+	size := int(query.Limit)
+
+	req := buildSearchRequest(query)
+	req.From = &from
+	req.Size = &size
+
+	res, err := r.es.Search().
+		Index(common.IndexProducts).
+		Request(req).
+		TypedKeys(true).
+		Do(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("tìm kiếm thất bại: %w", err)
+	}
+
+	productIDs := make([]int64, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		var doc internalType.ProductDocument
+		if err := json.Unmarshal(hit.Source_, &doc); err != nil {
+			return nil, fmt.Errorf("lỗi giải mã document: %w", err)
+		}
+		productIDs = append(productIDs, doc.ID)
+	}
+
+	total := res.Hits.Total.Value
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+
+	result := &internalType.ProductSearchResult{
+		IDs:        productIDs,
+		Total:      total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: totalPages,
+		HasPrev:    query.Page > 1,
+		HasNext:    int64(query.Page) < totalPages,
+	}
+	parseAggregations(res.Aggregations, query, result)
+
+	return result, nil
+}
+
+func buildSearchRequest(query request.ProductPaginationQuery) *search.Request {
+	categoryFilter := buildCategoryFilter(query)
+	priceFilter := buildPriceFilter(query)
+	attributeFilters := buildAttributeFilters(query)
+
+	return &search.Request{
+		Query:        buildQuery(query),
+		PostFilter:   buildPostFilter(append([]*types.Query{categoryFilter, priceFilter}, attributeFilters.except(0)...)...),
+		Aggregations: buildAggregations(query, categoryFilter, priceFilter, attributeFilters),
+		Sort:         buildSort(query),
+	}
+}
+
+func buildQuery(query request.ProductPaginationQuery) *types.Query {
+	var mustQueries, mustNotQueries []types.Query
+
+	if query.Search != "" {
+		mustQueries = append(mustQueries, types.Query{
+			MultiMatch: &types.MultiMatchQuery{
+				Query:  query.Search,
+				Fields: []string{"name^3", "category_name^2", "description"},
+			},
+		})
+	}
+
+	if query.IsActive != nil {
+		mustQueries = append(mustQueries, types.Query{
+			Term: map[string]types.TermQuery{
+				"is_active": {Value: *query.IsActive},
+			},
+		})
+	}
+
+	if query.Status != "" {
+		mustQueries = append(mustQueries, types.Query{
+			Term: map[string]types.TermQuery{
+				"status": {Value: query.Status},
+			},
+		})
+	}
+
+	if query.PublishedOnly {
+		now := "now"
+		mustQueries = append(mustQueries, types.Query{
+			Term: map[string]types.TermQuery{
+				"status": {Value: common.ProductStatusPublished},
+			},
+		})
+		mustNotQueries = append(mustNotQueries, types.Query{
+			Range: map[string]types.RangeQuery{
+				"unpublish_at": types.DateRangeQuery{Lte: &now},
+			},
+		})
+	}
+
+	if query.InStock {
+		minStock := types.Float64(1)
+		mustQueries = append(mustQueries, types.Query{
+			Range: map[string]types.RangeQuery{
+				"stock": types.NumberRangeQuery{Gte: &minStock},
+			},
+		})
+	}
+
+	if len(mustQueries) == 0 {
+		mustQueries = append(mustQueries, types.Query{
+			MatchAll: &types.MatchAllQuery{},
+		})
+	}
+
+	return &types.Query{
+		Bool: &types.BoolQuery{
+			Must:    mustQueries,
+			MustNot: mustNotQueries,
+		},
+	}
+}
+
+func buildCategoryFilter(query request.ProductPaginationQuery) *types.Query {
+	if len(query.FilterCategoryIDs) > 0 {
+		filter := buildTermsQuery("category_id", query.FilterCategoryIDs)
+		return &filter
+	}
+
+	if query.CategoryID != 0 {
+		return &types.Query{
+			Term: map[string]types.TermQuery{
+				"category_id": {Value: query.CategoryID},
+			},
+		}
+	}
+
+	return nil
+}
+
+func buildPriceFilter(query request.ProductPaginationQuery) *types.Query {
+	if query.MinPrice == nil && query.MaxPrice == nil {
+		return nil
+	}
+
+	priceRange := types.NumberRangeQuery{}
+	if query.MinPrice != nil {
+		minPrice := types.Float64(*query.MinPrice)
+		priceRange.Gte = &minPrice
+	}
+	if query.MaxPrice != nil {
+		maxPrice := types.Float64(*query.MaxPrice)
+		priceRange.Lte = &maxPrice
+	}
+
+	return &types.Query{
+		Range: map[string]types.RangeQuery{
+			"price": priceRange,
+		},
+	}
+}
+
+type attributeFilter struct {
+	attributeID int64
+	query       *types.Query
+}
+
+type attributeFilterList []*attributeFilter
+
+func buildAttributeFilters(query request.ProductPaginationQuery) attributeFilterList {
+	attributeIDs := make([]int64, 0, len(query.Attributes))
+	for id := range query.Attributes {
+		attributeIDs = append(attributeIDs, id)
+	}
+	sort.Slice(attributeIDs, func(i, j int) bool { return attributeIDs[i] < attributeIDs[j] })
+
+	filters := make(attributeFilterList, 0, len(attributeIDs))
+	for _, id := range attributeIDs {
+		values := make([]types.FieldValue, 0, len(query.Attributes[id]))
+		for _, value := range query.Attributes[id] {
+			values = append(values, common.AttributeTerm(id, value))
+		}
+
+		filters = append(filters, &attributeFilter{
+			attributeID: id,
+			query: &types.Query{
+				Terms: &types.TermsQuery{
+					TermsQuery: map[string]types.TermsQueryField{
+						"attributes": values,
+					},
+				},
+			},
+		})
+	}
+
+	return filters
+}
+
+func (l attributeFilterList) except(attributeID int64) []*types.Query {
+	queries := make([]*types.Query, 0, len(l))
+	for _, filter := range l {
+		if filter.attributeID != attributeID {
+			queries = append(queries, filter.query)
+		}
+	}
+
+	return queries
+}
+
+func buildPostFilter(filters ...*types.Query) *types.Query {
+	var filterQueries []types.Query
+	for _, filter := range filters {
+		if filter != nil {
+			filterQueries = append(filterQueries, *filter)
+		}
+	}
+
+	if len(filterQueries) == 0 {
+		return &types.Query{
+			MatchAll: &types.MatchAllQuery{},
+		}
+	}
+
+	return &types.Query{
+		Bool: &types.BoolQuery{
+			Filter: filterQueries,
+		},
+	}
+}
+
+func buildAggregations(query request.ProductPaginationQuery, categoryFilter, priceFilter *types.Query, attributeFilters attributeFilterList) map[string]types.Aggregations {
+	categoryField := "category_id"
+	priceField := "price"
+	attributeField := "attributes"
+	categorySize := 100
+	attributeSize := 500
+	attributeValueSize := 100
+	minDocCount := 1
+
+	interval := types.Float64(query.PriceInterval)
+	if interval <= 0 {
+		interval = 100000
+	}
+
+	aggregations := map[string]types.Aggregations{
+		"categories": {
+			Filter: buildPostFilter(append([]*types.Query{priceFilter}, attributeFilters.except(0)...)...),
+			Aggregations: map[string]types.Aggregations{
+				"ids": {
+					Terms: &types.TermsAggregation{
+						Field: &categoryField,
+						Size:  &categorySize,
+					},
+				},
+			},
+		},
+		"prices": {
+			Filter: buildPostFilter(append([]*types.Query{categoryFilter}, attributeFilters.except(0)...)...),
+			Aggregations: map[string]types.Aggregations{
+				"histogram": {
+					Histogram: &types.HistogramAggregation{
+						Field:       &priceField,
+						Interval:    &interval,
+						MinDocCount: &minDocCount,
+					},
+				},
+				"stats": {
+					Stats: &types.StatsAggregation{
+						Field: &priceField,
+					},
+				},
+			},
+		},
+		"attributes": {
+			Filter: buildPostFilter(append([]*types.Query{categoryFilter, priceFilter}, attributeFilters.except(0)...)...),
+			Aggregations: map[string]types.Aggregations{
+				"values": {
+					Terms: &types.TermsAggregation{
+						Field: &attributeField,
+						Size:  &attributeSize,
+					},
+				},
+			},
+		},
+	}
+
+	for _, filter := range attributeFilters {
+		aggregations[attributeAggregationName(filter.attributeID)] = types.Aggregations{
+			Filter: buildPostFilter(append([]*types.Query{categoryFilter, priceFilter}, attributeFilters.except(filter.attributeID)...)...),
+			Aggregations: map[string]types.Aggregations{
+				"values": {
+					Terms: &types.TermsAggregation{
+						Field:   &attributeField,
+						Size:    &attributeValueSize,
+						Include: strconv.FormatInt(filter.attributeID, 10) + ":.*",
+					},
+				},
+			},
+		}
+	}
+
+	return aggregations
+}
+
+func attributeAggregationName(attributeID int64) string {
+	return "attribute_" + strconv.FormatInt(attributeID, 10)
+}
+
+func parseAggregations(aggs map[string]types.Aggregate, query request.ProductPaginationQuery, result *internalType.ProductSearchResult) {
+	interval := query.PriceInterval
+	if interval <= 0 {
+		interval = 100000
+	}
+
+	if categories, ok := aggs["categories"].(*types.FilterAggregate); ok {
+		if ids, ok := categories.Aggregations["ids"].(*types.LongTermsAggregate); ok {
+			if buckets, ok := ids.Buckets.([]types.LongTermsBucket); ok {
+				for _, bucket := range buckets {
+					result.CategoryCounts = append(result.CategoryCounts, &internalType.CategoryCount{
+						CategoryID: bucket.Key,
+						Count:      bucket.DocCount,
+					})
+				}
+			}
+		}
+	}
+
+	if prices, ok := aggs["prices"].(*types.FilterAggregate); ok {
+		if histogram, ok := prices.Aggregations["histogram"].(*types.HistogramAggregate); ok {
+			if buckets, ok := histogram.Buckets.([]types.HistogramBucket); ok {
+				for _, bucket := range buckets {
+					result.PriceBuckets = append(result.PriceBuckets, &internalType.PriceBucket{
+						From:  float64(bucket.Key),
+						To:    float64(bucket.Key) + interval,
+						Count: bucket.DocCount,
+					})
+				}
+			}
+		}
+
+		if stats, ok := prices.Aggregations["stats"].(*types.StatsAggregate); ok && stats.Count > 0 {
+			if stats.Min != nil {
+				minPrice := float64(*stats.Min)
+				result.PriceMin = &minPrice
+			}
+			if stats.Max != nil {
+				maxPrice := float64(*stats.Max)
+				result.PriceMax = &maxPrice
+			}
+		}
+	}
+
+	for _, count := range parseAttributeCounts(aggs["attributes"]) {
+		if _, selected := query.Attributes[count.AttributeID]; !selected {
+			result.AttributeCounts = append(result.AttributeCounts, count)
+		}
+	}
+	for _, filter := range buildAttributeFilters(query) {
+		for _, count := range parseAttributeCounts(aggs[attributeAggregationName(filter.attributeID)]) {
+			if count.AttributeID == filter.attributeID {
+				result.AttributeCounts = append(result.AttributeCounts, count)
+			}
+		}
+	}
+}
+
+func parseAttributeCounts(agg types.Aggregate) []*internalType.AttributeValueCount {
+	filter, ok := agg.(*types.FilterAggregate)
+	if !ok {
+		return nil
+	}
+
+	values, ok := filter.Aggregations["values"].(*types.StringTermsAggregate)
+	if !ok {
+		return nil
+	}
+
+	buckets, ok := values.Buckets.([]types.StringTermsBucket)
+	if !ok {
+		return nil
+	}
+
+	counts := make([]*internalType.AttributeValueCount, 0, len(buckets))
+	for _, bucket := range buckets {
+		key, ok := bucket.Key.(string)
+		if !ok {
+			continue
+		}
+
+		attributeID, value, ok := common.ParseAttributeTerm(key)
+		if !ok {
+			continue
+		}
+
+		counts = append(counts, &internalType.AttributeValueCount{
+			AttributeID: attributeID,
+			Value:       value,
+			Count:       bucket.DocCount,
+		})
+	}
+
+	return counts
+}
+
+func buildTermsQuery(field string, ids []int64) types.Query {
+	values := make([]types.FieldValue, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id)
+	}
+
+	return types.Query{
+		Terms: &types.TermsQuery{
+			TermsQuery: map[string]types.TermsQueryField{
+				field: values,
+			},
+		},
+	}
+}
+
+func buildSort(query request.ProductPaginationQuery) []types.SortCombinations {
+	if query.Sort == "" {
+		if query.Search != "" {
+			return []types.SortCombinations{
+				types.SortOptions{
+					SortOptions: map[string]types.FieldSort{
+						"_score": {Order: &sortorder.Desc},
+					},
+				},
+			}
+		}
+
+		return []types.SortCombinations{
+			types.SortOptions{
+				SortOptions: map[string]types.FieldSort{
+					"created_at": {Order: &sortorder.Desc},
+				},
+			},
+		}
+	}
+
+	order := sortorder.Asc
+	if query.Order == "desc" {
+		order = sortorder.Desc
+	}
+
+	sortField := ""
+	switch query.Sort {
+	case "name":
+		sortField = "name.keyword"
+	case "price":
+		sortField = "price"
+	case "created_at":
+		sortField = "created_at"
+	case "updated_at":
+		sortField = "updated_at"
+	case "rating":
+		return []types.SortCombinations{
+			types.SortOptions{
+				SortOptions: map[string]types.FieldSort{
+					"rating_average": {Order: &order},
+				},
+			},
+			types.SortOptions{
+				SortOptions: map[string]types.FieldSort{
+					"rating_count": {Order: &order},
+				},
+			},
+		}
+	}
+
+	return []types.SortCombinations{
+		types.SortOptions{
+			SortOptions: map[string]types.FieldSort{
+				sortField: {Order: &order},
+			},
+		},
+	}
+}
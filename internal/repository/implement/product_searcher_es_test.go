@@ -0,0 +1,176 @@
+package implement
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	internalType "github.com/tienhai2808/ecom_go/internal/types"
+)
+
+func facetTestQuery() request.ProductPaginationQuery {
+	minPrice := 100000.0
+	return request.ProductPaginationQuery{
+		Search:            "áo thun",
+		FilterCategoryIDs: []int64{10, 11},
+		MinPrice:          &minPrice,
+		Attributes: map[int64][]string{
+			1: {"Đỏ", "Xanh"},
+			2: {"M"},
+		},
+	}
+}
+
+func filterSummary(q *types.Query) (attributeTerms []string, hasCategory, hasPrice bool) {
+	if q == nil || q.Bool == nil {
+		return nil, false, false
+	}
+
+	for _, filter := range q.Bool.Filter {
+		if filter.Terms != nil {
+			for field, values := range filter.Terms.TermsQuery {
+				switch field {
+				case "attributes":
+					for _, value := range values.([]types.FieldValue) {
+						attributeTerms = append(attributeTerms, value.(string))
+					}
+				case "category_id":
+					hasCategory = true
+				}
+			}
+		}
+		if _, ok := filter.Range["price"]; ok {
+			hasPrice = true
+		}
+	}
+	sort.Strings(attributeTerms)
+
+	return attributeTerms, hasCategory, hasPrice
+}
+
+func assertTerms(t *testing.T, name string, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got attribute terms %v, want %v", name, got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%s: got attribute terms %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestBuildSearchRequestAppliesCombinedFacetFilters(t *testing.T) {
+	req := buildSearchRequest(facetTestQuery())
+
+	if req.Query == nil || req.Query.Bool == nil || req.Query.Bool.Must[0].MultiMatch == nil {
+		t.Fatalf("search text must stay in the main query")
+	}
+
+	terms, hasCategory, hasPrice := filterSummary(req.PostFilter)
+	if !hasCategory || !hasPrice {
+		t.Fatalf("post_filter must contain category and price filters")
+	}
+	assertTerms(t, "post_filter", terms, []string{"1:Xanh", "1:Đỏ", "2:M"})
+
+	terms, hasCategory, hasPrice = filterSummary(req.Aggregations["attributes"].Filter)
+	if !hasCategory || !hasPrice {
+		t.Fatalf("attributes aggregation must respect category and price filters")
+	}
+	assertTerms(t, "attributes", terms, []string{"1:Xanh", "1:Đỏ", "2:M"})
+
+	terms, hasCategory, hasPrice = filterSummary(req.Aggregations["attribute_1"].Filter)
+	if !hasCategory || !hasPrice {
+		t.Fatalf("attribute_1 aggregation must respect category and price filters")
+	}
+	assertTerms(t, "attribute_1", terms, []string{"2:M"})
+	if include := req.Aggregations["attribute_1"].Aggregations["values"].Terms.Include; include != "1:.*" {
+		t.Fatalf("attribute_1 aggregation include = %v, want 1:.*", include)
+	}
+
+	terms, _, _ = filterSummary(req.Aggregations["attribute_2"].Filter)
+	assertTerms(t, "attribute_2", terms, []string{"1:Xanh", "1:Đỏ"})
+
+	terms, hasCategory, hasPrice = filterSummary(req.Aggregations["categories"].Filter)
+	if hasCategory || !hasPrice {
+		t.Fatalf("categories aggregation must ignore the category filter and keep the price filter")
+	}
+	assertTerms(t, "categories", terms, []string{"1:Xanh", "1:Đỏ", "2:M"})
+
+	terms, hasCategory, hasPrice = filterSummary(req.Aggregations["prices"].Filter)
+	if !hasCategory || hasPrice {
+		t.Fatalf("prices aggregation must keep the category filter and ignore the price filter")
+	}
+	assertTerms(t, "prices", terms, []string{"1:Xanh", "1:Đỏ", "2:M"})
+}
+
+func TestParseAggregationsCountsAttributesUnderCombinedFilters(t *testing.T) {
+	raw := `{
+		"took": 1,
+		"timed_out": false,
+		"_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0},
+		"hits": {"total": {"value": 3, "relation": "eq"}, "hits": []},
+		"aggregations": {
+			"filter#attributes": {
+				"doc_count": 3,
+				"sterms#values": {"buckets": [
+					{"key": "1:Đỏ", "doc_count": 2},
+					{"key": "1:Xanh", "doc_count": 1},
+					{"key": "2:M", "doc_count": 3},
+					{"key": "3:Cotton", "doc_count": 2},
+					{"key": "3:Len", "doc_count": 1}
+				]}
+			},
+			"filter#attribute_1": {
+				"doc_count": 7,
+				"sterms#values": {"buckets": [
+					{"key": "1:Đỏ", "doc_count": 4},
+					{"key": "1:Vàng", "doc_count": 2},
+					{"key": "1:Xanh", "doc_count": 1}
+				]}
+			},
+			"filter#attribute_2": {
+				"doc_count": 5,
+				"sterms#values": {"buckets": [
+					{"key": "2:M", "doc_count": 3},
+					{"key": "2:L", "doc_count": 2}
+				]}
+			}
+		}
+	}`
+
+	var res search.Response
+	if err := json.Unmarshal([]byte(raw), &res); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	result := &internalType.ProductSearchResult{}
+	parseAggregations(res.Aggregations, facetTestQuery(), result)
+
+	got := make(map[int64]map[string]int64)
+	for _, count := range result.AttributeCounts {
+		if got[count.AttributeID] == nil {
+			got[count.AttributeID] = make(map[string]int64)
+		}
+		got[count.AttributeID][count.Value] = count.Count
+	}
+
+	want := map[int64]map[string]int64{
+		1: {"Đỏ": 4, "Vàng": 2, "Xanh": 1},
+		2: {"M": 3, "L": 2},
+		3: {"Cotton": 2, "Len": 1},
+	}
+	for attributeID, values := range want {
+		if len(got[attributeID]) != len(values) {
+			t.Fatalf("attribute %d: got %v, want %v", attributeID, got[attributeID], values)
+		}
+		for value, count := range values {
+			if got[attributeID][value] != count {
+				t.Fatalf("attribute %d value %s: got %d, want %d", attributeID, value, got[attributeID][value], count)
+			}
+		}
+	}
+}
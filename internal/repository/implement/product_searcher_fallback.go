@@ -0,0 +1,117 @@
+package implement
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	internalType "github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type fallbackProductSearcherImpl struct {
+	primary   repository.ProductSearcher
+	secondary repository.ProductSearcher
+	threshold int
+	cooldown  time.Duration
+	timeout   time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func NewFallbackProductSearcher(primary, secondary repository.ProductSearcher, threshold int, cooldown, timeout time.Duration) repository.ProductSearcher {
+	if threshold <= 0 {
+		threshold = 3
+	}
+	if cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+	if timeout <= 0 {
+		timeout = 1500 * time.Millisecond
+	}
+
+	return &fallbackProductSearcherImpl{
+		primary:   primary,
+		secondary: secondary,
+		threshold: threshold,
+		cooldown:  cooldown,
+		timeout:   timeout,
+	}
+}
+
+func (r *fallbackProductSearcherImpl) Search(ctx context.Context, query request.ProductPaginationQuery) (*internalType.ProductSearchResult, error) {
+	if !r.allow() {
+		return r.secondary.Search(ctx, query)
+	}
+
+	primaryCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	result, err := r.primary.Search(primaryCtx, query)
+	if err == nil {
+		r.onSuccess()
+		return result, nil
+	}
+
+	if ctx.Err() != nil {
+		r.onCanceled()
+		return nil, ctx.Err()
+	}
+
+	r.onFailure()
+	log.Printf("tìm kiếm bằng Elasticsearch thất bại, chuyển sang MySQL: %v", err)
+
+	return r.secondary.Search(ctx, query)
+}
+
+func (r *fallbackProductSearcherImpl) allow() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failures < r.threshold {
+		return true
+	}
+	if time.Now().Before(r.openUntil) || r.probing {
+		return false
+	}
+
+	r.probing = true
+	return true
+}
+
+func (r *fallbackProductSearcherImpl) onSuccess() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failures >= r.threshold {
+		log.Println("Elasticsearch hoạt động trở lại, đóng circuit breaker")
+	}
+	r.failures = 0
+	r.probing = false
+}
+
+func (r *fallbackProductSearcherImpl) onCanceled() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.probing = false
+}
+
+func (r *fallbackProductSearcherImpl) onFailure() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures++
+	r.probing = false
+	if r.failures >= r.threshold {
+		r.openUntil = time.Now().Add(r.cooldown)
+		if r.failures == r.threshold {
+			log.Printf("Elasticsearch lỗi %d lần liên tiếp, mở circuit breaker trong %s", r.failures, r.cooldown)
+		}
+	}
+}
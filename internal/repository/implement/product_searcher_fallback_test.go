@@ -0,0 +1,67 @@
+package implement
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/request"
+	internalType "github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type blockingSearcher struct{}
+
+func (blockingSearcher) Search(ctx context.Context, _ request.ProductPaginationQuery) (*internalType.ProductSearchResult, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+type staticSearcher struct {
+	calls int
+}
+
+func (s *staticSearcher) Search(context.Context, request.ProductPaginationQuery) (*internalType.ProductSearchResult, error) {
+	s.calls++
+	return &internalType.ProductSearchResult{Total: 1}, nil
+}
+
+func TestFallbackSearchTimesOutBlockingPrimary(t *testing.T) {
+	secondary := &staticSearcher{}
+	searcher := NewFallbackProductSearcher(blockingSearcher{}, secondary, 3, time.Minute, 20*time.Millisecond).(*fallbackProductSearcherImpl)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	result, err := searcher.Search(ctx, request.ProductPaginationQuery{})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("fallback took %s, want it bounded by the primary timeout", elapsed)
+	}
+	if result.Total != 1 || secondary.calls != 1 {
+		t.Fatalf("secondary result was not returned")
+	}
+	if searcher.failures != 1 {
+		t.Fatalf("failures = %d, want 1", searcher.failures)
+	}
+}
+
+func TestFallbackSearchIgnoresCallerCancellation(t *testing.T) {
+	secondary := &staticSearcher{}
+	searcher := NewFallbackProductSearcher(blockingSearcher{}, secondary, 3, time.Minute, time.Second).(*fallbackProductSearcherImpl)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := searcher.Search(ctx, request.ProductPaginationQuery{}); err == nil {
+		t.Fatalf("search must return the caller's context error")
+	}
+	if searcher.failures != 0 {
+		t.Fatalf("failures = %d, want 0", searcher.failures)
+	}
+	if secondary.calls != 0 {
+		t.Fatalf("secondary must not run after the caller gave up")
+	}
+}
@@ -0,0 +1,250 @@
+package implement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	internalType "github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
+)
+
+type mysqlProductSearcherImpl struct {
+	db *gorm.DB
+}
+
+func NewMySQLProductSearcher(db *gorm.DB) repository.ProductSearcher {
+	return &mysqlProductSearcherImpl{db}
+}
+
+func (r *mysqlProductSearcherImpl) Search(ctx context.Context, query request.ProductPaginationQuery) (*internalType.ProductSearchResult, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	var total int64
+	if err := r.baseQuery(ctx, query).Scopes(categoryScope(query), priceScope(query), r.attributeScope(query, 0)).Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("tìm kiếm thất bại: %w", err)
+	}
+
+	var productIDs []int64
+	if err := r.baseQuery(ctx, query).
+		Scopes(categoryScope(query), priceScope(query), r.attributeScope(query, 0)).
+		Order(mysqlSortClause(query)).
+		Offset(int((query.Page - 1) * query.Limit)).
+		Limit(int(query.Limit)).
+		Pluck("products.id", &productIDs).Error; err != nil {
+		return nil, fmt.Errorf("tìm kiếm thất bại: %w", err)
+	}
+
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+
+	result := &internalType.ProductSearchResult{
+		IDs:        productIDs,
+		Total:      total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: totalPages,
+		HasPrev:    query.Page > 1,
+		HasNext:    int64(query.Page) < totalPages,
+	}
+
+	if err := r.aggregate(ctx, query, result); err != nil {
+		return nil, fmt.Errorf("thống kê kết quả tìm kiếm thất bại: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *mysqlProductSearcherImpl) baseQuery(ctx context.Context, query request.ProductPaginationQuery) *gorm.DB {
+	db := r.db.WithContext(ctx).Model(&model.Product{})
+
+	if query.Search != "" {
+		keyword := "%" + query.Search + "%"
+		db = db.Where("products.name LIKE ? OR products.description LIKE ?", keyword, keyword)
+	}
+
+	if query.IsActive != nil {
+		db = db.Where("products.is_active = ?", *query.IsActive)
+	}
+
+	if query.Status != "" {
+		db = db.Where("products.status = ?", query.Status)
+	}
+
+	if query.PublishedOnly {
+		db = db.Where("products.status = ? AND (products.unpublish_at IS NULL OR products.unpublish_at > ?)", common.ProductStatusPublished, time.Now())
+	}
+
+	if query.InStock {
+		db = db.Joins("JOIN inventories ON inventories.product_id = products.id").Where("inventories.stock > 0")
+	}
+
+	return db
+}
+
+func (r *mysqlProductSearcherImpl) aggregate(ctx context.Context, query request.ProductPaginationQuery, result *internalType.ProductSearchResult) error {
+	var categoryCounts []*internalType.CategoryCount
+	if err := r.baseQuery(ctx, query).
+		Scopes(priceScope(query), r.attributeScope(query, 0)).
+		Select("products.category_id AS category_id, COUNT(*) AS count").
+		Group("products.category_id").
+		Order("count DESC").
+		Limit(100).
+		Scan(&categoryCounts).Error; err != nil {
+		return err
+	}
+	result.CategoryCounts = categoryCounts
+
+	interval := query.PriceInterval
+	if interval <= 0 {
+		interval = 100000
+	}
+
+	var buckets []struct {
+		Bucket float64
+		Count  int64
+	}
+	if err := r.baseQuery(ctx, query).
+		Scopes(categoryScope(query), r.attributeScope(query, 0)).
+		Select("FLOOR(products.price / ?) AS bucket, COUNT(*) AS count", interval).
+		Group("bucket").
+		Order("bucket ASC").
+		Scan(&buckets).Error; err != nil {
+		return err
+	}
+	for _, bucket := range buckets {
+		from := bucket.Bucket * interval
+		result.PriceBuckets = append(result.PriceBuckets, &internalType.PriceBucket{
+			From:  from,
+			To:    from + interval,
+			Count: bucket.Count,
+		})
+	}
+
+	var stats struct {
+		MinPrice *float64
+		MaxPrice *float64
+	}
+	if err := r.baseQuery(ctx, query).
+		Scopes(categoryScope(query), r.attributeScope(query, 0)).
+		Select("MIN(products.price) AS min_price, MAX(products.price) AS max_price").
+		Scan(&stats).Error; err != nil {
+		return err
+	}
+	result.PriceMin = stats.MinPrice
+	result.PriceMax = stats.MaxPrice
+
+	selectedIDs := make([]int64, 0, len(query.Attributes))
+	for id := range query.Attributes {
+		selectedIDs = append(selectedIDs, id)
+	}
+
+	attributeCounts, err := r.countAttributeValues(ctx, query, 0, selectedIDs)
+	if err != nil {
+		return err
+	}
+	result.AttributeCounts = attributeCounts
+
+	for _, id := range selectedIDs {
+		counts, err := r.countAttributeValues(ctx, query, id, nil)
+		if err != nil {
+			return err
+		}
+		result.AttributeCounts = append(result.AttributeCounts, counts...)
+	}
+
+	return nil
+}
+
+func (r *mysqlProductSearcherImpl) countAttributeValues(ctx context.Context, query request.ProductPaginationQuery, attributeID int64, excludeIDs []int64) ([]*internalType.AttributeValueCount, error) {
+	productIDs := r.baseQuery(ctx, query).
+		Scopes(categoryScope(query), priceScope(query), r.attributeScope(query, attributeID)).
+		Select("products.id")
+
+	db := r.db.WithContext(ctx).Model(&model.ProductAttributeValue{}).
+		Select("product_attribute_values.attribute_id, product_attribute_values.value, COUNT(DISTINCT product_attribute_values.product_id) AS count").
+		Where("product_attribute_values.product_id IN (?)", productIDs)
+	if attributeID != 0 {
+		db = db.Where("product_attribute_values.attribute_id = ?", attributeID)
+	}
+	if len(excludeIDs) > 0 {
+		db = db.Where("product_attribute_values.attribute_id NOT IN ?", excludeIDs)
+	}
+
+	var counts []*internalType.AttributeValueCount
+	if err := db.Group("product_attribute_values.attribute_id, product_attribute_values.value").
+		Order("count DESC, product_attribute_values.value ASC").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *mysqlProductSearcherImpl) attributeScope(query request.ProductPaginationQuery, exceptID int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for attributeID, values := range query.Attributes {
+			if attributeID == exceptID {
+				continue
+			}
+
+			subQuery := r.db.Model(&model.ProductAttributeValue{}).Select("product_id").Where("attribute_id = ? AND value IN ?", attributeID, values)
+			db = db.Where("products.id IN (?)", subQuery)
+		}
+		return db
+	}
+}
+
+func categoryScope(query request.ProductPaginationQuery) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(query.FilterCategoryIDs) > 0 {
+			return db.Where("products.category_id IN ?", query.FilterCategoryIDs)
+		}
+		if query.CategoryID != 0 {
+			return db.Where("products.category_id = ?", query.CategoryID)
+		}
+		return db
+	}
+}
+
+func priceScope(query request.ProductPaginationQuery) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if query.MinPrice != nil {
+			db = db.Where("products.price >= ?", *query.MinPrice)
+		}
+		if query.MaxPrice != nil {
+			db = db.Where("products.price <= ?", *query.MaxPrice)
+		}
+		return db
+	}
+}
+
+func mysqlSortClause(query request.ProductPaginationQuery) string {
+	order := "ASC"
+	if query.Order == "desc" {
+		order = "DESC"
+	}
+
+	switch query.Sort {
+	case "name":
+		return "products.name " + order
+	case "price":
+		return "products.price " + order
+	case "created_at":
+		return "products.created_at " + order
+	case "updated_at":
+		return "products.updated_at " + order
+	case "rating":
+		return "products.rating_average " + order + ", products.rating_count " + order
+	}
+
+	return "products.created_at DESC"
+}
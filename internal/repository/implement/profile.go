@@ -43,3 +43,20 @@ func (r *profileRepositoryImpl) Update(ctx context.Context, id int64, updateData
 
 	return nil
 }
+
+func (r *profileRepositoryImpl) UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error {
+	result := tx.WithContext(ctx).Model(&model.Profile{}).Where("id = ?", id).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrProfileNotFound
+	}
+
+	return nil
+}
+
+func (r *profileRepositoryImpl) UpdateByUserIDTx(ctx context.Context, tx *gorm.DB, userID int64, updateData map[string]any) error {
+	return tx.WithContext(ctx).Model(&model.Profile{}).Where("user_id = ?", userID).Updates(updateData).Error
+}
@@ -0,0 +1,150 @@
+package implement
+
+import (
+	"context"
+	"errors"
+
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"gorm.io/gorm"
+)
+
+type reviewRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) repository.ReviewRepository {
+	return &reviewRepositoryImpl{db}
+}
+
+func (r *reviewRepositoryImpl) FindAll(ctx context.Context, query request.ReviewPaginationQuery) ([]*model.Review, int64, error) {
+	db := r.db.WithContext(ctx).Model(&model.Review{})
+	if query.ProductID != 0 {
+		db = db.Where("product_id = ?", query.ProductID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.Rating != 0 {
+		db = db.Where("rating = ?", query.Rating)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reviews []*model.Review
+	if err := db.
+		Preload("User").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
+		Order("created_at DESC").
+		Offset(int((query.Page - 1) * query.Limit)).
+		Limit(int(query.Limit)).
+		Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+func (r *reviewRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.Review, error) {
+	var review model.Review
+	if err := r.db.WithContext(ctx).Preload("Images").Where("id = ?", id).First(&review).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *reviewRepositoryImpl) FindByIDWithDetails(ctx context.Context, id int64) (*model.Review, error) {
+	var review model.Review
+	if err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
+		Where("id = ?", id).
+		First(&review).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *reviewRepositoryImpl) FindAllByUserIDWithImages(ctx context.Context, userID int64) ([]*model.Review, error) {
+	var reviews []*model.Review
+	if err := r.db.WithContext(ctx).
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *reviewRepositoryImpl) ExistsByProductIDAndUserID(ctx context.Context, productID, userID int64) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.Review{}).Where("product_id = ? AND user_id = ?", productID, userID).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *reviewRepositoryImpl) CreateTx(ctx context.Context, tx *gorm.DB, review *model.Review) error {
+	return tx.WithContext(ctx).Create(review).Error
+}
+
+func (r *reviewRepositoryImpl) UpdateStatusTx(ctx context.Context, tx *gorm.DB, id int64, status string) error {
+	result := tx.WithContext(ctx).Model(&model.Review{}).Where("id = ?", id).Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrReviewNotFound
+	}
+
+	return nil
+}
+
+func (r *reviewRepositoryImpl) DeleteTx(ctx context.Context, tx *gorm.DB, id int64) error {
+	result := tx.WithContext(ctx).Where("id = ?", id).Delete(&model.Review{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrReviewNotFound
+	}
+
+	return nil
+}
+
+func (r *reviewRepositoryImpl) UpdateImage(ctx context.Context, id int64, updateData map[string]any) error {
+	result := r.db.WithContext(ctx).Model(&model.ReviewImage{}).Where("id = ?", id).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrImageNotFound
+	}
+
+	return nil
+}
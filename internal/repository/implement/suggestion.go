@@ -0,0 +1,329 @@
+package implement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/operator"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/suggestmode"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	internalType "github.com/tienhai2808/ecom_go/internal/types"
+)
+
+const suggestionIndexVersion = 2
+
+const suggestionIndexMapping = `{
+	"settings": {
+		"analysis": {
+			"filter": {
+				"autocomplete_filter": {
+					"type": "edge_ngram",
+					"min_gram": 1,
+					"max_gram": 20
+				},
+				"shingle_filter": {
+					"type": "shingle",
+					"min_shingle_size": 2,
+					"max_shingle_size": 3
+				}
+			},
+			"analyzer": {
+				"folding": {
+					"type": "custom",
+					"tokenizer": "standard",
+					"filter": ["lowercase", "asciifolding"]
+				},
+				"autocomplete": {
+					"type": "custom",
+					"tokenizer": "standard",
+					"filter": ["lowercase", "asciifolding", "autocomplete_filter"]
+				},
+				"shingle": {
+					"type": "custom",
+					"tokenizer": "standard",
+					"filter": ["lowercase", "asciifolding", "shingle_filter"]
+				}
+			}
+		}
+	},
+	"mappings": {
+		"_meta": {"version": 2},
+		"properties": {
+			"id": {"type": "long"},
+			"type": {"type": "keyword"},
+			"slug": {"type": "keyword"},
+			"is_active": {"type": "boolean"},
+			"name": {
+				"type": "text",
+				"analyzer": "folding",
+				"fields": {
+					"autocomplete": {
+						"type": "text",
+						"analyzer": "autocomplete",
+						"search_analyzer": "folding"
+					},
+					"suggest": {
+						"type": "text",
+						"analyzer": "shingle"
+					},
+					"keyword": {"type": "keyword"}
+				}
+			}
+		}
+	}
+}`
+
+type suggestionRepositoryImpl struct {
+	es *elasticsearch.TypedClient
+}
+
+func NewSuggestionRepository(es *elasticsearch.TypedClient) repository.SuggestionRepository {
+	return &suggestionRepositoryImpl{es}
+}
+
+func (r *suggestionRepositoryImpl) EnsureIndex(ctx context.Context) (bool, error) {
+	if r.es == nil {
+		return false, customErr.ErrSearchUnavailable
+	}
+
+	exists, err := r.es.Indices.Exists(common.IndexSuggestions).Do(ctx)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		upToDate, err := r.mappingUpToDate(ctx)
+		if err != nil {
+			return false, err
+		}
+		if upToDate {
+			return false, nil
+		}
+	}
+
+	if err = r.RecreateIndex(ctx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *suggestionRepositoryImpl) RecreateIndex(ctx context.Context) error {
+	if r.es == nil {
+		return customErr.ErrSearchUnavailable
+	}
+
+	exists, err := r.es.Indices.Exists(common.IndexSuggestions).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if _, err = r.es.Indices.Delete(common.IndexSuggestions).Do(ctx); err != nil {
+			return err
+		}
+	}
+
+	_, err = r.es.Indices.Create(common.IndexSuggestions).Raw(strings.NewReader(suggestionIndexMapping)).Do(ctx)
+	return err
+}
+
+func (r *suggestionRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	if r.es == nil {
+		return 0, customErr.ErrSearchUnavailable
+	}
+
+	res, err := r.es.Count().Index(common.IndexSuggestions).Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Count, nil
+}
+
+func (r *suggestionRepositoryImpl) mappingUpToDate(ctx context.Context) (bool, error) {
+	res, err := r.es.Indices.GetMapping().Index(common.IndexSuggestions).Do(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, record := range res {
+		raw, ok := record.Mappings.Meta_["version"]
+		if !ok {
+			return false, nil
+		}
+
+		var version int
+		if err = json.Unmarshal(raw, &version); err != nil {
+			return false, nil
+		}
+		if version < suggestionIndexVersion {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (r *suggestionRepositoryImpl) Index(ctx context.Context, doc *internalType.SuggestionDocument) error {
+	if r.es == nil {
+		return customErr.ErrSearchUnavailable
+	}
+
+	_, err := r.es.Index(common.IndexSuggestions).
+		Id(suggestionDocumentID(doc.Type, doc.ID)).
+		Document(doc).
+		Do(ctx)
+
+	return err
+}
+
+func (r *suggestionRepositoryImpl) IndexAll(ctx context.Context, docs []*internalType.SuggestionDocument) error {
+	if r.es == nil {
+		return customErr.ErrSearchUnavailable
+	}
+
+	if len(docs) == 0 {
+		return nil
+	}
+
+	bulk := r.es.Bulk().Index(common.IndexSuggestions)
+	for _, doc := range docs {
+		id := suggestionDocumentID(doc.Type, doc.ID)
+		if err := bulk.IndexOp(types.IndexOperation{Id_: &id}, doc); err != nil {
+			return err
+		}
+	}
+
+	res, err := bulk.Do(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Errors {
+		return fmt.Errorf("một số document không được đánh chỉ mục")
+	}
+
+	return nil
+}
+
+func (r *suggestionRepositoryImpl) DeleteAll(ctx context.Context, docType string, ids []int64) error {
+	if r.es == nil {
+		return customErr.ErrSearchUnavailable
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	bulk := r.es.Bulk().Index(common.IndexSuggestions)
+	for _, id := range ids {
+		docID := suggestionDocumentID(docType, id)
+		if err := bulk.DeleteOp(types.DeleteOperation{Id_: &docID}); err != nil {
+			return err
+		}
+	}
+
+	_, err := bulk.Do(ctx)
+	return err
+}
+
+func (r *suggestionRepositoryImpl) Suggest(ctx context.Context, text string, limit int) ([]*internalType.SuggestionDocument, error) {
+	if r.es == nil {
+		return nil, customErr.ErrSearchUnavailable
+	}
+
+	size := limit * 2
+	autocompleteBoost := float32(2)
+	and := operator.And
+
+	req := &search.Request{
+		Query: &types.Query{
+			Bool: &types.BoolQuery{
+				Filter: []types.Query{
+					{Term: map[string]types.TermQuery{"is_active": {Value: true}}},
+				},
+				Should: []types.Query{
+					{Match: map[string]types.MatchQuery{
+						"name.autocomplete": {Query: text, Operator: &and, Boost: &autocompleteBoost},
+					}},
+					{Match: map[string]types.MatchQuery{
+						"name": {Query: text, Operator: &and, Fuzziness: "AUTO"},
+					}},
+				},
+				MinimumShouldMatch: 1,
+			},
+		},
+		Size: &size,
+	}
+
+	res, err := r.es.Search().Index(common.IndexSuggestions).Request(req).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tìm kiếm gợi ý thất bại: %w", err)
+	}
+
+	docs := make([]*internalType.SuggestionDocument, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		var doc internalType.SuggestionDocument
+		if err := json.Unmarshal(hit.Source_, &doc); err != nil {
+			return nil, fmt.Errorf("lỗi giải mã document: %w", err)
+		}
+		docs = append(docs, &doc)
+	}
+
+	return docs, nil
+}
+
+func (r *suggestionRepositoryImpl) DidYouMean(ctx context.Context, text string) (string, error) {
+	if r.es == nil {
+		return "", customErr.ErrSearchUnavailable
+	}
+
+	size := 0
+	suggestSize := 1
+	maxErrors := types.Float64(2)
+	maxEdits := 2
+	mode := suggestmode.Always
+
+	req := &search.Request{
+		Size: &size,
+		Suggest: &types.Suggester{
+			Text: &text,
+			Suggesters: map[string]types.FieldSuggester{
+				"did_you_mean": {
+					Phrase: &types.PhraseSuggester{
+						Field:     "name.suggest",
+						Size:      &suggestSize,
+						MaxErrors: &maxErrors,
+						DirectGenerator: []types.DirectGenerator{
+							{Field: "name.suggest", MaxEdits: &maxEdits, SuggestMode: &mode},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	res, err := r.es.Search().Index(common.IndexSuggestions).Request(req).TypedKeys(true).Do(ctx)
+	if err != nil {
+		return "", fmt.Errorf("tìm kiếm từ khóa gợi ý thất bại: %w", err)
+	}
+
+	for _, suggest := range res.Suggest["did_you_mean"] {
+		phrase, ok := suggest.(*types.PhraseSuggest)
+		if !ok || len(phrase.Options) == 0 {
+			continue
+		}
+		return phrase.Options[0].Text, nil
+	}
+
+	return "", nil
+}
+
+func suggestionDocumentID(docType string, id int64) string {
+	return fmt.Sprintf("%s_%d", docType, id)
+}
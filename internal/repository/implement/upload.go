@@ -0,0 +1,91 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type uploadRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUploadRepository(db *gorm.DB) repository.UploadRepository {
+	return &uploadRepositoryImpl{db}
+}
+
+func (r *uploadRepositoryImpl) CreateAll(ctx context.Context, slots []*model.UploadSlot) error {
+	return r.db.WithContext(ctx).Create(slots).Error
+}
+
+func (r *uploadRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.UploadSlot, error) {
+	var slot model.UploadSlot
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&slot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &slot, nil
+}
+
+func (r *uploadRepositoryImpl) FindAllByUserID(ctx context.Context, userID int64) ([]*model.UploadSlot, error) {
+	var slots []*model.UploadSlot
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&slots).Error; err != nil {
+		return nil, err
+	}
+
+	return slots, nil
+}
+
+func (r *uploadRepositoryImpl) UpdateStatus(ctx context.Context, id int64, status string) error {
+	result := r.db.WithContext(ctx).Model(&model.UploadSlot{}).Where("id = ?", id).Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrUploadNotFound
+	}
+
+	return nil
+}
+
+func (r *uploadRepositoryImpl) FindAllByKeysTx(ctx context.Context, tx *gorm.DB, keys []string) ([]*model.UploadSlot, error) {
+	var slots []*model.UploadSlot
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("`key` IN ?", keys).Find(&slots).Error; err != nil {
+		return nil, err
+	}
+
+	return slots, nil
+}
+
+func (r *uploadRepositoryImpl) UpdateStatusByKeysTx(ctx context.Context, tx *gorm.DB, keys []string, status string) error {
+	return tx.WithContext(ctx).Model(&model.UploadSlot{}).Where("`key` IN ?", keys).Update("status", status).Error
+}
+
+func (r *uploadRepositoryImpl) FindAllExpired(ctx context.Context, before time.Time, limit int) ([]*model.UploadSlot, error) {
+	var slots []*model.UploadSlot
+	if err := r.db.WithContext(ctx).Where("status <> ? AND expires_at <= ?", common.UploadStatusAttached, before).Order("expires_at ASC").Limit(limit).Find(&slots).Error; err != nil {
+		return nil, err
+	}
+
+	return slots, nil
+}
+
+func (r *uploadRepositoryImpl) DeleteAllByID(ctx context.Context, ids []int64) (int64, error) {
+	result := r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&model.UploadSlot{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -3,11 +3,14 @@ package implement
 import (
 	"context"
 	"errors"
+	"time"
+
 	customErr "github.com/tienhai2808/ecom_go/internal/errors"
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"github.com/tienhai2808/ecom_go/internal/repository"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userRepositoryImpl struct {
@@ -36,6 +39,36 @@ func (r *userRepositoryImpl) ExistsByID(ctx context.Context, id int64) (bool, er
 	return count > 0, nil
 }
 
+func (r *userRepositoryImpl) CountByRole(ctx context.Context, role string) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.User{}).Where("role = ? AND deactivated_at IS NULL", role).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *userRepositoryImpl) CountByRoleForUpdateTx(ctx context.Context, tx *gorm.DB, role string) (int64, error) {
+	var ids []int64
+	if err := tx.WithContext(ctx).Model(&model.User{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("role = ? AND deactivated_at IS NULL", role).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+
+	return int64(len(ids)), nil
+}
+
+func (r *userRepositoryImpl) FindAllByID(ctx context.Context, ids []int64) ([]*model.User, error) {
+	var users []*model.User
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (r *userRepositoryImpl) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
 	if err := r.db.WithContext(ctx).Model(&model.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
@@ -58,6 +91,10 @@ func (r *userRepositoryImpl) Create(ctx context.Context, user *model.User) error
 	return r.db.WithContext(ctx).Create(user).Error
 }
 
+func (r *userRepositoryImpl) CreateTx(ctx context.Context, tx *gorm.DB, user *model.User) error {
+	return tx.WithContext(ctx).Create(user).Error
+}
+
 func (r *userRepositoryImpl) FindByUsernameWithProfile(ctx context.Context, username string) (*model.User, error) {
 	var user model.User
 	if err := r.db.Preload("Profile").WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
@@ -95,6 +132,28 @@ func (r *userRepositoryImpl) FindByEmailWithProfile(ctx context.Context, email s
 	return &user, nil
 }
 
+func (r *userRepositoryImpl) FindAllDeletionDue(ctx context.Context, before time.Time) ([]*model.User, error) {
+	var users []*model.User
+	if err := r.db.WithContext(ctx).Where("deletion_scheduled_at <= ? AND anonymized_at IS NULL", before).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *userRepositoryImpl) UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error {
+	result := tx.WithContext(ctx).Model(&model.User{}).Where("id = ?", id).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrUserNotFound
+	}
+
+	return nil
+}
+
 func (r *userRepositoryImpl) Update(ctx context.Context, id int64, updateData map[string]any) error {
 	result := r.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", id).Updates(updateData)
 	if result.Error != nil {
@@ -121,6 +180,19 @@ func (r *userRepositoryImpl) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (r *userRepositoryImpl) DeleteTx(ctx context.Context, tx *gorm.DB, id int64) error {
+	result := tx.WithContext(ctx).Where("id = ?", id).Delete(&model.User{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrUserNotFound
+	}
+
+	return nil
+}
+
 func (r *userRepositoryImpl) DeleteAllByID(ctx context.Context, ids []int64) (int64, error) {
 	result := r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&model.User{})
 	if result.Error != nil {
@@ -129,3 +201,12 @@ func (r *userRepositoryImpl) DeleteAllByID(ctx context.Context, ids []int64) (in
 
 	return result.RowsAffected, nil
 }
+
+func (r *userRepositoryImpl) DeleteAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) (int64, error) {
+	result := tx.WithContext(ctx).Where("id IN ?", ids).Delete(&model.User{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,91 @@
+package implement
+
+import (
+	"context"
+	"errors"
+
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"gorm.io/gorm"
+)
+
+type variantRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewVariantRepository(db *gorm.DB) repository.VariantRepository {
+	return &variantRepositoryImpl{db}
+}
+
+func (r *variantRepositoryImpl) FindByID(ctx context.Context, id int64) (*model.ProductVariant, error) {
+	var variant model.ProductVariant
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&variant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &variant, nil
+}
+
+func (r *variantRepositoryImpl) FindAllByIDWithOptionValues(ctx context.Context, ids []int64) ([]*model.ProductVariant, error) {
+	var variants []*model.ProductVariant
+	if err := r.db.WithContext(ctx).Preload("OptionValues.Option").Where("id IN ?", ids).Find(&variants).Error; err != nil {
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+func (r *variantRepositoryImpl) FindAllBySKUTx(ctx context.Context, tx *gorm.DB, skus []string) ([]*model.ProductVariant, error) {
+	var variants []*model.ProductVariant
+	if err := tx.WithContext(ctx).Where("sku IN ?", skus).Find(&variants).Error; err != nil {
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+func (r *variantRepositoryImpl) CountByProductID(ctx context.Context, productID int64) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.ProductVariant{}).Where("product_id = ?", productID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *variantRepositoryImpl) CreateAllTx(ctx context.Context, tx *gorm.DB, variants []*model.ProductVariant) error {
+	return tx.WithContext(ctx).Create(&variants).Error
+}
+
+func (r *variantRepositoryImpl) UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error {
+	result := tx.WithContext(ctx).Model(&model.ProductVariant{}).Where("id = ?", id).Updates(updateData)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return customErr.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func (r *variantRepositoryImpl) DeleteAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) error {
+	return tx.WithContext(ctx).Where("id IN ?", ids).Delete(&model.ProductVariant{}).Error
+}
+
+func (r *variantRepositoryImpl) CreateOptionsTx(ctx context.Context, tx *gorm.DB, options []*model.ProductOption) error {
+	return tx.WithContext(ctx).Create(&options).Error
+}
+
+func (r *variantRepositoryImpl) CreateOptionValuesTx(ctx context.Context, tx *gorm.DB, values []*model.ProductOptionValue) error {
+	return tx.WithContext(ctx).Create(&values).Error
+}
+
+func (r *variantRepositoryImpl) DeleteOptionsByProductIDTx(ctx context.Context, tx *gorm.DB, productID int64) error {
+	return tx.WithContext(ctx).Where("product_id = ?", productID).Delete(&model.ProductOption{}).Error
+}
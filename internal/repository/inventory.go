@@ -8,4 +8,8 @@ import (
 
 type InventoryRepository interface {
 	UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error
+
+	UpdateAllByProductIDTx(ctx context.Context, tx *gorm.DB, productIDs []int64, updateData map[string]any) error
+
+	SumVariantQuantityByProductIDTx(ctx context.Context, tx *gorm.DB, productID int64) (uint, error)
 }
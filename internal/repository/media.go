@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"gorm.io/gorm"
+)
+
+type MediaRepository interface {
+	FindAll(ctx context.Context, query request.MediaPaginationQuery) ([]*model.MediaAsset, int64, error)
+
+	FindByID(ctx context.Context, id int64) (*model.MediaAsset, error)
+
+	FindAllByUserID(ctx context.Context, userID int64) ([]*model.MediaAsset, error)
+
+	FindAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) ([]*model.MediaAsset, error)
+
+	CreateTx(ctx context.Context, tx *gorm.DB, asset *model.MediaAsset) error
+
+	Update(ctx context.Context, id int64, updateData map[string]any) error
+
+	UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error
+
+	ReplaceTagsTx(ctx context.Context, tx *gorm.DB, id int64, tags []string) error
+
+	CountUsagesTx(ctx context.Context, tx *gorm.DB, id int64) (int64, error)
+
+	DeleteTx(ctx context.Context, tx *gorm.DB, id int64) error
+
+	IsKeyReferenced(ctx context.Context, key string) (bool, error)
+}
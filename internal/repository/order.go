@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+)
+
+type OrderRepository interface {
+	ExistsDeliveredItem(ctx context.Context, userID, productID int64) (bool, error)
+
+	FindAllByUserIDWithItems(ctx context.Context, userID int64) ([]*model.Order, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+)
+
+type PermissionRepository interface {
+	FindAll(ctx context.Context) ([]*model.RolePermission, error)
+
+	FindAllByRole(ctx context.Context, role string) ([]*model.RolePermission, error)
+
+	CreateAll(ctx context.Context, rolePermissions []*model.RolePermission) error
+
+	DeleteAllByRoleAndPermissions(ctx context.Context, role string, permissions []string) (int64, error)
+}
@@ -2,37 +2,69 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"github.com/tienhai2808/ecom_go/internal/request"
-	"github.com/tienhai2808/ecom_go/internal/types"
 	"gorm.io/gorm"
 )
 
 type ProductRepository interface {
 	FindAll(ctx context.Context) ([]*model.Product, error)
 
-	Search(ctx context.Context, query request.ProductPaginationQuery) (*types.ProductSearchResult, error)
+	FindAllForIndex(ctx context.Context, ids []int64) ([]*model.Product, error)
+
+	FindAllIDsAfter(ctx context.Context, afterID int64, limit int) ([]int64, error)
+
+	FindAllIDsByCategoryIDs(ctx context.Context, categoryIDs []int64) ([]int64, error)
+
+	FindAllSimilarIDs(ctx context.Context, product *model.Product, excludeIDs []int64, limit int) ([]int64, error)
 
 	FindByIDWithDetails(ctx context.Context, id int64) (*model.Product, error)
 
+	FindActiveBySlugWithDetails(ctx context.Context, slug string) (*model.Product, error)
+
 	FindByID(ctx context.Context, id int64) (*model.Product, error)
 
 	FindByIDWithImages(ctx context.Context, id int64) (*model.Product, error)
 
 	FindByIDWithDetailsTx(ctx context.Context, tx *gorm.DB, id int64) (*model.Product, error)
 
+	FindBySlugForImportTx(ctx context.Context, tx *gorm.DB, slug string) (*model.Product, error)
+
 	FindAllByIDWithImages(ctx context.Context, ids []int64) ([]*model.Product, error)
 
 	FindAllByIDWithThumbnail(ctx context.Context, ids []int64) ([]*model.Product, error)
 
 	FindAllByIDWithCategoryAndThumbnail(ctx context.Context, ids []int64) ([]*model.Product, error)
 
+	FindAllForExport(ctx context.Context, afterID int64, limit int, activeOnly bool) ([]*model.Product, error)
+
 	Create(ctx context.Context, product *model.Product) error
 
+	CreateTx(ctx context.Context, tx *gorm.DB, product *model.Product) error
+
 	UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error
 
-	Delete(ctx context.Context, id int64) error
+	FindAllForBulkUpdateTx(ctx context.Context, tx *gorm.DB, ids []int64, filter *request.BulkProductFilter, limit int) ([]*model.Product, error)
+
+	FindAllDueForPublishTx(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]*model.Product, error)
+
+	UpdateAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64, updateData map[string]any) (int64, error)
+
+	UpdateRatingTx(ctx context.Context, tx *gorm.DB, id int64) error
+
+	FindAllByIDForHistoryTx(ctx context.Context, tx *gorm.DB, ids []int64) ([]*model.Product, error)
+
+	DeleteTx(ctx context.Context, tx *gorm.DB, id int64) error
+
+	DeleteAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) (int64, error)
+
+	FindAllDeleted(ctx context.Context) ([]*model.Product, error)
+
+	FindAllDeletedBeforeWithImages(ctx context.Context, before time.Time) ([]*model.Product, error)
+
+	RestoreTx(ctx context.Context, tx *gorm.DB, id int64) error
 
-	DeleteAllByID(ctx context.Context, ids []int64) (int64, error)
+	PurgeAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) (int64, error)
 }
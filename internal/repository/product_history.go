@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"gorm.io/gorm"
+)
+
+type ProductHistoryRepository interface {
+	CreateTx(ctx context.Context, tx *gorm.DB, history *model.ProductHistory) error
+
+	FindAllByProductID(ctx context.Context, productID int64, query request.ProductHistoryPaginationQuery) ([]*model.ProductHistory, int64, error)
+
+	FindByProductIDAndVersionTx(ctx context.Context, tx *gorm.DB, productID int64, version int) (*model.ProductHistory, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+)
+
+type ProductImportRepository interface {
+	Create(ctx context.Context, job *model.ProductImportJob) error
+
+	FindAll(ctx context.Context, query request.ImportJobPaginationQuery) ([]*model.ProductImportJob, int64, error)
+
+	FindByID(ctx context.Context, id int64) (*model.ProductImportJob, error)
+
+	FindByIDWithPayload(ctx context.Context, id int64) (*model.ProductImportJob, error)
+
+	Update(ctx context.Context, id int64, updateData map[string]any) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type ProductIndexRepository interface {
+	AliasExists(ctx context.Context) (bool, error)
+
+	MappingUpToDate(ctx context.Context) (bool, error)
+
+	CreateIndex(ctx context.Context, index string) error
+
+	IndexAll(ctx context.Context, index string, docs []*types.ProductDocument) error
+
+	DeleteAll(ctx context.Context, index string, ids []int64) error
+
+	MoreLikeThis(ctx context.Context, productID, categoryID int64, price float64, limit int) ([]int64, error)
+
+	SwapAlias(ctx context.Context, index string) ([]string, error)
+
+	DeleteIndices(ctx context.Context, indices []string) error
+}
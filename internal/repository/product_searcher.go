@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type ProductSearcher interface {
+	Search(ctx context.Context, query request.ProductPaginationQuery) (*types.ProductSearchResult, error)
+}
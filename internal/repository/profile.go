@@ -4,10 +4,15 @@ import (
 	"context"
 
 	"github.com/tienhai2808/ecom_go/internal/model"
+	"gorm.io/gorm"
 )
 
 type ProfileRepository interface {
 	FindByID(ctx context.Context, id int64) (*model.Profile, error)
 
-	Update(ctx context.Context, id int64, updateData map[string]any) error 
+	Update(ctx context.Context, id int64, updateData map[string]any) error
+
+	UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error
+
+	UpdateByUserIDTx(ctx context.Context, tx *gorm.DB, userID int64, updateData map[string]any) error
 }
\ No newline at end of file
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"gorm.io/gorm"
+)
+
+type ReviewRepository interface {
+	FindAll(ctx context.Context, query request.ReviewPaginationQuery) ([]*model.Review, int64, error)
+
+	FindByID(ctx context.Context, id int64) (*model.Review, error)
+
+	FindByIDWithDetails(ctx context.Context, id int64) (*model.Review, error)
+
+	FindAllByUserIDWithImages(ctx context.Context, userID int64) ([]*model.Review, error)
+
+	ExistsByProductIDAndUserID(ctx context.Context, productID, userID int64) (bool, error)
+
+	CreateTx(ctx context.Context, tx *gorm.DB, review *model.Review) error
+
+	UpdateStatusTx(ctx context.Context, tx *gorm.DB, id int64, status string) error
+
+	DeleteTx(ctx context.Context, tx *gorm.DB, id int64) error
+
+	UpdateImage(ctx context.Context, id int64, updateData map[string]any) error
+}
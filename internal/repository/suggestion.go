@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type SuggestionRepository interface {
+	EnsureIndex(ctx context.Context) (bool, error)
+
+	RecreateIndex(ctx context.Context) error
+
+	Count(ctx context.Context) (int64, error)
+
+	Index(ctx context.Context, doc *types.SuggestionDocument) error
+
+	IndexAll(ctx context.Context, docs []*types.SuggestionDocument) error
+
+	DeleteAll(ctx context.Context, docType string, ids []int64) error
+
+	Suggest(ctx context.Context, text string, limit int) ([]*types.SuggestionDocument, error)
+
+	DidYouMean(ctx context.Context, text string) (string, error)
+}
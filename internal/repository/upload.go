@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"gorm.io/gorm"
+)
+
+type UploadRepository interface {
+	CreateAll(ctx context.Context, slots []*model.UploadSlot) error
+
+	FindByID(ctx context.Context, id int64) (*model.UploadSlot, error)
+
+	FindAllByUserID(ctx context.Context, userID int64) ([]*model.UploadSlot, error)
+
+	UpdateStatus(ctx context.Context, id int64, status string) error
+
+	FindAllByKeysTx(ctx context.Context, tx *gorm.DB, keys []string) ([]*model.UploadSlot, error)
+
+	UpdateStatusByKeysTx(ctx context.Context, tx *gorm.DB, keys []string, status string) error
+
+	FindAllExpired(ctx context.Context, before time.Time, limit int) ([]*model.UploadSlot, error)
+
+	DeleteAllByID(ctx context.Context, ids []int64) (int64, error)
+}
@@ -1,8 +1,11 @@
 package repository
 
 import (
-	"github.com/tienhai2808/ecom_go/internal/model"
 	"context"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"gorm.io/gorm"
 )
 
 type UserRepository interface {
@@ -14,17 +17,33 @@ type UserRepository interface {
 
 	ExistsByID(ctx context.Context, id int64) (bool, error)
 
+	CountByRole(ctx context.Context, role string) (int64, error)
+
+	CountByRoleForUpdateTx(ctx context.Context, tx *gorm.DB, role string) (int64, error)
+
+	FindAllByID(ctx context.Context, ids []int64) ([]*model.User, error)
+
 	Create(ctx context.Context, user *model.User) error
 
+	CreateTx(ctx context.Context, tx *gorm.DB, user *model.User) error
+
 	FindByUsernameWithProfile(ctx context.Context, username string) (*model.User, error)
 
 	FindByIDWithProfile(ctx context.Context, id int64) (*model.User, error)
 
 	FindByEmailWithProfile(ctx context.Context, email string) (*model.User, error)
 
+	FindAllDeletionDue(ctx context.Context, before time.Time) ([]*model.User, error)
+
 	Update(ctx context.Context, id int64, updateData map[string]any) error
 
+	UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error
+
 	Delete(ctx context.Context, id int64) error
 
+	DeleteTx(ctx context.Context, tx *gorm.DB, id int64) error
+
 	DeleteAllByID(ctx context.Context, ids []int64) (int64, error)
+
+	DeleteAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) (int64, error)
 }
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"gorm.io/gorm"
+)
+
+type VariantRepository interface {
+	FindByID(ctx context.Context, id int64) (*model.ProductVariant, error)
+
+	FindAllByIDWithOptionValues(ctx context.Context, ids []int64) ([]*model.ProductVariant, error)
+
+	FindAllBySKUTx(ctx context.Context, tx *gorm.DB, skus []string) ([]*model.ProductVariant, error)
+
+	CountByProductID(ctx context.Context, productID int64) (int64, error)
+
+	CreateAllTx(ctx context.Context, tx *gorm.DB, variants []*model.ProductVariant) error
+
+	UpdateTx(ctx context.Context, tx *gorm.DB, id int64, updateData map[string]any) error
+
+	DeleteAllByIDTx(ctx context.Context, tx *gorm.DB, ids []int64) error
+
+	CreateOptionsTx(ctx context.Context, tx *gorm.DB, options []*model.ProductOption) error
+
+	CreateOptionValuesTx(ctx context.Context, tx *gorm.DB, values []*model.ProductOptionValue) error
+
+	DeleteOptionsByProductIDTx(ctx context.Context, tx *gorm.DB, productID int64) error
+}
@@ -0,0 +1,5 @@
+package request
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required,min=6"`
+}
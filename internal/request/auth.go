@@ -33,4 +33,14 @@ type ResetPasswordRequest struct {
 type ChangePasswordRequest struct {
 	OldPassword string `json:"old_password" binding:"required,min=6"`
 	NewPassword string `json:"new_password" binding:"required,min=6"`
-}
\ No newline at end of file
+}
+
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type VerifyChangeEmailRequest struct {
+	ChangeEmailToken string `json:"change_email_token" binding:"required,uuid4"`
+	Otp              string `json:"otp" binding:"required,len=6,numeric"`
+}
@@ -1,8 +1,9 @@
 package request
 
 type AddCartItemRequest struct {
-	ProductID int64 `json:"product_id" binding:"required,gt=0"`
-	Quantity  uint  `json:"quantity" binding:"required,min=1"`
+	ProductID int64  `json:"product_id" binding:"required,gt=0"`
+	VariantID *int64 `json:"variant_id" binding:"omitempty,gt=0"`
+	Quantity  uint   `json:"quantity" binding:"required,min=1"`
 }
 
 type UpdateCartItemRequest struct {
@@ -1,9 +1,32 @@
 package request
 
 type CreateCategoryRequest struct {
-	Name string `json:"name" binding:"required,min=1"`
+	Name     string `json:"name" binding:"required,min=1"`
+	ParentID *int64 `json:"parent_id" binding:"omitempty,gt=0"`
+}
+
+type MoveCategoryRequest struct {
+	ParentID *int64 `json:"parent_id" binding:"omitempty,gt=0"`
 }
 
 type UpdateCategoryRequest struct {
-	Name string `json:"name" binding:"required,min=1"`
+	Name         string `json:"name" binding:"required,min=1"`
+	ImageAssetID *int64 `json:"image_asset_id" binding:"omitempty,min=0"`
+}
+
+type CreateCategoryAttributeRequest struct {
+	Name       string   `json:"name" binding:"required,min=1,max=100"`
+	Type       string   `json:"type" binding:"required,oneof=text number enum boolean"`
+	Unit       *string  `json:"unit" binding:"omitempty,max=20"`
+	Options    []string `json:"options" binding:"required_if=Type enum,omitempty,dive,required,max=100"`
+	IsRequired *bool    `json:"is_required" binding:"omitempty"`
+	Position   int      `json:"position" binding:"omitempty,min=0"`
+}
+
+type UpdateCategoryAttributeRequest struct {
+	Name       *string  `json:"name" binding:"omitempty,min=1,max=100"`
+	Unit       *string  `json:"unit" binding:"omitempty,max=20"`
+	Options    []string `json:"options" binding:"omitempty,dive,required,max=100"`
+	IsRequired *bool    `json:"is_required" binding:"omitempty"`
+	Position   *int     `json:"position" binding:"omitempty,min=0"`
 }
@@ -0,0 +1,19 @@
+package request
+
+type CreateMediaAssetRequest struct {
+	Key  string   `json:"key" binding:"required,max=255"`
+	Tags []string `json:"tags" binding:"omitempty,max=20,dive,required,max=50"`
+}
+
+type UpdateMediaAssetRequest struct {
+	FileName *string  `json:"file_name" binding:"omitempty,min=1,max=255"`
+	Tags     []string `json:"tags" binding:"omitempty,max=20,dive,required,max=50"`
+}
+
+type MediaPaginationQuery struct {
+	Page   uint32   `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit  uint32   `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	Search string   `form:"search" json:"search"`
+	Tags   []string `form:"tags" binding:"omitempty,dive,max=50" json:"tags"`
+	Status string   `form:"status" binding:"omitempty,oneof=pending ready failed" json:"status"`
+}
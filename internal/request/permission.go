@@ -0,0 +1,5 @@
+package request
+
+type RolePermissionsRequest struct {
+	Permissions []string `json:"permissions" binding:"required,min=1,dive,required"`
+}
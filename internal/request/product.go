@@ -1,15 +1,25 @@
 package request
 
+import "time"
+
 type UpdateProductForm struct {
-	Name           *string                  `json:"name" validate:"omitempty,min=2"`
-	CategoryID     *int64                   `json:"category_id" validate:"omitempty,gt=0"`
-	Price          *float64                 `json:"price" validate:"omitempty,gt=0"`
-	Quantity       *uint                    `json:"quantity" validate:"omitempty,min=0"`
-	Description    *string                  `json:"description" validate:"omitempty,min=2"`
-	IsActive       *bool                    `json:"is_active" validate:"omitempty"`
-	NewImages      []CreateProductImageForm `json:"new_images" validate:"omitempty,dive"`
-	UpdateImages   []UpdateProductImageForm `json:"update_images" validate:"omitempty,dive"`
-	DeleteImageIDs []int64                  `json:"delete_image_ids" validate:"omitempty,dive"`
+	Name             *string                    `json:"name" validate:"omitempty,min=2"`
+	CategoryID       *int64                     `json:"category_id" validate:"omitempty,gt=0"`
+	Price            *float64                   `json:"price" validate:"omitempty,gt=0"`
+	Quantity         *uint                      `json:"quantity" validate:"omitempty,min=0"`
+	Description      *string                    `json:"description" validate:"omitempty,min=2"`
+	IsActive         *bool                      `json:"is_active" validate:"omitempty"`
+	Status           string                     `json:"status" validate:"omitempty,oneof=draft scheduled published archived"`
+	PublishAt        *time.Time                 `json:"publish_at" validate:"omitempty"`
+	UnpublishAt      *time.Time                 `json:"unpublish_at" validate:"omitempty"`
+	NewImages        []CreateProductImageForm   `json:"new_images" validate:"omitempty,dive"`
+	UpdateImages     []UpdateProductImageForm   `json:"update_images" validate:"omitempty,dive"`
+	DeleteImageIDs   []int64                    `json:"delete_image_ids" validate:"omitempty,dive"`
+	Options          []CreateProductOptionForm  `json:"options" validate:"omitempty,dive"`
+	NewVariants      []CreateProductVariantForm `json:"new_variants" validate:"omitempty,dive"`
+	UpdateVariants   []UpdateProductVariantForm `json:"update_variants" validate:"omitempty,dive"`
+	DeleteVariantIDs []int64                    `json:"delete_variant_ids" validate:"omitempty,dive"`
+	Attributes       []ProductAttributeForm     `json:"attributes" validate:"omitempty,dive"`
 }
 
 type UpdateProductImageForm struct {
@@ -19,27 +29,123 @@ type UpdateProductImageForm struct {
 }
 
 type CreateProductForm struct {
-	Name        string                   `json:"name" validate:"required,min=2"`
-	CategoryID  int64                    `json:"category_id" validate:"required,gt=0"`
-	Price       float64                  `json:"price" validate:"required,gt=0"`
-	Quantity    uint                     `json:"quantity" validate:"required,min=1"`
-	Description string                   `json:"description" validate:"required,min=2"`
-	IsActive    *bool                    `json:"is_active" validate:"required"`
-	Images      []CreateProductImageForm `json:"images" validate:"required,dive"`
+	Name        string                     `json:"name" validate:"required,min=2"`
+	CategoryID  int64                      `json:"category_id" validate:"required,gt=0"`
+	Price       float64                    `json:"price" validate:"required,gt=0"`
+	Quantity    uint                       `json:"quantity" validate:"required_without=Variants"`
+	Description string                     `json:"description" validate:"required,min=2"`
+	IsActive    *bool                      `json:"is_active" validate:"required_without=Status"`
+	Status      string                     `json:"status" validate:"omitempty,oneof=draft scheduled published archived"`
+	PublishAt   *time.Time                 `json:"publish_at" validate:"omitempty"`
+	UnpublishAt *time.Time                 `json:"unpublish_at" validate:"omitempty"`
+	Images      []CreateProductImageForm   `json:"images" validate:"required,dive"`
+	Options     []CreateProductOptionForm  `json:"options" validate:"omitempty,dive"`
+	Variants    []CreateProductVariantForm `json:"variants" validate:"omitempty,dive"`
+	Attributes  []ProductAttributeForm     `json:"attributes" validate:"omitempty,dive"`
+}
+
+type ProductAttributeForm struct {
+	AttributeID int64  `json:"attribute_id" validate:"required,gt=0"`
+	Value       string `json:"value" validate:"omitempty,max=255"`
+}
+
+type CreateProductOptionForm struct {
+	Name   string   `json:"name" validate:"required,max=50"`
+	Values []string `json:"values" validate:"required,min=1,dive,required,max=100"`
+}
+
+type CreateProductVariantForm struct {
+	SKU          string                   `json:"sku" validate:"required,max=100"`
+	Price        *float64                 `json:"price" validate:"omitempty,gt=0"`
+	Quantity     uint                     `json:"quantity" validate:"min=0"`
+	IsActive     *bool                    `json:"is_active" validate:"required"`
+	OptionValues []string                 `json:"option_values" validate:"required,min=1,dive,required"`
+	Images       []CreateProductImageForm `json:"images" validate:"omitempty,dive"`
+}
+
+type UpdateProductVariantForm struct {
+	ID       int64    `json:"id" validate:"required"`
+	SKU      *string  `json:"sku" validate:"omitempty,max=100"`
+	Price    *float64 `json:"price" validate:"omitempty,gt=0"`
+	Quantity *uint    `json:"quantity" validate:"omitempty,min=0"`
+	IsActive *bool    `json:"is_active" validate:"omitempty"`
 }
 
 type CreateProductImageForm struct {
 	IsThumbnail *bool  `form:"is_thumbnail" validate:"required"`
 	SortOrder   int    `form:"sort_order" validate:"required,gt=0"`
-	FileData    []byte `form:"file_data" validate:"required"`
+	Key         string `form:"key" validate:"required_without=AssetID,max=255"`
+	AssetID     int64  `form:"asset_id" validate:"omitempty,gt=0"`
+}
+
+type ProductSuggestQuery struct {
+	Query string `form:"q" binding:"required,max=100" json:"q"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=20" json:"limit"`
 }
 
 type ProductPaginationQuery struct {
-	Page       uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
-	Limit      uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
-	Sort       string `form:"sort" json:"sort"`
-	Order      string `form:"order" binding:"omitempty,oneof=asc desc" json:"order"`
-	IsActive   *bool  `form:"is_active" json:"is_active"`
-	Search     string `form:"search" json:"search"`
-	CategoryID int64 `form:"category_id" json:"category_id" binding:"omitempty,gt=0"`
+	Page        uint32  `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit       uint32  `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	Sort        string  `form:"sort" json:"sort"`
+	Order       string  `form:"order" binding:"omitempty,oneof=asc desc" json:"order"`
+	IsActive    *bool   `form:"is_active" json:"is_active"`
+	Status      string  `form:"status" binding:"omitempty,oneof=draft scheduled published archived" json:"status"`
+	Search      string  `form:"search" json:"search"`
+	CategoryID    int64    `form:"category_id" json:"category_id" binding:"omitempty,gt=0"`
+	CategoryIDs   []int64  `form:"category_ids" json:"category_ids" binding:"omitempty,dive,gt=0"`
+	MinPrice      *float64 `form:"min_price" json:"min_price" binding:"omitempty,min=0"`
+	MaxPrice      *float64 `form:"max_price" json:"max_price" binding:"omitempty,min=0"`
+	InStock       bool     `form:"in_stock" json:"in_stock"`
+	PriceInterval float64  `form:"price_interval" json:"price_interval" binding:"omitempty,gt=0"`
+
+	Attributes        map[int64][]string `form:"-" json:"-"`
+	FilterCategoryIDs []int64            `form:"-" json:"-"`
+	PublishedOnly     bool               `form:"-" json:"-"`
+}
+
+type RelatedProductQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=20" json:"limit"`
+}
+
+type RetryImagesRequest struct {
+	ImageIDs []int64 `json:"image_ids" binding:"omitempty,dive,gt=0"`
+}
+
+type ExportProductsQuery struct {
+	Format     string `form:"format" binding:"omitempty,oneof=csv json"`
+	ActiveOnly bool   `form:"active_only"`
+}
+
+type BulkUpdateProductsRequest struct {
+	IDs     []int64             `json:"ids" binding:"required_without=Filter,max=1000,dive,gt=0"`
+	Filter  *BulkProductFilter  `json:"filter" binding:"omitempty"`
+	Changes *BulkProductChanges `json:"changes" binding:"required"`
+	DryRun  bool                `json:"dry_run"`
+}
+
+type BulkProductFilter struct {
+	CategoryID *int64   `json:"category_id" binding:"omitempty,gt=0"`
+	IsActive   *bool    `json:"is_active"`
+	Search     string   `json:"search" binding:"omitempty,max=255"`
+	MinPrice   *float64 `json:"min_price" binding:"omitempty,min=0"`
+	MaxPrice   *float64 `json:"max_price" binding:"omitempty,min=0"`
+
+	FilterCategoryIDs []int64 `json:"-"`
+}
+
+type BulkProductChanges struct {
+	IsActive    *bool                `json:"is_active"`
+	CategoryID  *int64               `json:"category_id" binding:"omitempty,gt=0"`
+	PriceAdjust *BulkPriceAdjustment `json:"price_adjust" binding:"omitempty"`
+	Quantity    *uint                `json:"quantity"`
+}
+
+type BulkPriceAdjustment struct {
+	Type  string  `json:"type" binding:"required,oneof=percent amount"`
+	Value float64 `json:"value" binding:"required,ne=0"`
+}
+
+type ProductHistoryPaginationQuery struct {
+	Page  uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
 }
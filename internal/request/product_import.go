@@ -0,0 +1,22 @@
+package request
+
+type ImportProductRow struct {
+	Name         string   `json:"name" validate:"required,min=2"`
+	Slug         string   `json:"slug" validate:"omitempty,max=255"`
+	CategorySlug string   `json:"category_slug" validate:"required,max=150"`
+	Price        float64  `json:"price" validate:"required,gt=0"`
+	Quantity     uint     `json:"quantity" validate:"min=0"`
+	Description  string   `json:"description" validate:"required,min=2"`
+	IsActive     *bool    `json:"is_active" validate:"omitempty"`
+	ImageURLs    []string `json:"image_urls" validate:"omitempty,max=10,dive,required,url"`
+}
+
+type ImportJobPaginationQuery struct {
+	Page   uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit  uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	Status string `form:"status" binding:"omitempty,oneof=pending running completed failed" json:"status"`
+}
+
+type CreateImportJobForm struct {
+	Upsert bool `form:"upsert"`
+}
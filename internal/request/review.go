@@ -0,0 +1,25 @@
+package request
+
+type CreateReviewForm struct {
+	Rating uint8                   `form:"rating" validate:"required,min=1,max=5"`
+	Title  string                  `form:"title" validate:"required,max=150"`
+	Body   string                  `form:"body" validate:"omitempty,max=5000"`
+	Images []CreateReviewImageForm `form:"images" validate:"omitempty,max=5,dive"`
+}
+
+type CreateReviewImageForm struct {
+	SortOrder int    `form:"sort_order" validate:"required,gt=0"`
+	Key       string `form:"key" validate:"required,max=255"`
+}
+
+type ReviewPaginationQuery struct {
+	Page      uint32 `form:"page" binding:"omitempty,min=1" json:"page"`
+	Limit     uint32 `form:"limit" binding:"omitempty,min=1,max=100" json:"limit"`
+	Rating    uint8  `form:"rating" binding:"omitempty,min=1,max=5" json:"rating"`
+	Status    string `form:"status" binding:"omitempty,oneof=pending approved rejected" json:"status"`
+	ProductID int64  `form:"product_id" binding:"omitempty,gt=0" json:"product_id"`
+}
+
+type ModerateReviewRequest struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected"`
+}
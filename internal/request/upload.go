@@ -0,0 +1,11 @@
+package request
+
+type CreateUploadSlotsRequest struct {
+	Files []CreateUploadSlotRequest `json:"files" binding:"required,min=1,max=10,dive"`
+}
+
+type CreateUploadSlotRequest struct {
+	FileName    string `json:"file_name" binding:"required,max=150"`
+	ContentType string `json:"content_type" binding:"required,oneof=image/jpeg image/png image/webp image/gif"`
+	Size        int64  `json:"size" binding:"required,gt=0"`
+}
@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+type AccountDeletionResponse struct {
+	DeactivatedAt       time.Time `json:"deactivated_at"`
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
+
+type AccountExportResponse struct {
+	ExportedAt  time.Time             `json:"exported_at"`
+	User        *UserResponse         `json:"user"`
+	Addresses   []*AddressResponse    `json:"addresses"`
+	Cart        *CartResponse         `json:"cart"`
+	Orders      []*OrderResponse      `json:"orders"`
+	Reviews     []*ReviewResponse     `json:"reviews"`
+	AuditLogs   []*AuditLogResponse   `json:"audit_logs"`
+	Uploads     []*UploadSlotResponse `json:"uploads"`
+	MediaAssets []*MediaAssetResponse `json:"media_assets"`
+}
@@ -13,4 +13,6 @@ type MetaResponse struct {
 	TotalPages int64  `json:"total_pages"`
 	HasPrev    bool   `json:"has_prev"`
 	HasNext    bool   `json:"has_next"`
+
+	DidYouMean *string `json:"did_you_mean,omitempty"`
 }
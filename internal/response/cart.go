@@ -1,10 +1,11 @@
 package response
 
 type CartResponse struct {
-	ID            int64               `json:"id"`
-	TotalQuantity uint                `json:"total_quantity"`
-	TotalPrice    float64             `json:"total_price"`
-	CartItems     []*CartItemResponse `json:"cart_items"`
+	ID            int64                  `json:"id"`
+	TotalQuantity uint                   `json:"total_quantity"`
+	TotalPrice    float64                `json:"total_price"`
+	CartItems     []*CartItemResponse    `json:"cart_items"`
+	Suggestions   []*BaseProductResponse `json:"suggestions,omitempty"`
 }
 
 type CartItemResponse struct {
@@ -13,12 +14,14 @@ type CartItemResponse struct {
 	Quantity   uint                   `json:"quantity"`
 	TotalPrice float64                `json:"total_price"`
 	Product    *SimpleProductResponse `json:"product"`
+	Variant    *BaseVariantResponse   `json:"variant"`
 }
 
 type GuestCartResponse struct {
 	TotalQuantity uint                     `json:"total_quantity"`
 	TotalPrice    float64                  `json:"total_price"`
 	CartItems     []*GuestCartItemResponse `json:"cart_items"`
+	Suggestions   []*BaseProductResponse   `json:"suggestions,omitempty"`
 }
 
 type GuestCartItemResponse struct {
@@ -26,4 +29,5 @@ type GuestCartItemResponse struct {
 	Quantity   uint                   `json:"quantity"`
 	TotalPrice float64                `json:"total_price"`
 	Product    *SimpleProductResponse `json:"product"`
+	Variant    *BaseVariantResponse   `json:"variant"`
 }
@@ -3,11 +3,30 @@ package response
 import "time"
 
 type CategoryResponse struct {
-	ID        int64     `json:"id"`
-	Name      string    `json:"name"`
-	Slug      string    `json:"slug"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        int64               `json:"id"`
+	Name      string              `json:"name"`
+	Slug      string              `json:"slug"`
+	ParentID  *int64              `json:"parent_id"`
+	Depth     int                 `json:"depth"`
+	Image     *MediaImageResponse `json:"image"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+type CategoryTreeResponse struct {
+	ID       int64                   `json:"id"`
+	Name     string                  `json:"name"`
+	Slug     string                  `json:"slug"`
+	Depth    int                     `json:"depth"`
+	Image    *MediaImageResponse     `json:"image"`
+	Children []*CategoryTreeResponse `json:"children"`
+}
+
+type ProductCategoryResponse struct {
+	ID         int64                   `json:"id"`
+	Name       string                  `json:"name"`
+	Slug       string                  `json:"slug"`
+	Breadcrumb []*BaseCategoryResponse `json:"breadcrumb"`
 }
 
 type BaseCategoryResponse struct {
@@ -15,3 +34,20 @@ type BaseCategoryResponse struct {
 	Name string `json:"name"`
 	Slug string `json:"slug"`
 }
+
+type DeletedCategoryResponse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type CategoryAttributeResponse struct {
+	ID         int64    `json:"id"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Unit       *string  `json:"unit"`
+	Options    []string `json:"options"`
+	IsRequired bool     `json:"is_required"`
+	Position   int      `json:"position"`
+}
@@ -0,0 +1,33 @@
+package response
+
+import "time"
+
+type MediaAssetResponse struct {
+	ID               int64             `json:"id"`
+	Key              string            `json:"key"`
+	FileName         string            `json:"file_name"`
+	ContentType      string            `json:"content_type"`
+	Url              string            `json:"url"`
+	Srcset           map[string]string `json:"srcset"`
+	Width            int               `json:"width"`
+	Height           int               `json:"height"`
+	PlaceholderColor string            `json:"placeholder_color"`
+	Status           string            `json:"status"`
+	Tags             []string          `json:"tags"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+}
+
+type MediaImageResponse struct {
+	ID               int64             `json:"id"`
+	Url              string            `json:"url"`
+	Srcset           map[string]string `json:"srcset"`
+	Width            int               `json:"width"`
+	Height           int               `json:"height"`
+	PlaceholderColor string            `json:"placeholder_color"`
+}
+
+type MediaAssetListResponse struct {
+	Assets []*MediaAssetResponse `json:"assets"`
+	Meta   *MetaResponse         `json:"meta"`
+}
@@ -0,0 +1,25 @@
+package response
+
+type OrderItemResponse struct {
+	ID         int64   `json:"id"`
+	ProductID  int64   `json:"product_id"`
+	VariantID  *int64  `json:"variant_id"`
+	SKU        string  `json:"sku"`
+	UnitPrice  float64 `json:"unit_price"`
+	Quantity   uint    `json:"quantity"`
+	TotalPrice float64 `json:"total_price"`
+}
+
+type OrderResponse struct {
+	ID            int64                `json:"id"`
+	FullName      string               `json:"full_name"`
+	PhoneNumber   string               `json:"phone_number"`
+	Address       string               `json:"address"`
+	Commune       string               `json:"commune"`
+	Province      string               `json:"province"`
+	TotalPrice    float64              `json:"total_price"`
+	TotalQuantity uint                 `json:"total_quantity"`
+	PaymentMethod string               `json:"payment_method"`
+	Status        string               `json:"status"`
+	Items         []*OrderItemResponse `json:"items"`
+}
@@ -0,0 +1,6 @@
+package response
+
+type RolePermissionsResponse struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
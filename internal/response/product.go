@@ -3,24 +3,73 @@ package response
 import "time"
 
 type ProductResponse struct {
-	ID          int64                 `json:"id"`
-	Name        string                `json:"name"`
-	Slug        string                `json:"slug"`
-	Price       float64               `json:"price"`
-	Description string                `json:"description"`
-	IsActive    bool                  `json:"is_active"`
-	CreatedAt   time.Time             `json:"created_at"`
-	UpdatedAt   time.Time             `json:"updated_at"`
-	Category    *BaseCategoryResponse `json:"category"`
-	Inventory   *InventoryResponse    `json:"inventory"`
-	Images      []*ImageResponse      `json:"images"`
+	ID             int64                     `json:"id"`
+	Name           string                    `json:"name"`
+	Slug           string                    `json:"slug"`
+	Price          float64                   `json:"price"`
+	Description    string                    `json:"description"`
+	IsActive       bool                      `json:"is_active"`
+	Status         string                    `json:"status"`
+	PublishAt      *time.Time                `json:"publish_at"`
+	UnpublishAt    *time.Time                `json:"unpublish_at"`
+	RatingAverage  float64                   `json:"rating_average"`
+	RatingCount    uint                      `json:"rating_count"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+	Category       *ProductCategoryResponse  `json:"category"`
+	Inventory      *InventoryResponse        `json:"inventory"`
+	Images         []*ImageResponse          `json:"images"`
+	Options        []*ProductOptionResponse  `json:"options"`
+	Variants       []*ProductVariantResponse `json:"variants"`
+	Specifications []*SpecificationResponse  `json:"specifications"`
+}
+
+type SpecificationResponse struct {
+	AttributeID int64   `json:"attribute_id"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	Value       any     `json:"value"`
+	Unit        *string `json:"unit"`
+	Display     string  `json:"display"`
+}
+
+type ProductOptionResponse struct {
+	ID     int64                         `json:"id"`
+	Name   string                        `json:"name"`
+	Values []*ProductOptionValueResponse `json:"values"`
+}
+
+type ProductOptionValueResponse struct {
+	ID    int64  `json:"id"`
+	Value string `json:"value"`
+}
+
+type ProductVariantResponse struct {
+	ID            int64              `json:"id"`
+	SKU           string             `json:"sku"`
+	Price         float64            `json:"price"`
+	PriceOverride *float64           `json:"price_override"`
+	IsActive      bool               `json:"is_active"`
+	Options       map[string]string  `json:"options"`
+	Inventory     *InventoryResponse `json:"inventory"`
+	Images        []*ImageResponse   `json:"images"`
+}
+
+type BaseVariantResponse struct {
+	ID      int64             `json:"id"`
+	SKU     string            `json:"sku"`
+	Options map[string]string `json:"options"`
 }
 
 type ImageResponse struct {
-	ID          int64  `json:"id"`
-	Url         string `json:"url"`
-	IsThumbnail bool   `json:"is_thumbnail"`
-	SortOrder   int    `json:"sort_order"`
+	ID               int64             `json:"id"`
+	Url              string            `json:"url"`
+	Srcset           map[string]string `json:"srcset"`
+	Width            int               `json:"width"`
+	Height           int               `json:"height"`
+	PlaceholderColor string            `json:"placeholder_color"`
+	IsThumbnail      bool              `json:"is_thumbnail"`
+	SortOrder        int               `json:"sort_order"`
 }
 
 type InventoryResponse struct {
@@ -31,13 +80,44 @@ type InventoryResponse struct {
 	IsStock   bool  `json:"is_stock"`
 }
 
+type StorefrontProductResponse struct {
+	ID             int64                        `json:"id"`
+	Name           string                       `json:"name"`
+	Slug           string                       `json:"slug"`
+	Description    string                       `json:"description"`
+	Price          float64                      `json:"price"`
+	EffectivePrice float64                      `json:"effective_price"`
+	InStock        bool                         `json:"in_stock"`
+	StockStatus    string                       `json:"stock_status"`
+	RatingAverage  float64                      `json:"rating_average"`
+	RatingCount    uint                         `json:"rating_count"`
+	Category       *ProductCategoryResponse     `json:"category"`
+	Images         []*ImageResponse             `json:"images"`
+	Options        []*ProductOptionResponse     `json:"options"`
+	Variants       []*StorefrontVariantResponse `json:"variants"`
+	Specifications []*SpecificationResponse     `json:"specifications"`
+}
+
+type StorefrontVariantResponse struct {
+	ID          int64             `json:"id"`
+	SKU         string            `json:"sku"`
+	Price       float64           `json:"price"`
+	InStock     bool              `json:"in_stock"`
+	StockStatus string            `json:"stock_status"`
+	Options     map[string]string `json:"options"`
+	Images      []*ImageResponse  `json:"images"`
+}
+
 type BaseProductResponse struct {
-	ID        int64   `json:"id"`
-	Name      string  `json:"name"`
-	Slug      string  `json:"slug"`
-	Price     float64 `json:"price"`
-	IsActive  bool    `json:"is_active"`
-	Thumbnail string  `json:"thumbnail"`
+	ID            int64   `json:"id"`
+	Name          string  `json:"name"`
+	Slug          string  `json:"slug"`
+	Price         float64 `json:"price"`
+	IsActive      bool    `json:"is_active"`
+	Status        string  `json:"status"`
+	RatingAverage float64 `json:"rating_average"`
+	RatingCount   uint    `json:"rating_count"`
+	Thumbnail     string  `json:"thumbnail"`
 }
 
 type SimpleProductResponse struct {
@@ -48,7 +128,98 @@ type SimpleProductResponse struct {
 	Thumbnail string                `json:"thumbnail"`
 }
 
+type DeletedProductResponse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Price     float64   `json:"price"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type ImageStatusResponse struct {
+	ID        int64     `json:"id"`
+	ProductID int64     `json:"product_id"`
+	VariantID *int64    `json:"variant_id"`
+	Key       string    `json:"key"`
+	Status    string    `json:"status"`
+	SortOrder int       `json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 type ProductListResponse struct {
 	Products []*BaseProductResponse `json:"products"`
 	Meta     *MetaResponse          `json:"meta"`
+	Facets   *ProductFacetsResponse `json:"facets"`
+}
+
+type ProductSuggestionResponse struct {
+	Products   []*SuggestionItemResponse `json:"products"`
+	Categories []*SuggestionItemResponse `json:"categories"`
+	DidYouMean *string                   `json:"did_you_mean"`
+}
+
+type SuggestionItemResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type ProductFacetsResponse struct {
+	Categories     []*CategoryFacetResponse  `json:"categories"`
+	PriceHistogram []*PriceBucketResponse    `json:"price_histogram"`
+	PriceMin       *float64                  `json:"price_min"`
+	PriceMax       *float64                  `json:"price_max"`
+	Attributes     []*AttributeFacetResponse `json:"attributes"`
+}
+
+type CategoryFacetResponse struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Count int64  `json:"count"`
+}
+
+type PriceBucketResponse struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int64   `json:"count"`
+}
+
+type AttributeFacetResponse struct {
+	ID     int64                          `json:"id"`
+	Name   string                         `json:"name"`
+	Type   string                         `json:"type"`
+	Unit   *string                        `json:"unit"`
+	Values []*AttributeFacetValueResponse `json:"values"`
+}
+
+type AttributeFacetValueResponse struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type RelatedProductsResponse struct {
+	Related                  []*BaseProductResponse `json:"related"`
+	FrequentlyBoughtTogether []*BaseProductResponse `json:"frequently_bought_together"`
+}
+
+type BulkFieldChangeResponse struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type BulkProductChangeResponse struct {
+	ProductID int64                      `json:"product_id"`
+	Name      string                     `json:"name"`
+	Changes   []*BulkFieldChangeResponse `json:"changes"`
+	Errors    []string                   `json:"errors,omitempty"`
+}
+
+type BulkUpdateProductsResponse struct {
+	DryRun   bool                         `json:"dry_run"`
+	Matched  int                          `json:"matched"`
+	Changed  int                          `json:"changed"`
+	Products []*BulkProductChangeResponse `json:"products"`
 }
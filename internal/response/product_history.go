@@ -0,0 +1,49 @@
+package response
+
+import "time"
+
+type InventorySnapshotResponse struct {
+	Quantity  uint `json:"quantity"`
+	Purchased uint `json:"purchased"`
+	Stock     uint `json:"stock"`
+}
+
+type ImageSnapshotResponse struct {
+	ID          int64  `json:"id"`
+	Url         string `json:"url"`
+	IsThumbnail bool   `json:"is_thumbnail"`
+	SortOrder   int    `json:"sort_order"`
+	Status      string `json:"status"`
+}
+
+type ProductSnapshotResponse struct {
+	Name        string                     `json:"name"`
+	Slug        string                     `json:"slug"`
+	Price       float64                    `json:"price"`
+	Description string                     `json:"description"`
+	IsActive    bool                       `json:"is_active"`
+	Status      string                     `json:"status"`
+	PublishAt   *time.Time                 `json:"publish_at"`
+	UnpublishAt *time.Time                 `json:"unpublish_at"`
+	CategoryID  int64                      `json:"category_id"`
+	DeletedAt   *time.Time                 `json:"deleted_at"`
+	Inventory   *InventorySnapshotResponse `json:"inventory"`
+	Images      []*ImageSnapshotResponse   `json:"images"`
+}
+
+type ProductHistoryResponse struct {
+	ID            int64                      `json:"id"`
+	Version       int                        `json:"version"`
+	Action        string                     `json:"action"`
+	Before        *ProductSnapshotResponse   `json:"before"`
+	After         *ProductSnapshotResponse   `json:"after"`
+	Changes       []*BulkFieldChangeResponse `json:"changes"`
+	ActorID       *int64                     `json:"actor_id"`
+	ActorUsername string                     `json:"actor_username"`
+	CreatedAt     time.Time                  `json:"created_at"`
+}
+
+type ProductHistoryListResponse struct {
+	Histories []*ProductHistoryResponse `json:"histories"`
+	Meta      *MetaResponse             `json:"meta"`
+}
@@ -0,0 +1,34 @@
+package response
+
+import "time"
+
+type ImportRowErrorResponse struct {
+	Row    int      `json:"row"`
+	Slug   string   `json:"slug"`
+	Errors []string `json:"errors"`
+}
+
+type ProductImportJobResponse struct {
+	ID            int64                     `json:"id"`
+	FileName      string                    `json:"file_name"`
+	Format        string                    `json:"format"`
+	Upsert        bool                      `json:"upsert"`
+	Status        string                    `json:"status"`
+	TotalRows     int                       `json:"total_rows"`
+	ProcessedRows int                       `json:"processed_rows"`
+	CreatedCount  int                       `json:"created_count"`
+	UpdatedCount  int                       `json:"updated_count"`
+	FailedCount   int                       `json:"failed_count"`
+	Progress      float64                   `json:"progress"`
+	Message       string                    `json:"message"`
+	RowErrors     []*ImportRowErrorResponse `json:"row_errors,omitempty"`
+	StartedAt     *time.Time                `json:"started_at"`
+	FinishedAt    *time.Time                `json:"finished_at"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	UserID        int64                     `json:"user_id"`
+}
+
+type ProductImportJobListResponse struct {
+	Jobs []*ProductImportJobResponse `json:"jobs"`
+	Meta *MetaResponse               `json:"meta"`
+}
@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+type ReviewResponse struct {
+	ID        int64                  `json:"id"`
+	Rating    uint8                  `json:"rating"`
+	Title     string                 `json:"title"`
+	Body      string                 `json:"body"`
+	Status    string                 `json:"status"`
+	ProductID int64                  `json:"product_id"`
+	User      *ReviewUserResponse    `json:"user"`
+	Images    []*ReviewImageResponse `json:"images"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+type ReviewUserResponse struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+type ReviewImageResponse struct {
+	ID        int64  `json:"id"`
+	Url       string `json:"url"`
+	SortOrder int    `json:"sort_order"`
+}
+
+type ReviewListResponse struct {
+	Reviews []*ReviewResponse `json:"reviews"`
+	Meta    *MetaResponse     `json:"meta"`
+}
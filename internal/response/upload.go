@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type UploadSlotResponse struct {
+	ID          int64             `json:"id"`
+	Key         string            `json:"key"`
+	FileName    string            `json:"file_name"`
+	ContentType string            `json:"content_type"`
+	Size        int64             `json:"size"`
+	Status      string            `json:"status"`
+	Method      string            `json:"method,omitempty"`
+	UploadURL   string            `json:"upload_url,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/security"
+
+	"github.com/gin-gonic/gin"
+)
+
+func NewAccountRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, accountHdl *handler.AccountHandler) {
+	accessName := cfg.App.AccessName
+	secretKey := cfg.App.JWTSecret
+
+	account := rg.Group("/auth/me", security.RequireAuth(accessName, secretKey, userRepo))
+	{
+		account.DELETE("", accountHdl.DeleteMe)
+
+		account.GET("/export", accountHdl.ExportMe)
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func NewAuthRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, authHdl *handler.AuthHandler) {
+func NewAuthRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, permissionRepo repository.PermissionRepository, authHdl *handler.AuthHandler) {
 	accessName := cfg.App.AccessName
 	refreshName := cfg.App.RefreshName
 	secretKey := cfg.App.JWTSecret
@@ -24,7 +24,7 @@ func NewAuthRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.
 
 		auth.POST("/signout", security.RequireAuth(accessName, secretKey, userRepo), authHdl.SignOut)
 
-		auth.GET("/me", security.RequireAuth(accessName, secretKey, userRepo), authHdl.GetMe)
+		auth.GET("/me", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo), authHdl.GetMe)
 
 		auth.GET("/refresh-token", security.RequireRefreshToken(refreshName, secretKey, userRepo), authHdl.RefreshToken)
 
@@ -35,5 +35,9 @@ func NewAuthRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.
 		auth.POST("/reset-password", authHdl.ResetPassword)
 
 		auth.POST("/change-password", security.RequireAuth(accessName, secretKey, userRepo), authHdl.ChangePassword)
+
+		auth.POST("/change-email", security.RequireAuth(accessName, secretKey, userRepo), authHdl.ChangeEmail)
+
+		auth.POST("/change-email/verify", security.RequireAuth(accessName, secretKey, userRepo), authHdl.VerifyChangeEmail)
 	}
 }
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/security"
+)
+
+func NewCatalogExportRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, permissionRepo repository.PermissionRepository, catalogExportHdl *handler.CatalogExportHandler) {
+	accessName := cfg.App.AccessName
+	secretKey := cfg.App.JWTSecret
+
+	rg.GET("/products/export", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), catalogExportHdl.ExportProducts)
+}
+
+func NewFeedRouter(rg *gin.RouterGroup, catalogExportHdl *handler.CatalogExportHandler) {
+	rg.GET("/sitemap.xml", catalogExportHdl.GetSitemap)
+
+	rg.GET("/sitemaps/:page", catalogExportHdl.GetSitemapPage)
+
+	rg.GET("/feeds/google-merchant.xml", catalogExportHdl.GetGoogleMerchantFeed)
+}
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"github.com/tienhai2808/ecom_go/internal/common"
 	"github.com/tienhai2808/ecom_go/internal/config"
 	"github.com/tienhai2808/ecom_go/internal/handler"
 	"github.com/tienhai2808/ecom_go/internal/repository"
@@ -8,20 +9,34 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func NewCategoryRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, categoryHdl *handler.CategoryHandler) {
+func NewCategoryRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, permissionRepo repository.PermissionRepository, categoryHdl *handler.CategoryHandler) {
 	accessName := cfg.App.AccessName
 	secretKey := cfg.App.JWTSecret
 
 	category := rg.Group("/categories")
 	{
-		category.POST("", security.RequireAuth(accessName, secretKey, userRepo), security.RequireAdmin(), categoryHdl.CreateCategory)
+		category.POST("", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermCategoryWrite), categoryHdl.CreateCategory)
 		
-		category.GET("", categoryHdl.GetAllCategories)
+		category.GET("", categoryHdl.GetCategoryTree)
 
-		category.PUT("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequireAdmin(), categoryHdl.UpdateCategory)
+		category.GET("/trash", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermCategoryWrite), categoryHdl.GetDeletedCategories)
 
-		category.DELETE("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequireAdmin(), categoryHdl.DeleteCategory)
+		category.PUT("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermCategoryWrite), categoryHdl.UpdateCategory)
 
-		category.DELETE("", security.RequireAuth(accessName, secretKey, userRepo), security.RequireAdmin(), categoryHdl.DeleteCategories)
+		category.PATCH("/:id/move", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermCategoryWrite), categoryHdl.MoveCategory)
+
+		category.POST("/:id/restore", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermCategoryWrite), categoryHdl.RestoreCategory)
+
+		category.DELETE("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermCategoryWrite), categoryHdl.DeleteCategory)
+
+		category.DELETE("", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermCategoryWrite), categoryHdl.DeleteCategories)
+
+		category.GET("/:id/attributes", categoryHdl.GetCategoryAttributes)
+
+		category.POST("/:id/attributes", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermCategoryWrite), categoryHdl.CreateCategoryAttribute)
+
+		category.PUT("/:id/attributes/:attribute_id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermCategoryWrite), categoryHdl.UpdateCategoryAttribute)
+
+		category.DELETE("/:id/attributes/:attribute_id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermCategoryWrite), categoryHdl.DeleteCategoryAttribute)
 	}
 }
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/security"
+)
+
+func NewMediaRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, permissionRepo repository.PermissionRepository, mediaHdl *handler.MediaHandler) {
+	accessName := cfg.App.AccessName
+	secretKey := cfg.App.JWTSecret
+
+	media := rg.Group("/media")
+	{
+		media.GET("", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), mediaHdl.GetMediaAssets)
+
+		media.GET("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), mediaHdl.GetMediaAssetByID)
+
+		media.POST("", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), mediaHdl.CreateMediaAsset)
+
+		media.PATCH("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), mediaHdl.UpdateMediaAsset)
+
+		media.DELETE("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), mediaHdl.DeleteMediaAsset)
+	}
+}
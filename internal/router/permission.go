@@ -0,0 +1,26 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/security"
+)
+
+func NewPermissionRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, permissionRepo repository.PermissionRepository, permissionHdl *handler.PermissionHandler) {
+	accessName := cfg.App.AccessName
+	secretKey := cfg.App.JWTSecret
+
+	role := rg.Group("/roles", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermRoleManage))
+	{
+		role.GET("", permissionHdl.GetAllRolePermissions)
+
+		role.GET("/:role/permissions", permissionHdl.GetRolePermissions)
+
+		role.POST("/:role/permissions", permissionHdl.GrantPermissions)
+
+		role.DELETE("/:role/permissions", permissionHdl.RevokePermissions)
+	}
+}
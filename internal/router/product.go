@@ -1,6 +1,7 @@
 package router
 
 import (
+	"github.com/tienhai2808/ecom_go/internal/common"
 	"github.com/tienhai2808/ecom_go/internal/config"
 	"github.com/tienhai2808/ecom_go/internal/handler"
 	"github.com/tienhai2808/ecom_go/internal/repository"
@@ -8,7 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func NewProductRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, productHdl *handler.ProductHandler) {
+func NewProductRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, permissionRepo repository.PermissionRepository, productHdl *handler.ProductHandler) {
 	accessName := cfg.App.AccessName
 	secretKey := cfg.App.JWTSecret
 
@@ -16,14 +17,34 @@ func NewProductRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo reposito
 	{
 		product.GET("", productHdl.GetAllProducts)
 
-		product.GET("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequireAdmin(), productHdl.GetProductByID)
+		product.GET("/suggest", productHdl.SuggestProducts)
 
-		product.POST("", security.RequireAuth(accessName, secretKey, userRepo), security.RequireAdmin(), productHdl.CreateProduct)
+		product.GET("/slug/:slug", productHdl.GetProductBySlug)
 
-		product.PATCH("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequireAdmin(), productHdl.UpdateProduct)
+		product.GET("/admin", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.GetAdminProducts)
 
-		product.DELETE("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequireAdmin(), productHdl.DeleteProduct)
+		product.GET("/trash", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.GetDeletedProducts)
 
-		product.DELETE("", security.RequireAuth(accessName, secretKey, userRepo), security.RequireAdmin(), productHdl.DeleteProducts)
+		product.GET("/images/failed", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.GetFailedImages)
+
+		product.POST("/images/retry", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.RetryFailedImages)
+
+		product.GET("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.GetProductByID)
+
+		product.POST("", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.CreateProduct)
+
+		product.PATCH("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.UpdateProduct)
+
+		product.POST("/:id/restore", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.RestoreProduct)
+
+		product.GET("/:id/history", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.GetProductHistory)
+
+		product.POST("/:id/history/:version/revert", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.RevertProduct)
+
+		product.DELETE("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.DeleteProduct)
+
+		product.PATCH("", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.BulkUpdateProducts)
+
+		product.DELETE("", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productHdl.DeleteProducts)
 	}
 }
@@ -0,0 +1,24 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/security"
+)
+
+func NewProductImportRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, permissionRepo repository.PermissionRepository, productImportHdl *handler.ProductImportHandler) {
+	accessName := cfg.App.AccessName
+	secretKey := cfg.App.JWTSecret
+
+	imports := rg.Group("/products/imports")
+	{
+		imports.POST("", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productImportHdl.CreateImportJob)
+
+		imports.GET("", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productImportHdl.GetImportJobs)
+
+		imports.GET("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermProductWrite), productImportHdl.GetImportJobByID)
+	}
+}
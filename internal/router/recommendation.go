@@ -0,0 +1,13 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+)
+
+func NewRecommendationRouter(rg *gin.RouterGroup, recommendationHdl *handler.RecommendationHandler) {
+	product := rg.Group("/products")
+	{
+		product.GET("/:id/related", recommendationHdl.GetRelatedProducts)
+	}
+}
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/security"
+)
+
+func NewReviewRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, permissionRepo repository.PermissionRepository, reviewHdl *handler.ReviewHandler) {
+	accessName := cfg.App.AccessName
+	secretKey := cfg.App.JWTSecret
+
+	productReview := rg.Group("/products/:id/reviews")
+	{
+		productReview.GET("", reviewHdl.GetProductReviews)
+
+		productReview.POST("", security.RequireAuth(accessName, secretKey, userRepo), reviewHdl.CreateReview)
+	}
+
+	review := rg.Group("/reviews")
+	{
+		review.GET("", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermReviewModerate), reviewHdl.GetReviews)
+
+		review.PATCH("/:id/moderate", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermReviewModerate), reviewHdl.ModerateReview)
+
+		review.DELETE("/:id", security.RequireAuth(accessName, secretKey, userRepo), security.RequirePermission(permissionRepo, common.PermReviewModerate), reviewHdl.DeleteReview)
+	}
+}
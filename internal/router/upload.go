@@ -0,0 +1,23 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	"github.com/tienhai2808/ecom_go/internal/handler"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/security"
+)
+
+func NewUploadRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, uploadHdl *handler.UploadHandler) {
+	accessName := cfg.App.AccessName
+	secretKey := cfg.App.JWTSecret
+
+	upload := rg.Group("/uploads")
+	{
+		upload.POST("/slots", security.RequireAuth(accessName, secretKey, userRepo), uploadHdl.CreateUploadSlots)
+
+		upload.PUT("/:id", security.RequireAuth(accessName, secretKey, userRepo), uploadHdl.UploadContent)
+
+		upload.POST("/:id/confirm", security.RequireAuth(accessName, secretKey, userRepo), uploadHdl.ConfirmUpload)
+	}
+}
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"github.com/tienhai2808/ecom_go/internal/common"
 	"github.com/tienhai2808/ecom_go/internal/config"
 	"github.com/tienhai2808/ecom_go/internal/handler"
 	"github.com/tienhai2808/ecom_go/internal/repository"
@@ -9,22 +10,24 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func NewUserRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, userHdl *handler.UserHandler) {
+func NewUserRouter(rg *gin.RouterGroup, cfg *config.Config, userRepo repository.UserRepository, permissionRepo repository.PermissionRepository, userHdl *handler.UserHandler) {
 	accessName := cfg.App.AccessName
 	secretKey := cfg.App.JWTSecret
 
-	user := rg.Group("/users", security.RequireAuth(accessName, secretKey, userRepo), security.RequireAdmin())
+	user := rg.Group("/users", security.RequireAuth(accessName, secretKey, userRepo))
 	{
-		user.GET("", userHdl.GetAllUsers)
+		user.GET("", security.RequirePermission(permissionRepo, common.PermUserRead), userHdl.GetAllUsers)
 
-		user.GET("/:id", userHdl.GetUserByID)
+		user.GET("/:id", security.RequirePermission(permissionRepo, common.PermUserRead), userHdl.GetUserByID)
 
-		user.POST("", userHdl.CreateUser)
+		user.GET("/:id/audit-logs", security.RequirePermission(permissionRepo, common.PermUserRead), userHdl.GetUserAuditLogs)
 
-		user.PATCH("/:id", userHdl.UpdateUser)
+		user.POST("", security.RequirePermission(permissionRepo, common.PermUserWrite), userHdl.CreateUser)
 
-		user.DELETE("/:id", userHdl.DeleteUser)
+		user.PATCH("/:id", security.RequirePermission(permissionRepo, common.PermUserWrite), userHdl.UpdateUser)
 
-		user.DELETE("", userHdl.DeleteManyUsers)
+		user.DELETE("/:id", security.RequirePermission(permissionRepo, common.PermUserWrite), userHdl.DeleteUser)
+
+		user.DELETE("", security.RequirePermission(permissionRepo, common.PermUserWrite), userHdl.DeleteManyUsers)
 	}
 }
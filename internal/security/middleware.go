@@ -3,6 +3,7 @@ package security
 import (
 	"fmt"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -68,6 +69,14 @@ func RequireAuth(accessName, secretKey string, userRepo repository.UserRepositor
 			return
 		}
 
+		if user.DeactivatedAt != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.ApiResponse{
+				StatusCode: http.StatusUnauthorized,
+				Message:    customErr.ErrAccountDeactivated.Error(),
+			})
+			return
+		}
+
 		userData := mapper.ToUserData(user)
 
 		c.Set("user", userData)
@@ -107,6 +116,56 @@ func RequireAdmin() gin.HandlerFunc {
 	}
 }
 
+func RequirePermission(permissionRepo repository.PermissionRepository, permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userAny, exists := c.Get("user")
+		if !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.ApiResponse{
+				StatusCode: http.StatusUnauthorized,
+				Message:    customErr.ErrUnAuth.Error(),
+			})
+			return
+		}
+
+		user, ok := userAny.(*types.UserData)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.ApiResponse{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "không thể chuyển đổi thông tin người dùng",
+			})
+			return
+		}
+
+		if user.Permissions == nil {
+			if user.Role == common.RoleAdmin {
+				user.Permissions = common.AllPermissions
+			} else {
+				rolePermissions, err := permissionRepo.FindAllByRole(c.Request.Context(), user.Role)
+				if err != nil {
+					c.AbortWithStatusJSON(http.StatusInternalServerError, response.ApiResponse{
+						StatusCode: http.StatusInternalServerError,
+						Message:    fmt.Sprintf("lấy quyền hạn của người dùng thất bại: %v", err),
+					})
+					return
+				}
+				user.Permissions = mapper.ToPermissionNames(rolePermissions)
+			}
+		}
+
+		for _, permission := range permissions {
+			if !slices.Contains(user.Permissions, permission) {
+				c.AbortWithStatusJSON(http.StatusForbidden, response.ApiResponse{
+					StatusCode: http.StatusForbidden,
+					Message:    customErr.ErrForbidden.Error(),
+				})
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
 func RequireGuestToken(guestName, secretKey string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokenStr, err := c.Cookie(guestName)
@@ -204,6 +263,14 @@ func RequireRefreshToken(refreshName, secretKey string, userRepo repository.User
 			return
 		}
 
+		if user.DeactivatedAt != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.ApiResponse{
+				StatusCode: http.StatusUnauthorized,
+				Message:    customErr.ErrAccountDeactivated.Error(),
+			})
+			return
+		}
+
 		c.Set("user_id", user.ID)
 		c.Set("user_role", user.Role)
 		c.Next()
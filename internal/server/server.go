@@ -9,13 +9,16 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
+	"github.com/tienhai2808/ecom_go/internal/common"
 	"github.com/tienhai2808/ecom_go/internal/config"
 	"github.com/tienhai2808/ecom_go/internal/consumers"
 	"github.com/tienhai2808/ecom_go/internal/container"
 	"github.com/tienhai2808/ecom_go/internal/initialization"
+	"github.com/tienhai2808/ecom_go/internal/jobs"
 	"github.com/tienhai2808/ecom_go/internal/kafka"
 	"github.com/tienhai2808/ecom_go/internal/router"
 )
@@ -52,22 +55,38 @@ func NewServer(cfg *config.Config) (*Server, error) {
 
 	kmq := initialization.InitKafka(cfg)
 
-	es, err := initialization.InitElasticsearch(cfg)
-	if err != nil {
-		return nil, err
+	var es *elasticsearch.TypedClient
+	if cfg.Search.Engine != common.SearchEngineMySQL {
+		if es, err = initialization.InitElasticsearch(cfg); err != nil {
+			log.Printf("%v, chuyển sang tìm kiếm bằng MySQL", err)
+		}
 	}
 
-	cld, err := initialization.InitCloudinary(cfg)
+	store, err := initialization.InitStorage(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	ctn := container.NewContainer(db.Gorm, rdb, cfg, rmq.Chan, sf, cld, es)
+	ctn := container.NewContainer(db.Gorm, rdb, cfg, rmq.Chan, sf, store, es)
 
 	go kafka.ConsumeMessages(context.Background(), kmq.Reader, kafka.MessageHandler)
 	go consumers.StartSendEmailConsumer(rmq, ctn.SMTPSvc)
-	go consumers.StartUploadImageMessage(rmq, ctn.CloudinarySvc, ctn.ProductModule.ImageRepo)
-	go consumers.StartDeleteImageMessage(rmq, ctn.CloudinarySvc)
+	go consumers.StartUploadImageMessage(rmq, ctn.Storage, ctn.ProductModule.ImageRepo, ctn.ProductModule.ProductIndexSvc)
+	go consumers.StartUploadReviewImageMessage(rmq, ctn.Storage, ctn.ReviewModule.ReviewRepo)
+	go consumers.StartUploadMediaMessage(rmq, ctn.Storage, ctn.MediaModule.MediaRepo)
+	go consumers.StartDeleteImageMessage(rmq, ctn.Storage, ctn.MediaModule.MediaRepo)
+	go consumers.StartProductIndexConsumer(rmq, ctn.ProductModule.ProductIndexSvc)
+	go consumers.StartProductImportConsumer(rmq, ctn.ProductImportModule.ProductImportSvc)
+	go jobs.StartAnonymizeAccountsJob(ctn.AccountModule.AccountSvc, time.Hour)
+	if es != nil {
+		go jobs.EnsureProductIndex(ctn.ProductModule.ProductIndexSvc)
+		go jobs.RebuildSuggestions(ctn.ProductModule.ProductSvc)
+	}
+	go jobs.StartPurgeTrashJob(ctn.ProductModule.ProductSvc, ctn.CategoryModule.CategorySvc, time.Duration(cfg.Catalog.TrashRetentionDays)*24*time.Hour, time.Hour)
+	go jobs.StartImageReaperJob(ctn.ProductModule.ProductSvc, ctn.UploadModule.UploadSvc, time.Duration(cfg.Storage.OrphanGraceMinutes)*time.Minute, 15*time.Minute)
+	go jobs.StartFeedJob(ctn.CatalogExportModule.CatalogExportSvc, time.Duration(cfg.Feed.IntervalMinutes)*time.Minute)
+	go jobs.StartPublishScheduleJob(ctn.ProductModule.ProductSvc, time.Duration(cfg.Catalog.PublishIntervalMinutes)*time.Minute)
+	go jobs.StartCoPurchaseJob(ctn.RecommendationModule.RecommendationSvc, time.Duration(cfg.Catalog.CoPurchaseIntervalHours)*time.Hour)
 
 	r := gin.Default()
 
@@ -86,15 +105,37 @@ func NewServer(cfg *config.Config) (*Server, error) {
 
 	r.Use(cors.New(corsConfig))
 
+	if cfg.Storage.Driver == common.StorageDriverLocal {
+		servePath := cfg.Storage.Local.ServePath
+		if servePath == "" {
+			servePath = "/uploads"
+		}
+		dir := cfg.Storage.Local.Dir
+		if dir == "" {
+			dir = "uploads"
+		}
+		r.Static(servePath, dir)
+	}
+
+	router.NewFeedRouter(&r.RouterGroup, ctn.CatalogExportModule.CatalogExportHdl)
+
 	api := r.Group(cfg.App.ApiPrefix)
 
-	router.NewUserRouter(api, cfg, ctn.UserModule.UserRepo, ctn.UserModule.UserHdl)
-	router.NewAuthRouter(api, cfg, ctn.UserModule.UserRepo, ctn.AuthModule.AuthHdl)
+	router.NewUserRouter(api, cfg, ctn.UserModule.UserRepo, ctn.PermissionModule.PermissionRepo, ctn.UserModule.UserHdl)
+	router.NewAuthRouter(api, cfg, ctn.UserModule.UserRepo, ctn.PermissionModule.PermissionRepo, ctn.AuthModule.AuthHdl)
 	router.NewAddressRouter(api, cfg, ctn.UserModule.UserRepo, ctn.AddressModule.AddressHdl)
-	router.NewProductRouter(api, cfg, ctn.UserModule.UserRepo, ctn.ProductModule.ProductHdl)
+	router.NewProductRouter(api, cfg, ctn.UserModule.UserRepo, ctn.PermissionModule.PermissionRepo, ctn.ProductModule.ProductHdl)
+	router.NewProductImportRouter(api, cfg, ctn.UserModule.UserRepo, ctn.PermissionModule.PermissionRepo, ctn.ProductImportModule.ProductImportHdl)
+	router.NewCatalogExportRouter(api, cfg, ctn.UserModule.UserRepo, ctn.PermissionModule.PermissionRepo, ctn.CatalogExportModule.CatalogExportHdl)
 	router.NewProfileRouter(api, cfg, ctn.UserModule.UserRepo, ctn.ProfileModule.ProfileHdl)
-	router.NewCategoryRouter(api, cfg, ctn.UserModule.UserRepo, ctn.CategoryModule.CategoryHdl)
+	router.NewCategoryRouter(api, cfg, ctn.UserModule.UserRepo, ctn.PermissionModule.PermissionRepo, ctn.CategoryModule.CategoryHdl)
 	router.NewCartRouter(api, cfg, ctn.UserModule.UserRepo, ctn.CartModule.CartHdl)
+	router.NewAccountRouter(api, cfg, ctn.UserModule.UserRepo, ctn.AccountModule.AccountHdl)
+	router.NewRecommendationRouter(api, ctn.RecommendationModule.RecommendationHdl)
+	router.NewUploadRouter(api, cfg, ctn.UserModule.UserRepo, ctn.UploadModule.UploadHdl)
+	router.NewMediaRouter(api, cfg, ctn.UserModule.UserRepo, ctn.PermissionModule.PermissionRepo, ctn.MediaModule.MediaHdl)
+	router.NewReviewRouter(api, cfg, ctn.UserModule.UserRepo, ctn.PermissionModule.PermissionRepo, ctn.ReviewModule.ReviewHdl)
+	router.NewPermissionRouter(api, cfg, ctn.UserModule.UserRepo, ctn.PermissionModule.PermissionRepo, ctn.PermissionModule.PermissionHdl)
 
 	addr := fmt.Sprintf(":%d", cfg.App.Port)
 
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+type AccountService interface {
+	RequestDeletion(ctx context.Context, userID int64, req request.DeleteAccountRequest) (*model.User, error)
+
+	ExportData(ctx context.Context, userID int64) (*response.AccountExportResponse, error)
+
+	AnonymizeExpiredAccounts(ctx context.Context) (int, error)
+}
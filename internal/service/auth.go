@@ -20,4 +20,8 @@ type AuthService interface {
 	ResetPassword(ctx context.Context, req request.ResetPasswordRequest) (*response.UserResponse, string, string, error)
 
 	ChangePassword(ctx context.Context, userID int64, req request.ChangePasswordRequest) (*response.UserResponse, string, string, error)
+
+	ChangeEmail(ctx context.Context, userID int64, req request.ChangeEmailRequest) (string, error)
+
+	VerifyChangeEmail(ctx context.Context, userID int64, req request.VerifyChangeEmailRequest) (*response.UserResponse, error)
 }
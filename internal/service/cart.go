@@ -21,7 +21,7 @@ type CartService interface {
 
 	GetGuestCart(ctx context.Context, guestID string) (*response.GuestCartResponse, error)
 
-	GuestUpdateCartItem(ctx context.Context, guestID string, productID int64, quantity uint) (*response.GuestCartResponse, error)
+	GuestUpdateCartItem(ctx context.Context, guestID string, productID int64, variantID *int64, quantity uint) (*response.GuestCartResponse, error)
 
-	GuestDeleteCartItem(ctx context.Context, guestID string, productID int64) (*response.GuestCartResponse, error)
+	GuestDeleteCartItem(ctx context.Context, guestID string, productID int64, variantID *int64) (*response.GuestCartResponse, error)
 }
\ No newline at end of file
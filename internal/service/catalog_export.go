@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/tienhai2808/ecom_go/internal/request"
+)
+
+type CatalogExportService interface {
+	ExportProducts(ctx context.Context, query request.ExportProductsQuery, w io.Writer) error
+
+	GenerateFeeds(ctx context.Context) (int, error)
+
+	GetFeed(ctx context.Context, name string) ([]byte, error)
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"time"
+
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"github.com/tienhai2808/ecom_go/internal/request"
 )
@@ -9,11 +11,27 @@ import (
 type CategoryService interface {
 	CreateCategory(ctx context.Context, req request.CreateCategoryRequest) (*model.Category, error)
 
-	GetAllCategories(ctx context.Context) ([]*model.Category, error)
+	GetCategoryTree(ctx context.Context) ([]*model.Category, error)
+
+	MoveCategory(ctx context.Context, id int64, req request.MoveCategoryRequest) (*model.Category, error)
 
 	UpdateCategory(ctx context.Context, id int64, req request.UpdateCategoryRequest) (*model.Category, error)
 
 	DeleteCategory(ctx context.Context, id int64) error
 
 	DeleteCategories(ctx context.Context, req request.DeleteManyRequest) (int64, error)
+
+	GetDeletedCategories(ctx context.Context) ([]*model.Category, error)
+
+	RestoreCategory(ctx context.Context, id int64) (*model.Category, error)
+
+	PurgeDeletedCategories(ctx context.Context, before time.Time) (int64, error)
+
+	GetCategoryAttributes(ctx context.Context, categoryID int64) ([]*model.CategoryAttribute, error)
+
+	CreateCategoryAttribute(ctx context.Context, categoryID int64, req request.CreateCategoryAttributeRequest) (*model.CategoryAttribute, error)
+
+	UpdateCategoryAttribute(ctx context.Context, categoryID, attributeID int64, req request.UpdateCategoryAttributeRequest) (*model.CategoryAttribute, error)
+
+	DeleteCategoryAttribute(ctx context.Context, categoryID, attributeID int64) error
 }
@@ -0,0 +1,230 @@
+package implement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/mapper"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/rabbitmq"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/response"
+	"github.com/tienhai2808/ecom_go/internal/security"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
+)
+
+type accountServiceImpl struct {
+	db           *gorm.DB
+	userRepo     repository.UserRepository
+	profileRepo  repository.ProfileRepository
+	addressRepo  repository.AddressRepository
+	cartRepo     repository.CartRepository
+	orderRepo    repository.OrderRepository
+	reviewRepo   repository.ReviewRepository
+	auditLogRepo repository.AuditLogRepository
+	uploadRepo   repository.UploadRepository
+	mediaRepo    repository.MediaRepository
+	rabbitChan   *amqp091.Channel
+	cfg          *config.Config
+}
+
+func NewAccountService(db *gorm.DB, userRepo repository.UserRepository, profileRepo repository.ProfileRepository, addressRepo repository.AddressRepository, cartRepo repository.CartRepository, orderRepo repository.OrderRepository, reviewRepo repository.ReviewRepository, auditLogRepo repository.AuditLogRepository, uploadRepo repository.UploadRepository, mediaRepo repository.MediaRepository, rabbitChan *amqp091.Channel, cfg *config.Config) service.AccountService {
+	return &accountServiceImpl{
+		db,
+		userRepo,
+		profileRepo,
+		addressRepo,
+		cartRepo,
+		orderRepo,
+		reviewRepo,
+		auditLogRepo,
+		uploadRepo,
+		mediaRepo,
+		rabbitChan,
+		cfg,
+	}
+}
+
+func (s *accountServiceImpl) RequestDeletion(ctx context.Context, userID int64, req request.DeleteAccountRequest) (*model.User, error) {
+	user, err := s.userRepo.FindByIDWithProfile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin người dùng thất bại: %w", err)
+	}
+	if user == nil {
+		return nil, customErr.ErrUserNotFound
+	}
+
+	isCorrectPassword, err := security.VerifyPassword(user.Password, req.Password)
+	if err != nil || !isCorrectPassword {
+		return nil, customErr.ErrIncorrectPassword
+	}
+
+	now := time.Now()
+	scheduledAt := now.Add(s.deletionGracePeriod())
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		if user.Role == common.RoleAdmin {
+			adminCount, err := s.userRepo.CountByRoleForUpdateTx(ctx, tx, common.RoleAdmin)
+			if err != nil {
+				return fmt.Errorf("đếm số lượng admin thất bại: %w", err)
+			}
+			if adminCount <= 1 {
+				return customErr.ErrLastAdmin
+			}
+		}
+
+		if err := s.userRepo.UpdateTx(ctx, tx, user.ID, map[string]any{
+			"deactivated_at":        now,
+			"deletion_scheduled_at": scheduledAt,
+		}); err != nil {
+			return fmt.Errorf("vô hiệu hóa tài khoản thất bại: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	user.DeactivatedAt = &now
+	user.DeletionScheduledAt = &scheduledAt
+
+	emailMsg := types.SendEmailMessage{
+		To:      user.Email,
+		Subject: "Yêu cầu xóa tài khoản",
+		Body:    fmt.Sprintf(`Tài khoản của bạn đã bị vô hiệu hóa và sẽ bị xóa vĩnh viễn vào <strong>%s</strong>. Đăng nhập lại trước thời điểm này để hủy yêu cầu xóa tài khoản.`, scheduledAt.Format("15:04 02/01/2006")),
+	}
+
+	go func(msg types.SendEmailMessage) {
+		body, _ := json.Marshal(msg)
+		if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeEmail, common.RoutingKeyEmailSend, body); err != nil {
+			log.Printf("publish email msg thất bại: %v", err)
+		}
+	}(emailMsg)
+
+	return user, nil
+}
+
+func (s *accountServiceImpl) ExportData(ctx context.Context, userID int64) (*response.AccountExportResponse, error) {
+	user, err := s.userRepo.FindByIDWithProfile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin người dùng thất bại: %w", err)
+	}
+	if user == nil {
+		return nil, customErr.ErrUserNotFound
+	}
+
+	addresses, err := s.addressRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách địa chỉ thất bại: %w", err)
+	}
+
+	cart, err := s.cartRepo.FindCartByUserIDWithDetails(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin giỏ hàng thất bại: %w", err)
+	}
+
+	orders, err := s.orderRepo.FindAllByUserIDWithItems(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách đơn hàng thất bại: %w", err)
+	}
+
+	reviews, err := s.reviewRepo.FindAllByUserIDWithImages(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách đánh giá thất bại: %w", err)
+	}
+
+	auditLogs, err := s.auditLogRepo.FindAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy nhật ký thay đổi thất bại: %w", err)
+	}
+
+	slots, err := s.uploadRepo.FindAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách tệp tải lên thất bại: %w", err)
+	}
+
+	assets, err := s.mediaRepo.FindAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách tệp đa phương tiện thất bại: %w", err)
+	}
+
+	return mapper.ToAccountExportResponse(user, addresses, cart, orders, reviews, auditLogs, slots, assets), nil
+}
+
+func (s *accountServiceImpl) AnonymizeExpiredAccounts(ctx context.Context) (int, error) {
+	users, err := s.userRepo.FindAllDeletionDue(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("lấy danh sách tài khoản cần xóa thất bại: %w", err)
+	}
+
+	anonymized := 0
+	for _, user := range users {
+		if err = s.anonymizeUser(ctx, user.ID); err != nil {
+			log.Printf("ẩn danh tài khoản %d thất bại: %v", user.ID, err)
+			continue
+		}
+		anonymized++
+	}
+
+	return anonymized, nil
+}
+
+func (s *accountServiceImpl) anonymizeUser(ctx context.Context, userID int64) error {
+	hashedPassword, err := security.HashPassword(uuid.NewString())
+	if err != nil {
+		return fmt.Errorf("băm mật khẩu thất bại: %w", err)
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.userRepo.UpdateTx(ctx, tx, userID, map[string]any{
+			"username":      fmt.Sprintf("deleted_%d", userID),
+			"email":         fmt.Sprintf("deleted_%d@anonymized.invalid", userID),
+			"password":      hashedPassword,
+			"role":          common.RoleUser,
+			"anonymized_at": time.Now(),
+		}); err != nil {
+			return fmt.Errorf("ẩn danh người dùng thất bại: %w", err)
+		}
+
+		if err := s.profileRepo.UpdateByUserIDTx(ctx, tx, userID, map[string]any{
+			"first_name":   "",
+			"last_name":    "",
+			"phone_number": "",
+			"dob":          nil,
+			"gender":       "other",
+		}); err != nil {
+			return fmt.Errorf("ẩn danh hồ sơ thất bại: %w", err)
+		}
+
+		if err := s.addressRepo.UpdateAllByUserIDTx(ctx, tx, userID, map[string]any{
+			"full_name":    "",
+			"phone_number": "",
+			"address":      "",
+			"commune":      "",
+			"province":     "",
+		}); err != nil {
+			return fmt.Errorf("ẩn danh địa chỉ thất bại: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *accountServiceImpl) deletionGracePeriod() time.Duration {
+	days := s.cfg.Account.DeletionGraceDays
+	if days <= 0 {
+		days = 30
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
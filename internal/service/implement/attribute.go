@@ -0,0 +1,96 @@
+package implement
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+)
+
+func (s *productServiceImpl) buildAttributeValues(productID int64, attributes []*model.CategoryAttribute, current []*model.ProductAttributeValue, forms []request.ProductAttributeForm) ([]*model.ProductAttributeValue, error) {
+	attributeMap := make(map[int64]*model.CategoryAttribute, len(attributes))
+	for _, attribute := range attributes {
+		attributeMap[attribute.ID] = attribute
+	}
+
+	values := make(map[int64]string, len(attributes))
+	for _, value := range current {
+		if _, ok := attributeMap[value.AttributeID]; ok {
+			values[value.AttributeID] = value.Value
+		}
+	}
+
+	for _, form := range forms {
+		attribute, ok := attributeMap[form.AttributeID]
+		if !ok {
+			return nil, customErr.ErrHasAttributeNotFound
+		}
+
+		raw := strings.TrimSpace(form.Value)
+		if raw == "" {
+			delete(values, attribute.ID)
+			continue
+		}
+
+		value, err := normalizeAttributeValue(attribute, raw)
+		if err != nil {
+			return nil, err
+		}
+		values[attribute.ID] = value
+	}
+
+	attributeValues := make([]*model.ProductAttributeValue, 0, len(values))
+	for _, attribute := range attributes {
+		value, ok := values[attribute.ID]
+		if !ok {
+			if attribute.IsRequired {
+				return nil, customErr.ErrAttributeValueRequired
+			}
+			continue
+		}
+
+		valueID, err := s.sfg.NextID()
+		if err != nil {
+			return nil, err
+		}
+
+		attributeValues = append(attributeValues, &model.ProductAttributeValue{
+			ID:          valueID,
+			Value:       value,
+			ProductID:   productID,
+			AttributeID: attribute.ID,
+		})
+	}
+
+	return attributeValues, nil
+}
+
+func normalizeAttributeValue(attribute *model.CategoryAttribute, value string) (string, error) {
+	switch attribute.Type {
+	case common.AttributeTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return "", customErr.ErrInvalidAttributeValue
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case common.AttributeTypeBoolean:
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", customErr.ErrInvalidAttributeValue
+		}
+		return strconv.FormatBool(boolean), nil
+	case common.AttributeTypeEnum:
+		for _, option := range attribute.Options {
+			if strings.EqualFold(option, value) {
+				return option, nil
+			}
+		}
+		return "", customErr.ErrInvalidAttributeValue
+	default:
+		return value, nil
+	}
+}
@@ -205,11 +205,24 @@ func (s *authServiceImpl) SignIn(ctx context.Context, req request.SignInRequest)
 		return nil, "", "", customErr.ErrUserNotFound
 	}
 
+	if user.AnonymizedAt != nil {
+		return nil, "", "", customErr.ErrUserNotFound
+	}
+
 	isCorrectPassword, err := security.VerifyPassword(user.Password, req.Password)
 	if err != nil || !isCorrectPassword {
 		return nil, "", "", customErr.ErrIncorrectPassword
 	}
 
+	if user.DeactivatedAt != nil {
+		if err = s.userRepo.Update(ctx, user.ID, map[string]any{
+			"deactivated_at":        nil,
+			"deletion_scheduled_at": nil,
+		}); err != nil {
+			return nil, "", "", fmt.Errorf("khôi phục tài khoản thất bại: %w", err)
+		}
+	}
+
 	accessToken, err := security.GenerateToken(user.ID, string(user.Role), 60*time.Minute, s.cfg.App.JWTSecret)
 	if err != nil {
 		return nil, "", "", fmt.Errorf("tạo access_token thất bại: %w", err)
@@ -385,6 +398,137 @@ func (s *authServiceImpl) ChangePassword(ctx context.Context, userID int64, req
 	return mapper.ToUserResponse(user), accessToken, refreshToken, nil
 }
 
+func (s *authServiceImpl) ChangeEmail(ctx context.Context, userID int64, req request.ChangeEmailRequest) (string, error) {
+	user, err := s.userRepo.FindByIDWithProfile(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("lấy thông tin người dùng thất bại: %w", err)
+	}
+	if user == nil {
+		return "", customErr.ErrUserNotFound
+	}
+
+	isCorrectPassword, err := security.VerifyPassword(user.Password, req.Password)
+	if err != nil {
+		return "", fmt.Errorf("so sánh mật khẩu thất bại: %w", err)
+	}
+	if !isCorrectPassword {
+		return "", customErr.ErrIncorrectPassword
+	}
+
+	if req.NewEmail == user.Email {
+		return "", customErr.ErrSameEmail
+	}
+
+	exists, err := s.userRepo.ExistsByEmail(ctx, req.NewEmail)
+	if err != nil {
+		return "", fmt.Errorf("kiểm tra người dùng tồn tại thất bại: %w", err)
+	}
+	if exists {
+		return "", customErr.ErrEmailExists
+	}
+
+	otp := generateOtp(5)
+	changeEmailToken := uuid.NewString()
+
+	changeData := types.ChangeEmailData{
+		UserID:   user.ID,
+		OldEmail: user.Email,
+		NewEmail: req.NewEmail,
+		Otp:      otp,
+		Attempts: 0,
+	}
+
+	if err = s.authRepo.AddChangeEmailData(ctx, changeEmailToken, changeData, 3*time.Minute); err != nil {
+		return "", fmt.Errorf("lưu dữ liệu thay đổi email thất bại: %w", err)
+	}
+
+	emailMsg := types.SendEmailMessage{
+		To:      req.NewEmail,
+		Subject: "Mã xác nhận Thay đổi email",
+		Body:    fmt.Sprintf(`Đây là mã OTP của bạn, nó sẽ hết hạn sau 3 phút: <p style="text-align: center"><strong style="font-size: 18px; color: #333;">%s</strong></p>`, otp),
+	}
+
+	go func(msg types.SendEmailMessage) {
+		body, _ := json.Marshal(msg)
+		if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeEmail, common.RoutingKeyEmailSend, body); err != nil {
+			log.Printf("publish email msg thất bại: %v", err)
+		}
+	}(emailMsg)
+
+	return changeEmailToken, nil
+}
+
+func (s *authServiceImpl) VerifyChangeEmail(ctx context.Context, userID int64, req request.VerifyChangeEmailRequest) (*response.UserResponse, error) {
+	changeData, err := s.authRepo.GetChangeEmailData(ctx, req.ChangeEmailToken)
+	if err != nil {
+		return nil, fmt.Errorf("lấy dữ liệu thay đổi email thất bại: %w", err)
+	}
+
+	if changeData == nil || changeData.UserID != userID {
+		return nil, customErr.ErrKeyNotFound
+	}
+
+	if changeData.Attempts >= 3 {
+		if err = s.authRepo.DeleteAuthData(ctx, "change-email", req.ChangeEmailToken); err != nil {
+			return nil, fmt.Errorf("xóa dữ liệu thay đổi email thất bại: %w", err)
+		}
+		return nil, customErr.ErrTooManyAttempts
+	}
+
+	if changeData.Otp != req.Otp {
+		changeData.Attempts++
+		if err = s.authRepo.AddChangeEmailData(ctx, req.ChangeEmailToken, *changeData, 3*time.Minute); err != nil {
+			return nil, fmt.Errorf("cập nhật dữ liệu thay đổi email thất bại: %w", err)
+		}
+		return nil, customErr.ErrInvalidOTP
+	}
+
+	exists, err := s.userRepo.ExistsByEmail(ctx, changeData.NewEmail)
+	if err != nil {
+		return nil, fmt.Errorf("kiểm tra người dùng tồn tại thất bại: %w", err)
+	}
+	if exists {
+		return nil, customErr.ErrEmailExists
+	}
+
+	if err = s.userRepo.Update(ctx, userID, map[string]any{"email": changeData.NewEmail}); err != nil {
+		if common.IsUniqueViolation(err) {
+			return nil, customErr.ErrEmailExists
+		}
+		if errors.Is(err, customErr.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("cập nhật email thất bại: %w", err)
+	}
+
+	if err = s.authRepo.DeleteAuthData(ctx, "change-email", req.ChangeEmailToken); err != nil {
+		return nil, fmt.Errorf("xóa dữ liệu thay đổi email thất bại: %w", err)
+	}
+
+	emailMsg := types.SendEmailMessage{
+		To:      changeData.OldEmail,
+		Subject: "Thông báo Thay đổi email",
+		Body:    fmt.Sprintf(`Email đăng nhập của tài khoản đã được thay đổi thành <strong>%s</strong>. Nếu bạn không thực hiện thay đổi này, vui lòng liên hệ với chúng tôi ngay.`, changeData.NewEmail),
+	}
+
+	go func(msg types.SendEmailMessage) {
+		body, _ := json.Marshal(msg)
+		if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeEmail, common.RoutingKeyEmailSend, body); err != nil {
+			log.Printf("publish email msg thất bại: %v", err)
+		}
+	}(emailMsg)
+
+	user, err := s.userRepo.FindByIDWithProfile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin người dùng thất bại: %w", err)
+	}
+	if user == nil {
+		return nil, customErr.ErrUserNotFound
+	}
+
+	return mapper.ToUserResponse(user), nil
+}
+
 func generateOtp(length int) string {
 	min := int(math.Pow10(length))
 	max := 9 * min
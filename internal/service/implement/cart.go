@@ -20,14 +20,16 @@ import (
 type cartServiceImpl struct {
 	cartRepo    repository.CartRepository
 	productRepo repository.ProductRepository
+	variantRepo repository.VariantRepository
 	db          *gorm.DB
 	sfg         snowflake.SnowflakeGenerator
 }
 
-func NewCartService(cartRepo repository.CartRepository, productRepo repository.ProductRepository, db *gorm.DB, sfg snowflake.SnowflakeGenerator) service.CartService {
+func NewCartService(cartRepo repository.CartRepository, productRepo repository.ProductRepository, variantRepo repository.VariantRepository, db *gorm.DB, sfg snowflake.SnowflakeGenerator) service.CartService {
 	return &cartServiceImpl{
 		cartRepo,
 		productRepo,
+		variantRepo,
 		db,
 		sfg,
 	}
@@ -75,8 +77,13 @@ func (s *cartServiceImpl) AddCartItem(ctx context.Context, userID int64, req req
 		return nil, customErr.ErrProductNotFound
 	}
 
+	unitPrice, err := s.resolveUnitPrice(ctx, product, req.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
 	if err = s.db.Transaction(func(tx *gorm.DB) error {
-		existingItem, err := s.cartRepo.FindCartItemByCartIDAndProductIDTx(ctx, tx, cart.ID, product.ID)
+		existingItem, err := s.cartRepo.FindCartItemByCartIDAndProductIDTx(ctx, tx, cart.ID, product.ID, req.VariantID)
 		if err != nil {
 			return fmt.Errorf("kiểm tra sản phẩm trong giỏ hàng thất bại: %w", err)
 		}
@@ -93,7 +100,7 @@ func (s *cartServiceImpl) AddCartItem(ctx context.Context, userID int64, req req
 				return fmt.Errorf("cập nhật sản phẩm trong giỏ hàng thất bại: %w", err)
 			}
 
-			totalPriceCart := cart.TotalPrice + product.Price*float64(req.Quantity)
+			totalPriceCart := cart.TotalPrice + existingItem.UnitPrice*float64(req.Quantity)
 			totalQuantityCart := cart.TotalQuantity + req.Quantity
 
 			updateData = map[string]any{
@@ -111,10 +118,11 @@ func (s *cartServiceImpl) AddCartItem(ctx context.Context, userID int64, req req
 
 			cartItem := &model.CartItem{
 				ID:        cartItemID,
-				UnitPrice: product.Price,
+				UnitPrice: unitPrice,
 				Quantity:  req.Quantity,
 				CartID:    cart.ID,
 				ProductID: product.ID,
+				VariantID: req.VariantID,
 			}
 			cartItem.SetTotalPrice()
 
@@ -280,9 +288,14 @@ func (s *cartServiceImpl) GuestAddCartItem(ctx context.Context, guestID string,
 		return nil, customErr.ErrProductNotFound
 	}
 
+	unitPrice, err := s.resolveUnitPrice(ctx, product, req.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
 	found := false
 	for i := range cart.Items {
-		if cart.Items[i].ProductID == product.ID {
+		if cart.Items[i].ProductID == product.ID && sameVariant(cart.Items[i].VariantID, req.VariantID) {
 			cart.Items[i].Quantity += req.Quantity
 			cart.Items[i].TotalPrice = cart.Items[i].UnitPrice * float64(cart.Items[i].Quantity)
 
@@ -297,9 +310,10 @@ func (s *cartServiceImpl) GuestAddCartItem(ctx context.Context, guestID string,
 	if !found {
 		newItem := types.CartItemData{
 			ProductID:  product.ID,
+			VariantID:  req.VariantID,
 			Quantity:   req.Quantity,
-			UnitPrice:  product.Price,
-			TotalPrice: product.Price * float64(req.Quantity),
+			UnitPrice:  unitPrice,
+			TotalPrice: unitPrice * float64(req.Quantity),
 		}
 		cart.Items = append(cart.Items, newItem)
 
@@ -329,7 +343,12 @@ func (s *cartServiceImpl) GuestAddCartItem(ctx context.Context, guestID string,
 		productMap[p.ID] = p
 	}
 
-	return toGuestCartResponse(cart, productMap), nil
+	variantMap, err := s.findGuestCartVariants(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGuestCartResponse(cart, productMap, variantMap), nil
 }
 
 func (s *cartServiceImpl) GetGuestCart(ctx context.Context, guestID string) (*response.GuestCartResponse, error) {
@@ -375,10 +394,15 @@ func (s *cartServiceImpl) GetGuestCart(ctx context.Context, guestID string) (*re
 		productMap[p.ID] = p
 	}
 
-	return toGuestCartResponse(cart, productMap), nil
+	variantMap, err := s.findGuestCartVariants(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGuestCartResponse(cart, productMap, variantMap), nil
 }
 
-func (s *cartServiceImpl) GuestUpdateCartItem(ctx context.Context, guestID string, productID int64, quantity uint) (*response.GuestCartResponse, error) {
+func (s *cartServiceImpl) GuestUpdateCartItem(ctx context.Context, guestID string, productID int64, variantID *int64, quantity uint) (*response.GuestCartResponse, error) {
 	cart, err := s.cartRepo.GetGuestCartData(ctx, guestID)
 	if err != nil {
 		return nil, err
@@ -389,7 +413,7 @@ func (s *cartServiceImpl) GuestUpdateCartItem(ctx context.Context, guestID strin
 
 	found := false
 	for i := range cart.Items {
-		if cart.Items[i].ProductID == productID {
+		if cart.Items[i].ProductID == productID && sameVariant(cart.Items[i].VariantID, variantID) {
 			oldQty := cart.Items[i].Quantity
 			oldTotal := cart.Items[i].TotalPrice
 
@@ -434,10 +458,15 @@ func (s *cartServiceImpl) GuestUpdateCartItem(ctx context.Context, guestID strin
 		productMap[p.ID] = p
 	}
 
-	return toGuestCartResponse(cart, productMap), nil
+	variantMap, err := s.findGuestCartVariants(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGuestCartResponse(cart, productMap, variantMap), nil
 }
 
-func (s *cartServiceImpl) GuestDeleteCartItem(ctx context.Context, guestID string, productID int64) (*response.GuestCartResponse, error) {
+func (s *cartServiceImpl) GuestDeleteCartItem(ctx context.Context, guestID string, productID int64, variantID *int64) (*response.GuestCartResponse, error) {
 	cart, err := s.cartRepo.GetGuestCartData(ctx, guestID)
 	if err != nil {
 		return nil, err
@@ -449,7 +478,7 @@ func (s *cartServiceImpl) GuestDeleteCartItem(ctx context.Context, guestID strin
 	found := false
 	newItems := make([]types.CartItemData, 0, len(cart.Items))
 	for _, item := range cart.Items {
-		if item.ProductID == productID {
+		if item.ProductID == productID && sameVariant(item.VariantID, variantID) {
 			cart.TotalQuantity -= item.Quantity
 			cart.TotalPrice -= item.TotalPrice
 
@@ -487,10 +516,72 @@ func (s *cartServiceImpl) GuestDeleteCartItem(ctx context.Context, guestID strin
 		productMap[p.ID] = p
 	}
 
-	return toGuestCartResponse(cart, productMap), nil
+	variantMap, err := s.findGuestCartVariants(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGuestCartResponse(cart, productMap, variantMap), nil
 }
 
-func toGuestCartResponse(cart *types.CartData, productMap map[int64]*model.Product) *response.GuestCartResponse {
+func (s *cartServiceImpl) resolveUnitPrice(ctx context.Context, product *model.Product, variantID *int64) (float64, error) {
+	if variantID == nil {
+		count, err := s.variantRepo.CountByProductID(ctx, product.ID)
+		if err != nil {
+			return 0, fmt.Errorf("kiểm tra biến thể sản phẩm thất bại: %w", err)
+		}
+		if count > 0 {
+			return 0, customErr.ErrVariantRequired
+		}
+
+		return product.Price, nil
+	}
+
+	variant, err := s.variantRepo.FindByID(ctx, *variantID)
+	if err != nil {
+		return 0, fmt.Errorf("lấy thông tin biến thể sản phẩm thất bại: %w", err)
+	}
+	if variant == nil || variant.ProductID != product.ID || !variant.IsActive {
+		return 0, customErr.ErrVariantNotFound
+	}
+
+	return variant.EffectivePrice(product.Price), nil
+}
+
+func (s *cartServiceImpl) findGuestCartVariants(ctx context.Context, cart *types.CartData) (map[int64]*model.ProductVariant, error) {
+	variantIDs := make([]int64, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		if item.VariantID != nil {
+			variantIDs = append(variantIDs, *item.VariantID)
+		}
+	}
+
+	variantMap := make(map[int64]*model.ProductVariant, len(variantIDs))
+	if len(variantIDs) == 0 {
+		return variantMap, nil
+	}
+
+	variants, err := s.variantRepo.FindAllByIDWithOptionValues(ctx, variantIDs)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin biến thể sản phẩm trong giỏ hàng thất bại: %w", err)
+	}
+
+	for _, v := range variants {
+		variantMap[v.ID] = v
+	}
+
+	return variantMap, nil
+}
+
+func sameVariant(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return *a == *b
+}
+
+func toGuestCartResponse(cart *types.CartData, productMap map[int64]*model.Product, variantMap map[int64]*model.ProductVariant) *response.GuestCartResponse {
 	cartItemsResp := make([]*response.GuestCartItemResponse, 0, len(cart.Items))
 	for _, item := range cart.Items {
 		p := productMap[item.ProductID]
@@ -500,11 +591,17 @@ func toGuestCartResponse(cart *types.CartData, productMap map[int64]*model.Produ
 			prodResp = mapper.ToSimpleProductResponse(p)
 		}
 
+		var variantResp *response.BaseVariantResponse
+		if item.VariantID != nil {
+			variantResp = mapper.ToBaseVariantResponse(variantMap[*item.VariantID])
+		}
+
 		cartItemsResp = append(cartItemsResp, &response.GuestCartItemResponse{
 			UnitPrice:  item.UnitPrice,
 			Quantity:   item.Quantity,
 			TotalPrice: item.TotalPrice,
 			Product:    prodResp,
+			Variant:    variantResp,
 		})
 	}
 
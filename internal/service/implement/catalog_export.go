@@ -0,0 +1,421 @@
+package implement
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+const (
+	exportBatchSize        = 500
+	maxSitemapURLs         = 50000
+	exportErrorMarker      = "#error"
+	maxFeedAdditionalImage = 10
+	defaultFeedSiteURL     = "http://localhost:3000"
+	defaultFeedCurrency    = "VND"
+)
+
+var exportCSVHeader = []string{"id", "name", "slug", "category_slug", "category_name", "price", "quantity", "purchased", "stock", "is_active", "description", "image_urls"}
+
+type catalogExportServiceImpl struct {
+	productRepo  repository.ProductRepository
+	categoryRepo repository.CategoryRepository
+	feedRepo     repository.FeedRepository
+	cfg          *config.Config
+}
+
+func NewCatalogExportService(productRepo repository.ProductRepository, categoryRepo repository.CategoryRepository, feedRepo repository.FeedRepository, cfg *config.Config) service.CatalogExportService {
+	return &catalogExportServiceImpl{
+		productRepo,
+		categoryRepo,
+		feedRepo,
+		cfg,
+	}
+}
+
+func (s *catalogExportServiceImpl) ExportProducts(ctx context.Context, query request.ExportProductsQuery, w io.Writer) error {
+	switch query.Format {
+	case common.ExportFormatJSON:
+		return s.exportJSON(ctx, query.ActiveOnly, w)
+	default:
+		return s.exportCSV(ctx, query.ActiveOnly, w)
+	}
+}
+
+func (s *catalogExportServiceImpl) GenerateFeeds(ctx context.Context) (int, error) {
+	siteURL := strings.TrimRight(s.cfg.Feed.SiteURL, "/")
+	if siteURL == "" {
+		siteURL = defaultFeedSiteURL
+	}
+
+	currency := s.cfg.Feed.Currency
+	if currency == "" {
+		currency = defaultFeedCurrency
+	}
+
+	title := s.cfg.Feed.Title
+	if title == "" {
+		title = s.cfg.App.Name
+	}
+
+	var feed bytes.Buffer
+	feed.WriteString(xml.Header)
+	feed.WriteString(`<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0"><channel>`)
+	feed.WriteString("<title>" + escapeXMLText(title) + "</title>")
+	feed.WriteString("<link>" + escapeXMLText(siteURL) + "</link>")
+	feed.WriteString("<description>" + escapeXMLText(s.cfg.Feed.Description) + "</description>")
+	feedEnc := xml.NewEncoder(&feed)
+
+	sitemap := &sitemapBuilder{}
+	if err := sitemap.add(types.SitemapURL{Loc: siteURL + "/"}); err != nil {
+		return 0, fmt.Errorf("tạo sitemap thất bại: %w", err)
+	}
+
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("lấy danh sách danh mục sản phẩm thất bại: %w", err)
+	}
+	for _, category := range categories {
+		if err = sitemap.add(types.SitemapURL{
+			Loc:     siteURL + "/categories/" + category.Slug,
+			LastMod: category.UpdatedAt.Format("2006-01-02"),
+		}); err != nil {
+			return 0, fmt.Errorf("tạo sitemap thất bại: %w", err)
+		}
+	}
+
+	items := 0
+	if err = s.eachExportBatch(ctx, true, func(products []*model.Product) error {
+		for _, product := range products {
+			if err := feedEnc.Encode(toGoogleMerchantItem(product, siteURL, currency)); err != nil {
+				return fmt.Errorf("tạo nguồn dữ liệu Google Merchant thất bại: %w", err)
+			}
+			items++
+
+			if err := sitemap.add(types.SitemapURL{
+				Loc:     siteURL + "/products/" + product.Slug,
+				LastMod: product.UpdatedAt.Format("2006-01-02"),
+			}); err != nil {
+				return fmt.Errorf("tạo sitemap thất bại: %w", err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	if err = feedEnc.Flush(); err != nil {
+		return 0, fmt.Errorf("tạo nguồn dữ liệu Google Merchant thất bại: %w", err)
+	}
+	feed.WriteString("</channel></rss>")
+
+	pages, err := sitemap.finish()
+	if err != nil {
+		return 0, fmt.Errorf("tạo sitemap thất bại: %w", err)
+	}
+
+	if err = s.feedRepo.Save(ctx, common.FeedGoogleMerchant, feed.Bytes()); err != nil {
+		return 0, fmt.Errorf("lưu nguồn dữ liệu Google Merchant thất bại: %w", err)
+	}
+	if err = s.saveSitemap(ctx, siteURL, pages); err != nil {
+		return 0, err
+	}
+
+	return items, nil
+}
+
+func (s *catalogExportServiceImpl) saveSitemap(ctx context.Context, siteURL string, pages [][]byte) error {
+	if len(pages) == 1 {
+		if err := s.feedRepo.Save(ctx, common.FeedSitemap, pages[0]); err != nil {
+			return fmt.Errorf("lưu sitemap thất bại: %w", err)
+		}
+		return nil
+	}
+
+	lastMod := time.Now().Format("2006-01-02")
+
+	var index bytes.Buffer
+	index.WriteString(xml.Header)
+	index.WriteString(`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	indexEnc := xml.NewEncoder(&index)
+	for i, page := range pages {
+		if err := s.feedRepo.Save(ctx, fmt.Sprintf(common.FeedSitemapPage, i+1), page); err != nil {
+			return fmt.Errorf("lưu sitemap thất bại: %w", err)
+		}
+		if err := indexEnc.Encode(types.SitemapIndexEntry{
+			Loc:     fmt.Sprintf("%s/sitemaps/%d.xml", siteURL, i+1),
+			LastMod: lastMod,
+		}); err != nil {
+			return fmt.Errorf("tạo sitemap thất bại: %w", err)
+		}
+	}
+	if err := indexEnc.Flush(); err != nil {
+		return fmt.Errorf("tạo sitemap thất bại: %w", err)
+	}
+	index.WriteString("</sitemapindex>")
+
+	if err := s.feedRepo.Save(ctx, common.FeedSitemap, index.Bytes()); err != nil {
+		return fmt.Errorf("lưu sitemap thất bại: %w", err)
+	}
+
+	return nil
+}
+
+type sitemapBuilder struct {
+	pages [][]byte
+	buf   *bytes.Buffer
+	enc   *xml.Encoder
+	urls  int
+}
+
+func (b *sitemapBuilder) add(url types.SitemapURL) error {
+	if b.buf != nil && b.urls >= maxSitemapURLs {
+		if err := b.closePage(); err != nil {
+			return err
+		}
+	}
+	if b.buf == nil {
+		b.buf = &bytes.Buffer{}
+		b.buf.WriteString(xml.Header)
+		b.buf.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+		b.enc = xml.NewEncoder(b.buf)
+		b.urls = 0
+	}
+
+	if err := b.enc.Encode(url); err != nil {
+		return err
+	}
+	b.urls++
+
+	return nil
+}
+
+func (b *sitemapBuilder) closePage() error {
+	if err := b.enc.Flush(); err != nil {
+		return err
+	}
+	b.buf.WriteString("</urlset>")
+	b.pages = append(b.pages, b.buf.Bytes())
+	b.buf = nil
+
+	return nil
+}
+
+func (b *sitemapBuilder) finish() ([][]byte, error) {
+	if b.buf != nil {
+		if err := b.closePage(); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.pages, nil
+}
+
+func (s *catalogExportServiceImpl) GetFeed(ctx context.Context, name string) ([]byte, error) {
+	if name != common.FeedGoogleMerchant && name != common.FeedSitemap && !strings.HasPrefix(name, common.FeedSitemap+"_") {
+		return nil, customErr.ErrFeedNotFound
+	}
+
+	content, err := s.feedRepo.FindByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("lấy nguồn dữ liệu thất bại: %w", err)
+	}
+	if content == nil {
+		return nil, customErr.ErrFeedNotGenerated
+	}
+
+	return content, nil
+}
+
+func (s *catalogExportServiceImpl) exportCSV(ctx context.Context, activeOnly bool, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportCSVHeader); err != nil {
+		return fmt.Errorf("ghi dữ liệu xuất sản phẩm thất bại: %w", err)
+	}
+
+	if err := s.eachExportBatch(ctx, activeOnly, func(products []*model.Product) error {
+		for _, product := range products {
+			row := toExportProductRow(product)
+			if err := writer.Write([]string{
+				strconv.FormatInt(row.ID, 10),
+				row.Name,
+				row.Slug,
+				row.CategorySlug,
+				row.CategoryName,
+				strconv.FormatFloat(row.Price, 'f', 2, 64),
+				strconv.FormatUint(uint64(row.Quantity), 10),
+				strconv.FormatUint(uint64(row.Purchased), 10),
+				strconv.FormatUint(uint64(row.Stock), 10),
+				strconv.FormatBool(row.IsActive),
+				row.Description,
+				strings.Join(row.ImageURLs, importImageSeparator),
+			}); err != nil {
+				return fmt.Errorf("ghi dữ liệu xuất sản phẩm thất bại: %w", err)
+			}
+		}
+
+		writer.Flush()
+		return writer.Error()
+	}); err != nil {
+		_ = writer.Write([]string{exportErrorMarker, err.Error()})
+		writer.Flush()
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func (s *catalogExportServiceImpl) exportJSON(ctx context.Context, activeOnly bool, w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return fmt.Errorf("ghi dữ liệu xuất sản phẩm thất bại: %w", err)
+	}
+
+	first := true
+	if err := s.eachExportBatch(ctx, activeOnly, func(products []*model.Product) error {
+		for _, product := range products {
+			data, err := json.Marshal(toExportProductRow(product))
+			if err != nil {
+				return fmt.Errorf("mã hóa dữ liệu xuất sản phẩm thất bại: %w", err)
+			}
+
+			if !first {
+				data = append([]byte(","), data...)
+			}
+			first = false
+
+			if _, err = w.Write(data); err != nil {
+				return fmt.Errorf("ghi dữ liệu xuất sản phẩm thất bại: %w", err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		marker, _ := json.Marshal(map[string]string{exportErrorMarker: err.Error()})
+		if !first {
+			marker = append([]byte(","), marker...)
+		}
+		_, _ = w.Write(append(marker, ']'))
+		return err
+	}
+
+	if _, err := io.WriteString(w, "]"); err != nil {
+		return fmt.Errorf("ghi dữ liệu xuất sản phẩm thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func (s *catalogExportServiceImpl) eachExportBatch(ctx context.Context, activeOnly bool, fn func([]*model.Product) error) error {
+	var afterID int64
+	for {
+		products, err := s.productRepo.FindAllForExport(ctx, afterID, exportBatchSize, activeOnly)
+		if err != nil {
+			return fmt.Errorf("lấy danh sách sản phẩm thất bại: %w", err)
+		}
+		if len(products) == 0 {
+			return nil
+		}
+
+		if err = fn(products); err != nil {
+			return err
+		}
+
+		if len(products) < exportBatchSize {
+			return nil
+		}
+		afterID = products[len(products)-1].ID
+	}
+}
+
+func toExportProductRow(product *model.Product) *types.ExportProductRow {
+	row := &types.ExportProductRow{
+		ID:          product.ID,
+		Name:        product.Name,
+		Slug:        product.Slug,
+		Price:       product.Price,
+		IsActive:    product.IsActive,
+		Description: product.Description,
+		ImageURLs:   make([]string, 0, len(product.Images)),
+	}
+	if product.Category != nil {
+		row.CategorySlug = product.Category.Slug
+		row.CategoryName = product.Category.Name
+	}
+	if product.Inventory != nil {
+		row.Quantity = product.Inventory.Quantity
+		row.Purchased = product.Inventory.Purchased
+		row.Stock = product.Inventory.Stock
+	}
+	for _, img := range product.Images {
+		if img.Url != "" {
+			row.ImageURLs = append(row.ImageURLs, img.Url)
+		}
+	}
+
+	return row
+}
+
+func toGoogleMerchantItem(product *model.Product, siteURL, currency string) *types.GoogleMerchantItem {
+	row := toExportProductRow(product)
+
+	availability := "out_of_stock"
+	if row.Stock > 0 {
+		availability = "in_stock"
+	}
+
+	item := &types.GoogleMerchantItem{
+		ID:               strconv.FormatInt(row.ID, 10),
+		Title:            truncateRunes(row.Name, 150),
+		Description:      truncateRunes(row.Description, 5000),
+		Link:             siteURL + "/products/" + row.Slug,
+		Availability:     availability,
+		Price:            strconv.FormatFloat(row.Price, 'f', 2, 64) + " " + currency,
+		ProductType:      row.CategoryName,
+		Condition:        "new",
+		IdentifierExists: "no",
+	}
+	if len(row.ImageURLs) > 0 {
+		item.ImageLink = row.ImageURLs[0]
+		additional := row.ImageURLs[1:]
+		if len(additional) > maxFeedAdditionalImage {
+			additional = additional[:maxFeedAdditionalImage]
+		}
+		item.AdditionalImageLinks = additional
+	}
+
+	return item
+}
+
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+
+	return string(runes[:max])
+}
+
+func escapeXMLText(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+
+	return buf.String()
+}
@@ -2,9 +2,13 @@ package implement
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/rabbitmq/amqp091-go"
 	"github.com/tienhai2808/ecom_go/internal/common"
 	customErr "github.com/tienhai2808/ecom_go/internal/errors"
 	"github.com/tienhai2808/ecom_go/internal/model"
@@ -12,16 +16,28 @@ import (
 	"github.com/tienhai2808/ecom_go/internal/request"
 	"github.com/tienhai2808/ecom_go/internal/service"
 	"github.com/tienhai2808/ecom_go/internal/snowflake"
+	"github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
 )
 
 type categoryServiceImpl struct {
-	categoryRepo repository.CategoryRepository
-	sfg          snowflake.SnowflakeGenerator
+	categoryRepo   repository.CategoryRepository
+	attributeRepo  repository.AttributeRepository
+	suggestionRepo repository.SuggestionRepository
+	mediaRepo      repository.MediaRepository
+	db             *gorm.DB
+	rabbitChan     *amqp091.Channel
+	sfg            snowflake.SnowflakeGenerator
 }
 
-func NewCategoryService(categoryRepo repository.CategoryRepository, sfg snowflake.SnowflakeGenerator) service.CategoryService {
+func NewCategoryService(categoryRepo repository.CategoryRepository, attributeRepo repository.AttributeRepository, suggestionRepo repository.SuggestionRepository, mediaRepo repository.MediaRepository, db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator) service.CategoryService {
 	return &categoryServiceImpl{
 		categoryRepo,
+		attributeRepo,
+		suggestionRepo,
+		mediaRepo,
+		db,
+		rabbitChan,
 		sfg,
 	}
 }
@@ -29,14 +45,31 @@ func NewCategoryService(categoryRepo repository.CategoryRepository, sfg snowflak
 func (s *categoryServiceImpl) CreateCategory(ctx context.Context, req request.CreateCategoryRequest) (*model.Category, error) {
 	slug := common.GenerateSlug(req.Name)
 
+	var parent *model.Category
+	if req.ParentID != nil {
+		p, err := s.categoryRepo.FindByID(ctx, *req.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("lấy thông tin danh mục cha thất bại: %w", err)
+		}
+		if p == nil {
+			return nil, customErr.ErrCategoryParentNotFound
+		}
+		parent = p
+	}
+
 	categoryID, err := s.sfg.NextID()
 	if err != nil {
 		return nil, err
 	}
 	category := &model.Category{
-		ID:   categoryID,
-		Name: req.Name,
-		Slug: slug,
+		ID:       categoryID,
+		Name:     req.Name,
+		Slug:     slug,
+		Path:     model.CategoryPath(parent, categoryID),
+		ParentID: req.ParentID,
+	}
+	if parent != nil {
+		category.Depth = parent.Depth + 1
 	}
 	if err := s.categoryRepo.Create(ctx, category); err != nil {
 		if common.IsUniqueViolation(err) {
@@ -45,16 +78,98 @@ func (s *categoryServiceImpl) CreateCategory(ctx context.Context, req request.Cr
 		return nil, fmt.Errorf("tạo danh mục sản phẩm thất bại: %w", err)
 	}
 
+	indexSuggestion(s.suggestionRepo, toCategorySuggestionDocument(category))
+
 	return category, nil
 }
 
-func (s *categoryServiceImpl) GetAllCategories(ctx context.Context) ([]*model.Category, error) {
+func (s *categoryServiceImpl) GetCategoryTree(ctx context.Context) ([]*model.Category, error) {
 	categories, err := s.categoryRepo.FindAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("lấy danh sách danh mục sản phẩm thất bại: %w", err)
 	}
 
-	return categories, nil
+	categoryMap := make(map[int64]*model.Category, len(categories))
+	for _, category := range categories {
+		category.Children = []*model.Category{}
+		categoryMap[category.ID] = category
+	}
+
+	roots := []*model.Category{}
+	for _, category := range categories {
+		if category.ParentID != nil {
+			if parent, ok := categoryMap[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, category)
+				continue
+			}
+		}
+		roots = append(roots, category)
+	}
+
+	return roots, nil
+}
+
+func (s *categoryServiceImpl) MoveCategory(ctx context.Context, id int64, req request.MoveCategoryRequest) (*model.Category, error) {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		category, err := s.categoryRepo.FindByIDTx(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("lấy thông tin danh mục sản phẩm thất bại: %w", err)
+		}
+		if category == nil {
+			return customErr.ErrCategoryNotFound
+		}
+
+		var parent *model.Category
+		if req.ParentID != nil {
+			parent, err = s.categoryRepo.FindByIDTx(ctx, tx, *req.ParentID)
+			if err != nil {
+				return fmt.Errorf("lấy thông tin danh mục cha thất bại: %w", err)
+			}
+			if parent == nil {
+				return customErr.ErrCategoryParentNotFound
+			}
+			if strings.HasPrefix(parent.Path, category.Path) {
+				return customErr.ErrInvalidCategoryParent
+			}
+		}
+
+		return s.moveCategoryTx(ctx, tx, category, parent)
+	}); err != nil {
+		return nil, err
+	}
+
+	category, err := s.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin danh mục sản phẩm thất bại: %w", err)
+	}
+	if category == nil {
+		return nil, customErr.ErrCategoryNotFound
+	}
+
+	return category, nil
+}
+
+func (s *categoryServiceImpl) moveCategoryTx(ctx context.Context, tx *gorm.DB, category, parent *model.Category) error {
+	var parentID *int64
+	depth := 0
+	if parent != nil {
+		parentID = &parent.ID
+		depth = parent.Depth + 1
+	}
+
+	if err := s.categoryRepo.UpdateTx(ctx, tx, category.ID, map[string]any{"parent_id": parentID}); err != nil {
+		if errors.Is(err, customErr.ErrCategoryNotFound) {
+			return err
+		}
+		return fmt.Errorf("cập nhật danh mục cha thất bại: %w", err)
+	}
+
+	newPath := model.CategoryPath(parent, category.ID)
+	if err := s.categoryRepo.UpdateSubtreePathTx(ctx, tx, category.Path, newPath, depth-category.Depth); err != nil {
+		return fmt.Errorf("cập nhật đường dẫn danh mục sản phẩm thất bại: %w", err)
+	}
+
+	return nil
 }
 
 func (s *categoryServiceImpl) UpdateCategory(ctx context.Context, id int64, req request.UpdateCategoryRequest) (*model.Category, error) {
@@ -71,6 +186,24 @@ func (s *categoryServiceImpl) UpdateCategory(ctx context.Context, id int64, req
 		"slug": common.GenerateSlug(req.Name),
 	}
 
+	if req.ImageAssetID != nil {
+		if *req.ImageAssetID == 0 {
+			updateData["image_asset_id"] = nil
+		} else {
+			asset, err := s.mediaRepo.FindByID(ctx, *req.ImageAssetID)
+			if err != nil {
+				return nil, fmt.Errorf("lấy thông tin tài nguyên thư viện ảnh thất bại: %w", err)
+			}
+			if asset == nil {
+				return nil, customErr.ErrMediaAssetNotFound
+			}
+			if asset.Status != common.ImageStatusReady {
+				return nil, customErr.ErrMediaAssetNotReady
+			}
+			updateData["image_asset_id"] = asset.ID
+		}
+	}
+
 	if err = s.categoryRepo.Update(ctx, id, updateData); err != nil {
 		if common.IsUniqueViolation(err) {
 			return nil, customErr.ErrCategorySlugAlreadyExists
@@ -89,6 +222,9 @@ func (s *categoryServiceImpl) UpdateCategory(ctx context.Context, id int64, req
 		return nil, customErr.ErrCategoryNotFound
 	}
 
+	indexSuggestion(s.suggestionRepo, toCategorySuggestionDocument(category))
+	publishProductIndex(s.rabbitChan, types.ProductIndexMessage{CategoryIDs: []int64{id}})
+
 	return category, nil
 }
 
@@ -101,6 +237,14 @@ func (s *categoryServiceImpl) DeleteCategory(ctx context.Context, id int64) erro
 		return customErr.ErrCategoryNotFound
 	}
 
+	children, err := s.categoryRepo.CountChildrenOutside(ctx, []int64{id})
+	if err != nil {
+		return fmt.Errorf("kiểm tra danh mục con thất bại: %w", err)
+	}
+	if children > 0 {
+		return customErr.ErrCategoryHasChildren
+	}
+
 	if err = s.categoryRepo.Delete(ctx, id); err != nil {
 		if errors.Is(err, customErr.ErrCategoryNotFound) {
 			return err
@@ -108,6 +252,8 @@ func (s *categoryServiceImpl) DeleteCategory(ctx context.Context, id int64) erro
 		return fmt.Errorf("xóa danh mục sản phẩm thất bại: %w", err)
 	}
 
+	deleteSuggestions(s.suggestionRepo, common.SuggestionTypeCategory, []int64{id})
+
 	return nil
 }
 
@@ -120,10 +266,260 @@ func (s *categoryServiceImpl) DeleteCategories(ctx context.Context, req request.
 		return 0, customErr.ErrHasCategoryNotFound
 	}
 
+	children, err := s.categoryRepo.CountChildrenOutside(ctx, req.IDs)
+	if err != nil {
+		return 0, fmt.Errorf("kiểm tra danh mục con thất bại: %w", err)
+	}
+	if children > 0 {
+		return 0, customErr.ErrCategoryHasChildren
+	}
+
 	rowsAccepted, err := s.categoryRepo.DeleteAllByID(ctx, req.IDs)
 	if err != nil {
 		return 0, fmt.Errorf("xóa danh sách danh mục sản phẩm thất bại: %w", err)
 	}
 
+	deleteSuggestions(s.suggestionRepo, common.SuggestionTypeCategory, req.IDs)
+
 	return rowsAccepted, nil
 }
+
+func (s *categoryServiceImpl) GetDeletedCategories(ctx context.Context) ([]*model.Category, error) {
+	categories, err := s.categoryRepo.FindAllDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách danh mục sản phẩm đã xóa thất bại: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (s *categoryServiceImpl) RestoreCategory(ctx context.Context, id int64) (*model.Category, error) {
+	if err := s.categoryRepo.Restore(ctx, id); err != nil {
+		if errors.Is(err, customErr.ErrCategoryNotFound) {
+			return nil, err
+		}
+		if common.IsUniqueViolation(err) {
+			return nil, customErr.ErrCategorySlugAlreadyExists
+		}
+		return nil, fmt.Errorf("khôi phục danh mục sản phẩm thất bại: %w", err)
+	}
+
+	category, err := s.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin danh mục sản phẩm thất bại: %w", err)
+	}
+	if category == nil {
+		return nil, customErr.ErrCategoryNotFound
+	}
+
+	indexSuggestion(s.suggestionRepo, toCategorySuggestionDocument(category))
+
+	if category.ParentID == nil {
+		return category, nil
+	}
+
+	parent, err := s.categoryRepo.FindByID(ctx, *category.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin danh mục cha thất bại: %w", err)
+	}
+	if parent != nil {
+		return category, nil
+	}
+
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.moveCategoryTx(ctx, tx, category, nil)
+	}); err != nil {
+		return nil, err
+	}
+
+	category, err = s.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin danh mục sản phẩm thất bại: %w", err)
+	}
+	if category == nil {
+		return nil, customErr.ErrCategoryNotFound
+	}
+
+	return category, nil
+}
+
+func (s *categoryServiceImpl) PurgeDeletedCategories(ctx context.Context, before time.Time) (int64, error) {
+	categories, err := s.categoryRepo.FindAllDeletedBefore(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("lấy danh sách danh mục sản phẩm hết hạn lưu trữ thất bại: %w", err)
+	}
+	if len(categories) == 0 {
+		return 0, nil
+	}
+
+	categoryIDs := make([]int64, 0, len(categories))
+	for _, category := range categories {
+		categoryIDs = append(categoryIDs, category.ID)
+	}
+
+	rowsAccepted, err := s.categoryRepo.PurgeAllByID(ctx, categoryIDs)
+	if err != nil {
+		return 0, fmt.Errorf("xóa vĩnh viễn danh mục sản phẩm thất bại: %w", err)
+	}
+
+	return rowsAccepted, nil
+}
+
+func (s *categoryServiceImpl) GetCategoryAttributes(ctx context.Context, categoryID int64) ([]*model.CategoryAttribute, error) {
+	category, err := s.categoryRepo.FindByID(ctx, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin danh mục sản phẩm thất bại: %w", err)
+	}
+	if category == nil {
+		return nil, customErr.ErrCategoryNotFound
+	}
+
+	attributes, err := s.attributeRepo.FindAllByCategoryID(ctx, category.ID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách thuộc tính của danh mục sản phẩm thất bại: %w", err)
+	}
+
+	return attributes, nil
+}
+
+func (s *categoryServiceImpl) CreateCategoryAttribute(ctx context.Context, categoryID int64, req request.CreateCategoryAttributeRequest) (*model.CategoryAttribute, error) {
+	category, err := s.categoryRepo.FindByID(ctx, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin danh mục sản phẩm thất bại: %w", err)
+	}
+	if category == nil {
+		return nil, customErr.ErrCategoryNotFound
+	}
+
+	var options []string
+	if req.Type == common.AttributeTypeEnum {
+		options = normalizeAttributeOptions(req.Options)
+		if len(options) == 0 {
+			return nil, customErr.ErrAttributeOptionsRequired
+		}
+	}
+
+	var unit *string
+	if req.Type == common.AttributeTypeNumber && req.Unit != nil && strings.TrimSpace(*req.Unit) != "" {
+		trimmed := strings.TrimSpace(*req.Unit)
+		unit = &trimmed
+	}
+
+	attributeID, err := s.sfg.NextID()
+	if err != nil {
+		return nil, err
+	}
+
+	attribute := &model.CategoryAttribute{
+		ID:         attributeID,
+		Name:       strings.TrimSpace(req.Name),
+		Type:       req.Type,
+		Unit:       unit,
+		Options:    options,
+		IsRequired: req.IsRequired != nil && *req.IsRequired,
+		Position:   req.Position,
+		CategoryID: category.ID,
+	}
+	if err = s.attributeRepo.Create(ctx, attribute); err != nil {
+		if common.IsUniqueViolation(err) {
+			return nil, customErr.ErrAttributeNameAlreadyExists
+		}
+		return nil, fmt.Errorf("tạo thuộc tính của danh mục sản phẩm thất bại: %w", err)
+	}
+
+	return attribute, nil
+}
+
+func (s *categoryServiceImpl) UpdateCategoryAttribute(ctx context.Context, categoryID, attributeID int64, req request.UpdateCategoryAttributeRequest) (*model.CategoryAttribute, error) {
+	attribute, err := s.attributeRepo.FindByID(ctx, attributeID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin thuộc tính của danh mục sản phẩm thất bại: %w", err)
+	}
+	if attribute == nil || attribute.CategoryID != categoryID {
+		return nil, customErr.ErrAttributeNotFound
+	}
+
+	updateData := map[string]any{}
+	if req.Name != nil && strings.TrimSpace(*req.Name) != attribute.Name {
+		updateData["name"] = strings.TrimSpace(*req.Name)
+	}
+	if req.Unit != nil && attribute.Type == common.AttributeTypeNumber {
+		updateData["unit"] = strings.TrimSpace(*req.Unit)
+	}
+	if req.Options != nil && attribute.Type == common.AttributeTypeEnum {
+		options := normalizeAttributeOptions(req.Options)
+		if len(options) == 0 {
+			return nil, customErr.ErrAttributeOptionsRequired
+		}
+
+		optionsJSON, err := json.Marshal(options)
+		if err != nil {
+			return nil, err
+		}
+		updateData["options"] = string(optionsJSON)
+	}
+	if req.IsRequired != nil && *req.IsRequired != attribute.IsRequired {
+		updateData["is_required"] = *req.IsRequired
+	}
+	if req.Position != nil && *req.Position != attribute.Position {
+		updateData["position"] = *req.Position
+	}
+
+	if len(updateData) > 0 {
+		if err = s.attributeRepo.Update(ctx, attribute.ID, updateData); err != nil {
+			if common.IsUniqueViolation(err) {
+				return nil, customErr.ErrAttributeNameAlreadyExists
+			}
+			if errors.Is(err, customErr.ErrAttributeNotFound) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("cập nhật thuộc tính của danh mục sản phẩm thất bại: %w", err)
+		}
+	}
+
+	attribute, err = s.attributeRepo.FindByID(ctx, attribute.ID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin thuộc tính của danh mục sản phẩm thất bại: %w", err)
+	}
+	if attribute == nil {
+		return nil, customErr.ErrAttributeNotFound
+	}
+
+	return attribute, nil
+}
+
+func (s *categoryServiceImpl) DeleteCategoryAttribute(ctx context.Context, categoryID, attributeID int64) error {
+	attribute, err := s.attributeRepo.FindByID(ctx, attributeID)
+	if err != nil {
+		return fmt.Errorf("lấy thông tin thuộc tính của danh mục sản phẩm thất bại: %w", err)
+	}
+	if attribute == nil || attribute.CategoryID != categoryID {
+		return customErr.ErrAttributeNotFound
+	}
+
+	if err = s.attributeRepo.Delete(ctx, attribute.ID); err != nil {
+		if errors.Is(err, customErr.ErrAttributeNotFound) {
+			return err
+		}
+		return fmt.Errorf("xóa thuộc tính của danh mục sản phẩm thất bại: %w", err)
+	}
+
+	publishProductIndex(s.rabbitChan, types.ProductIndexMessage{CategoryIDs: []int64{categoryID}})
+
+	return nil
+}
+
+func normalizeAttributeOptions(values []string) []string {
+	options := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[strings.ToLower(value)] {
+			continue
+		}
+		seen[strings.ToLower(value)] = true
+		options = append(options, value)
+	}
+
+	return options
+}
@@ -0,0 +1,209 @@
+package implement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/rabbitmq"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/response"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/snowflake"
+	"github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
+)
+
+type mediaServiceImpl struct {
+	mediaRepo  repository.MediaRepository
+	uploadRepo repository.UploadRepository
+	db         *gorm.DB
+	rabbitChan *amqp091.Channel
+	sfg        snowflake.SnowflakeGenerator
+}
+
+func NewMediaService(mediaRepo repository.MediaRepository, uploadRepo repository.UploadRepository, db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator) service.MediaService {
+	return &mediaServiceImpl{
+		mediaRepo,
+		uploadRepo,
+		db,
+		rabbitChan,
+		sfg,
+	}
+}
+
+func (s *mediaServiceImpl) GetMediaAssets(ctx context.Context, query request.MediaPaginationQuery) ([]*model.MediaAsset, *response.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+	query.Search = strings.TrimSpace(query.Search)
+	query.Tags = normalizeMediaTags(query.Tags)
+
+	assets, total, err := s.mediaRepo.FindAll(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lấy danh sách thư viện ảnh thất bại: %w", err)
+	}
+
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	meta := &response.MetaResponse{
+		Total:      total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: totalPages,
+		HasPrev:    query.Page > 1,
+		HasNext:    int64(query.Page) < totalPages,
+	}
+
+	return assets, meta, nil
+}
+
+func (s *mediaServiceImpl) GetMediaAssetByID(ctx context.Context, id int64) (*model.MediaAsset, error) {
+	asset, err := s.mediaRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin tài nguyên thư viện ảnh thất bại: %w", err)
+	}
+	if asset == nil {
+		return nil, customErr.ErrMediaAssetNotFound
+	}
+
+	return asset, nil
+}
+
+func (s *mediaServiceImpl) CreateMediaAsset(ctx context.Context, userID int64, req request.CreateMediaAssetRequest) (*model.MediaAsset, error) {
+	assetID, err := s.sfg.NextID()
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.TrimSpace(req.Key)
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := attachUploadsTx(ctx, tx, s.uploadRepo, []string{key}, userID); err != nil {
+			return err
+		}
+
+		slots, err := s.uploadRepo.FindAllByKeysTx(ctx, tx, []string{key})
+		if err != nil {
+			return fmt.Errorf("lấy thông tin file tải lên thất bại: %w", err)
+		}
+		if len(slots) == 0 {
+			return customErr.ErrUploadNotReady
+		}
+
+		asset := &model.MediaAsset{
+			ID:          assetID,
+			Key:         key,
+			FileName:    slots[0].FileName,
+			ContentType: slots[0].ContentType,
+			Status:      common.ImageStatusPending,
+			UserID:      userID,
+		}
+		if err := s.mediaRepo.CreateTx(ctx, tx, asset); err != nil {
+			return fmt.Errorf("tạo tài nguyên thư viện ảnh thất bại: %w", err)
+		}
+
+		if err := s.mediaRepo.ReplaceTagsTx(ctx, tx, assetID, normalizeMediaTags(req.Tags)); err != nil {
+			return fmt.Errorf("cập nhật thẻ tài nguyên thư viện ảnh thất bại: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		body, _ := json.Marshal(types.UploadImageMessage{ImageID: assetID, Key: key})
+		if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyMediaUpload, body); err != nil {
+			log.Printf("đẩy tin nhắn xử lý ảnh thư viện thất bại: %v", err)
+		}
+	}()
+
+	return s.GetMediaAssetByID(ctx, assetID)
+}
+
+func (s *mediaServiceImpl) UpdateMediaAsset(ctx context.Context, id int64, req request.UpdateMediaAssetRequest) (*model.MediaAsset, error) {
+	if _, err := s.GetMediaAssetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if req.FileName != nil {
+			if err := s.mediaRepo.UpdateTx(ctx, tx, id, map[string]any{"file_name": strings.TrimSpace(*req.FileName)}); err != nil {
+				return fmt.Errorf("cập nhật tài nguyên thư viện ảnh thất bại: %w", err)
+			}
+		}
+
+		if req.Tags != nil {
+			if err := s.mediaRepo.ReplaceTagsTx(ctx, tx, id, normalizeMediaTags(req.Tags)); err != nil {
+				return fmt.Errorf("cập nhật thẻ tài nguyên thư viện ảnh thất bại: %w", err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.GetMediaAssetByID(ctx, id)
+}
+
+func (s *mediaServiceImpl) DeleteMediaAsset(ctx context.Context, id int64) error {
+	asset, err := s.GetMediaAssetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		usages, err := s.mediaRepo.CountUsagesTx(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("kiểm tra tài nguyên thư viện ảnh đang sử dụng thất bại: %w", err)
+		}
+		if usages > 0 {
+			return customErr.ErrMediaAssetInUse
+		}
+
+		if err := s.mediaRepo.DeleteTx(ctx, tx, id); err != nil {
+			if errors.Is(err, customErr.ErrMediaAssetNotFound) {
+				return err
+			}
+			return fmt.Errorf("xóa tài nguyên thư viện ảnh thất bại: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go func() {
+		if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyImageDelete, []byte(asset.Key)); err != nil {
+			log.Printf("đẩy tin nhắn xóa ảnh thất bại: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+func normalizeMediaTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
@@ -0,0 +1,93 @@
+package implement
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+)
+
+type permissionServiceImpl struct {
+	permissionRepo repository.PermissionRepository
+}
+
+func NewPermissionService(permissionRepo repository.PermissionRepository) service.PermissionService {
+	return &permissionServiceImpl{permissionRepo}
+}
+
+func (s *permissionServiceImpl) GetAllRolePermissions(ctx context.Context) ([]*model.RolePermission, error) {
+	rolePermissions, err := s.permissionRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách quyền hạn thất bại: %w", err)
+	}
+
+	return rolePermissions, nil
+}
+
+func (s *permissionServiceImpl) GetRolePermissions(ctx context.Context, role string) ([]*model.RolePermission, error) {
+	if !slices.Contains(common.AllRoles, role) {
+		return nil, customErr.ErrInvalidRole
+	}
+
+	rolePermissions, err := s.permissionRepo.FindAllByRole(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách quyền hạn thất bại: %w", err)
+	}
+
+	return rolePermissions, nil
+}
+
+func (s *permissionServiceImpl) GrantPermissions(ctx context.Context, role string, req request.RolePermissionsRequest) ([]*model.RolePermission, error) {
+	if err := validateRolePermissions(role, req.Permissions); err != nil {
+		return nil, err
+	}
+
+	rolePermissions := make([]*model.RolePermission, 0, len(req.Permissions))
+	for _, permission := range req.Permissions {
+		rolePermissions = append(rolePermissions, &model.RolePermission{
+			Role:       role,
+			Permission: permission,
+		})
+	}
+
+	if err := s.permissionRepo.CreateAll(ctx, rolePermissions); err != nil {
+		return nil, fmt.Errorf("cấp quyền hạn thất bại: %w", err)
+	}
+
+	return s.GetRolePermissions(ctx, role)
+}
+
+func (s *permissionServiceImpl) RevokePermissions(ctx context.Context, role string, req request.RolePermissionsRequest) ([]*model.RolePermission, error) {
+	if err := validateRolePermissions(role, req.Permissions); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.permissionRepo.DeleteAllByRoleAndPermissions(ctx, role, req.Permissions); err != nil {
+		return nil, fmt.Errorf("thu hồi quyền hạn thất bại: %w", err)
+	}
+
+	return s.GetRolePermissions(ctx, role)
+}
+
+func validateRolePermissions(role string, permissions []string) error {
+	if !slices.Contains(common.AllRoles, role) {
+		return customErr.ErrInvalidRole
+	}
+	if role == common.RoleAdmin {
+		return customErr.ErrAdminRoleImmutable
+	}
+
+	for _, permission := range permissions {
+		if !slices.Contains(common.AllPermissions, permission) {
+			return customErr.ErrInvalidPermission
+		}
+	}
+
+	return nil
+}
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/rabbitmq/amqp091-go"
 	"github.com/tienhai2808/ecom_go/internal/common"
@@ -23,36 +25,89 @@ import (
 )
 
 type productServiceImpl struct {
-	productRepo   repository.ProductRepository
-	categoryRepo  repository.CategoryRepository
-	inventoryRepo repository.InventoryRepository
-	imageRepo     repository.ImageRepository
-	db            *gorm.DB
-	rabbitChan    *amqp091.Channel
-	sfg           snowflake.SnowflakeGenerator
+	productRepo    repository.ProductRepository
+	searcher       repository.ProductSearcher
+	categoryRepo   repository.CategoryRepository
+	inventoryRepo  repository.InventoryRepository
+	imageRepo      repository.ImageRepository
+	variantRepo    repository.VariantRepository
+	attributeRepo  repository.AttributeRepository
+	suggestionRepo repository.SuggestionRepository
+	uploadRepo     repository.UploadRepository
+	mediaRepo      repository.MediaRepository
+	historyRepo    repository.ProductHistoryRepository
+	db             *gorm.DB
+	rabbitChan     *amqp091.Channel
+	sfg            snowflake.SnowflakeGenerator
 }
 
-func NewProductService(productRepo repository.ProductRepository, categoryRepo repository.CategoryRepository, inventoryRepo repository.InventoryRepository, imageRepo repository.ImageRepository, db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator) service.ProductService {
+func NewProductService(productRepo repository.ProductRepository, searcher repository.ProductSearcher, categoryRepo repository.CategoryRepository, inventoryRepo repository.InventoryRepository, imageRepo repository.ImageRepository, variantRepo repository.VariantRepository, attributeRepo repository.AttributeRepository, suggestionRepo repository.SuggestionRepository, uploadRepo repository.UploadRepository, mediaRepo repository.MediaRepository, historyRepo repository.ProductHistoryRepository, db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator) service.ProductService {
 	return &productServiceImpl{
 		productRepo,
+		searcher,
 		categoryRepo,
 		inventoryRepo,
 		imageRepo,
+		variantRepo,
+		attributeRepo,
+		suggestionRepo,
+		uploadRepo,
+		mediaRepo,
+		historyRepo,
 		db,
 		rabbitChan,
 		sfg,
 	}
 }
 
-func (s *productServiceImpl) GetAllProducts(ctx context.Context, query request.ProductPaginationQuery) ([]*model.Product, *response.MetaResponse, error) {
-	result, err := s.productRepo.Search(ctx, query)
+func (s *productServiceImpl) GetAllProducts(ctx context.Context, query request.ProductPaginationQuery) ([]*model.Product, *response.MetaResponse, *response.ProductFacetsResponse, error) {
+	if query.MinPrice != nil && query.MaxPrice != nil && *query.MinPrice > *query.MaxPrice {
+		return nil, nil, nil, customErr.ErrInvalidPriceRange
+	}
+
+	categoryIDs := query.CategoryIDs
+	if query.CategoryID != 0 {
+		categoryIDs = append(categoryIDs, query.CategoryID)
+	}
+	if len(categoryIDs) > 0 {
+		filterCategoryIDs, err := s.findDescendantCategoryIDs(ctx, categoryIDs)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		query.FilterCategoryIDs = filterCategoryIDs
+	}
+
+	if len(query.Attributes) > 0 {
+		attributes, err := s.normalizeAttributeFilters(ctx, query.Attributes)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		query.Attributes = attributes
+	}
+
+	result, err := s.searcher.Search(ctx, query)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	products, err := s.productRepo.FindAllByIDWithImages(ctx, result.IDs)
+	loaded, err := s.productRepo.FindAllByIDWithImages(ctx, result.IDs)
 	if err != nil {
-		return nil, nil, fmt.Errorf("lấy thông tin danh sách sản phẩm thất bại: %w", err)
+		return nil, nil, nil, fmt.Errorf("lấy thông tin danh sách sản phẩm thất bại: %w", err)
+	}
+
+	productMap := make(map[int64]*model.Product, len(loaded))
+	for _, product := range loaded {
+		productMap[product.ID] = product
+	}
+
+	products := make([]*model.Product, 0, len(result.IDs))
+	for _, id := range result.IDs {
+		product, ok := productMap[id]
+		if !ok {
+			continue
+		}
+		products = append(products, product)
+		delete(productMap, id)
 	}
 
 	meta := &response.MetaResponse{
@@ -64,7 +119,23 @@ func (s *productServiceImpl) GetAllProducts(ctx context.Context, query request.P
 		HasNext:    result.HasNext,
 	}
 
-	return products, meta, nil
+	if result.Total == 0 && strings.TrimSpace(query.Search) != "" {
+		didYouMean, err := s.suggestionRepo.DidYouMean(ctx, query.Search)
+		if err != nil {
+			if !errors.Is(err, customErr.ErrSearchUnavailable) {
+				log.Printf("lấy từ khóa gợi ý thất bại: %v", err)
+			}
+		} else if didYouMean != "" && !strings.EqualFold(didYouMean, query.Search) {
+			meta.DidYouMean = &didYouMean
+		}
+	}
+
+	facets, err := s.buildProductFacets(ctx, result)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return products, meta, facets, nil
 }
 
 func (s *productServiceImpl) GetProductByID(ctx context.Context, id int64) (*model.Product, error) {
@@ -77,10 +148,35 @@ func (s *productServiceImpl) GetProductByID(ctx context.Context, id int64) (*mod
 		return nil, customErr.ErrProductNotFound
 	}
 
+	if err = s.loadCategoryAncestors(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (s *productServiceImpl) GetProductBySlug(ctx context.Context, slug string) (*model.Product, error) {
+	product, err := s.productRepo.FindActiveBySlugWithDetails(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+	}
+	if product == nil {
+		return nil, customErr.ErrProductNotFound
+	}
+
+	if err = s.loadCategoryAncestors(ctx, product); err != nil {
+		return nil, err
+	}
+
 	return product, nil
 }
 
-func (s *productServiceImpl) CreateProduct(ctx context.Context, req *request.CreateProductForm) (*model.Product, error) {
+func (s *productServiceImpl) CreateProduct(ctx context.Context, actor *types.UserData, req *request.CreateProductForm) (*model.Product, error) {
+	publishing, err := resolveProductPublishing(nil, req.Status, req.IsActive, req.PublishAt, req.UnpublishAt)
+	if err != nil {
+		return nil, err
+	}
+
 	productID, err := s.sfg.NextID()
 	if err != nil {
 		return nil, err
@@ -98,6 +194,16 @@ func (s *productServiceImpl) CreateProduct(ctx context.Context, req *request.Cre
 		return nil, customErr.ErrCategoryNotFound
 	}
 
+	attributes, err := s.attributeRepo.FindAllByCategoryID(ctx, category.ID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách thuộc tính của danh mục sản phẩm thất bại: %w", err)
+	}
+
+	attributeValues, err := s.buildAttributeValues(productID, attributes, nil, req.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
 	slug := common.GenerateSlug(req.Name)
 
 	imgQuan := len(req.Images)
@@ -106,7 +212,6 @@ func (s *productServiceImpl) CreateProduct(ctx context.Context, req *request.Cre
 
 	if imgQuan > 0 {
 		for _, img := range req.Images {
-			fileName := fmt.Sprintf("%s_%d", slug, img.SortOrder)
 			imageID, err := s.sfg.NextID()
 			if err != nil {
 				return nil, err
@@ -114,14 +219,21 @@ func (s *productServiceImpl) CreateProduct(ctx context.Context, req *request.Cre
 
 			newImg := &model.Image{
 				ID:          imageID,
+				PublicID:    img.Key,
 				IsThumbnail: *img.IsThumbnail,
 				SortOrder:   img.SortOrder,
+				Status:      common.ImageStatusPending,
+			}
+			if img.AssetID != 0 {
+				assetID := img.AssetID
+				newImg.AssetID = &assetID
+				images = append(images, newImg)
+				continue
 			}
 
 			uploadReq := &types.UploadImageMessage{
-				ImageID:  imageID,
-				FileName: fileName,
-				FileData: img.FileData,
+				ImageID: imageID,
+				Key:     img.Key,
 			}
 
 			publishCh <- uploadReq
@@ -137,6 +249,10 @@ func (s *productServiceImpl) CreateProduct(ctx context.Context, req *request.Cre
 		Slug:        slug,
 		CategoryID:  category.ID,
 		Description: req.Description,
+		IsActive:    publishing.Status == common.ProductStatusPublished,
+		Status:      publishing.Status,
+		PublishAt:   publishing.PublishAt,
+		UnpublishAt: publishing.UnpublishAt,
 		Inventory: &model.Inventory{
 			ID:        inventoryID,
 			Quantity:  req.Quantity,
@@ -144,13 +260,75 @@ func (s *productServiceImpl) CreateProduct(ctx context.Context, req *request.Cre
 		},
 		Images: images,
 	}
+
+	options, err := s.buildOptions(productID, req.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, _, variantImages, variantUploads, err := s.buildVariants(newProduct, options, nil, req.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(variants) > 0 {
+		newProduct.Inventory.Quantity = 0
+		for _, variant := range variants {
+			newProduct.Inventory.Quantity += variant.Inventory.Quantity
+		}
+	}
 	newProduct.Inventory.SetStock()
 
-	if err := s.productRepo.Create(ctx, newProduct); err != nil {
-		if common.IsUniqueViolation(err) {
-			return nil, customErr.ErrProductSlugAlreadyExists
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		skus := make([]string, 0, len(variants))
+		for _, variant := range variants {
+			skus = append(skus, variant.SKU)
+		}
+		if err := s.checkVariantSKUsTx(ctx, tx, skus); err != nil {
+			return err
+		}
+
+		if err := s.attachImagesTx(ctx, tx, append(images, variantImages...)); err != nil {
+			return err
+		}
+
+		if err := s.productRepo.CreateTx(ctx, tx, newProduct); err != nil {
+			if common.IsUniqueViolation(err) {
+				return customErr.ErrProductSlugAlreadyExists
+			}
+			return fmt.Errorf("tạo sản phẩm thất bại: %w", err)
+		}
+
+		if len(options) > 0 {
+			if err := s.variantRepo.CreateOptionsTx(ctx, tx, options); err != nil {
+				return fmt.Errorf("tạo tùy chọn sản phẩm thất bại: %w", err)
+			}
+		}
+
+		if len(variants) > 0 {
+			if err := s.variantRepo.CreateAllTx(ctx, tx, variants); err != nil {
+				if common.IsUniqueViolation(err) {
+					return customErr.ErrVariantSKUAlreadyExists
+				}
+				return fmt.Errorf("tạo biến thể sản phẩm thất bại: %w", err)
+			}
+		}
+
+		if len(variantImages) > 0 {
+			if err := s.imageRepo.CreateAllTx(ctx, tx, variantImages); err != nil {
+				return fmt.Errorf("tạo hình ảnh biến thể sản phẩm thất bại: %w", err)
+			}
+		}
+
+		if len(attributeValues) > 0 {
+			if err := s.attributeRepo.CreateValuesTx(ctx, tx, attributeValues); err != nil {
+				return fmt.Errorf("tạo thuộc tính sản phẩm thất bại: %w", err)
+			}
 		}
-		return nil, fmt.Errorf("tạo sản phẩm thất bại: %w", err)
+
+		return writeProductHistoryTx(ctx, tx, s.historyRepo, s.sfg, productID, common.ProductHistoryActionCreate, actor, nil, model.NewProductSnapshot(newProduct))
+	}); err != nil {
+		return nil, err
 	}
 
 	go func() {
@@ -160,12 +338,35 @@ func (s *productServiceImpl) CreateProduct(ctx context.Context, req *request.Cre
 				log.Printf("đẩy tin nhắn upload ảnh thất bại: %v", err)
 			}
 		}
+		for _, req := range variantUploads {
+			body, _ := json.Marshal(req)
+			if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyImageUpload, body); err != nil {
+				log.Printf("đẩy tin nhắn upload ảnh thất bại: %v", err)
+			}
+		}
 	}()
 
-	return newProduct, nil
+	createdProduct, err := s.productRepo.FindByIDWithDetails(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+	}
+	if createdProduct == nil {
+		return nil, customErr.ErrProductNotFound
+	}
+
+	if err = s.loadCategoryAncestors(ctx, createdProduct); err != nil {
+		return nil, err
+	}
+
+	indexSuggestion(s.suggestionRepo, toProductSuggestionDocument(createdProduct))
+	publishProductIndex(s.rabbitChan, types.ProductIndexMessage{ProductIDs: []int64{productID}})
+
+	return createdProduct, nil
 }
 
-func (s *productServiceImpl) UpdateProduct(ctx context.Context, id int64, req *request.UpdateProductForm) (*model.Product, error) {
+func (s *productServiceImpl) UpdateProduct(ctx context.Context, actor *types.UserData, id int64, req *request.UpdateProductForm) (*model.Product, error) {
+	var uploads []*types.UploadImageMessage
+	var deletePublicIDs []string
 	if err := s.db.Transaction(func(tx *gorm.DB) error {
 		product, err := s.productRepo.FindByIDWithDetailsTx(ctx, tx, id)
 		if err != nil {
@@ -174,6 +375,7 @@ func (s *productServiceImpl) UpdateProduct(ctx context.Context, id int64, req *r
 		if product == nil {
 			return customErr.ErrProductNotFound
 		}
+		before := model.NewProductSnapshot(product)
 
 		updateData := map[string]any{}
 		if req.Name != nil && *req.Name != product.Name {
@@ -186,10 +388,14 @@ func (s *productServiceImpl) UpdateProduct(ctx context.Context, id int64, req *r
 		if req.Description != nil && *req.Description != product.Description {
 			updateData["description"] = *req.Description
 		}
-		if req.IsActive != nil && *req.IsActive != product.IsActive {
-			updateData["is_active"] = *req.IsActive
+
+		publishing, err := resolveProductPublishing(product, req.Status, req.IsActive, req.PublishAt, req.UnpublishAt)
+		if err != nil {
+			return err
 		}
+		publishing.applyTo(product, updateData)
 
+		categoryID := product.CategoryID
 		if req.CategoryID != nil && *req.CategoryID != product.CategoryID {
 			category, err := s.categoryRepo.FindByIDTx(ctx, tx, *req.CategoryID)
 			if err != nil {
@@ -200,6 +406,29 @@ func (s *productServiceImpl) UpdateProduct(ctx context.Context, id int64, req *r
 			}
 
 			updateData["category_id"] = category.ID
+			categoryID = category.ID
+		}
+
+		if req.Attributes != nil || categoryID != product.CategoryID {
+			attributes, err := s.attributeRepo.FindAllByCategoryIDTx(ctx, tx, categoryID)
+			if err != nil {
+				return fmt.Errorf("lấy danh sách thuộc tính của danh mục sản phẩm thất bại: %w", err)
+			}
+
+			attributeValues, err := s.buildAttributeValues(product.ID, attributes, product.Attributes, req.Attributes)
+			if err != nil {
+				return err
+			}
+
+			if err = s.attributeRepo.DeleteValuesByProductIDTx(ctx, tx, product.ID); err != nil {
+				return fmt.Errorf("xóa thuộc tính sản phẩm thất bại: %w", err)
+			}
+
+			if len(attributeValues) > 0 {
+				if err = s.attributeRepo.CreateValuesTx(ctx, tx, attributeValues); err != nil {
+					return fmt.Errorf("cập nhật thuộc tính sản phẩm thất bại: %w", err)
+				}
+			}
 		}
 
 		if len(updateData) > 0 {
@@ -236,22 +465,11 @@ func (s *productServiceImpl) UpdateProduct(ctx context.Context, id int64, req *r
 				return fmt.Errorf("xóa danh sách hình ảnh sản phẩm thất bại: %w", err)
 			}
 
-			publishChan := make(chan string, len(imgs))
 			for _, img := range imgs {
 				if strings.TrimSpace(img.PublicID) != "" {
-					publishChan <- img.PublicID
+					deletePublicIDs = append(deletePublicIDs, img.PublicID)
 				}
 			}
-			close(publishChan)
-
-			go func() {
-				for req := range publishChan {
-					body := []byte(req)
-					if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyImageDelete, body); err != nil {
-						log.Printf("đẩy tin nhắn xóa ảnh thất bại: %v", err)
-					}
-				}
-			}()
 		}
 
 		if len(req.UpdateImages) > 0 {
@@ -288,61 +506,72 @@ func (s *productServiceImpl) UpdateProduct(ctx context.Context, id int64, req *r
 		imgQuan := len(req.NewImages)
 		if imgQuan > 0 {
 			images := make([]*model.Image, 0, imgQuan)
-			publishCh := make(chan *types.UploadImageMessage, imgQuan)
 
 			for _, img := range req.NewImages {
-				var slug string
-				if req.Name != nil {
-					slug = common.GenerateSlug(*req.Name)
-				} else {
-					slug = product.Slug
-				}
-
-				fileName := fmt.Sprintf("%s_%d", slug, img.SortOrder)
 				imageID, err := s.sfg.NextID()
 				if err != nil {
 					return err
 				}
 
-				fmt.Println(*img.IsThumbnail)
-
 				newImg := &model.Image{
 					ID:          imageID,
+					PublicID:    img.Key,
 					IsThumbnail: *img.IsThumbnail,
 					SortOrder:   img.SortOrder,
+					Status:      common.ImageStatusPending,
 					ProductID:   product.ID,
 				}
+				if img.AssetID != 0 {
+					assetID := img.AssetID
+					newImg.AssetID = &assetID
+					images = append(images, newImg)
+					continue
+				}
 
 				uploadReq := &types.UploadImageMessage{
-					ImageID:  imageID,
-					FileName: fileName,
-					FileData: img.FileData,
+					ImageID: imageID,
+					Key:     img.Key,
 				}
 
-				publishCh <- uploadReq
+				uploads = append(uploads, uploadReq)
 				images = append(images, newImg)
 			}
-			close(publishCh)
+
+			if err = s.attachImagesTx(ctx, tx, images); err != nil {
+				return err
+			}
 
 			if err = s.imageRepo.CreateAllTx(ctx, tx, images); err != nil {
 				return fmt.Errorf("tạo hình ảnh thất bại: %w", err)
 			}
+		}
 
-			go func() {
-				for req := range publishCh {
-					body, _ := json.Marshal(req)
-					if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyImageUpload, body); err != nil {
-						log.Printf("đẩy tin nhắn upload ảnh thất bại: %v", err)
-					}
-				}
-			}()
+		variantUploads, err := s.updateVariantsTx(ctx, tx, product, req)
+		if err != nil {
+			return err
 		}
+		uploads = append(uploads, variantUploads...)
 
-		return nil
+		return s.writeChangedProductHistoryTx(ctx, tx, id, common.ProductHistoryActionUpdate, actor, before)
 	}); err != nil {
 		return nil, err
 	}
 
+	go func() {
+		for _, publicID := range deletePublicIDs {
+			if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyImageDelete, []byte(publicID)); err != nil {
+				log.Printf("đẩy tin nhắn xóa ảnh thất bại: %v", err)
+			}
+		}
+
+		for _, req := range uploads {
+			body, _ := json.Marshal(req)
+			if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyImageUpload, body); err != nil {
+				log.Printf("đẩy tin nhắn upload ảnh thất bại: %v", err)
+			}
+		}
+	}()
+
 	updatedProduct, err := s.productRepo.FindByIDWithDetails(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
@@ -351,59 +580,175 @@ func (s *productServiceImpl) UpdateProduct(ctx context.Context, id int64, req *r
 		return nil, customErr.ErrProductNotFound
 	}
 
+	if err = s.loadCategoryAncestors(ctx, updatedProduct); err != nil {
+		return nil, err
+	}
+
+	indexSuggestion(s.suggestionRepo, toProductSuggestionDocument(updatedProduct))
+	publishProductIndex(s.rabbitChan, types.ProductIndexMessage{ProductIDs: []int64{id}})
+
 	return updatedProduct, nil
 }
 
-func (s *productServiceImpl) DeleteProduct(ctx context.Context, id int64) error {
-	product, err := s.productRepo.FindByIDWithImages(ctx, id)
-	if err != nil {
-		return fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
-	}
-	if product == nil {
-		return customErr.ErrProductNotFound
-	}
+func (s *productServiceImpl) DeleteProduct(ctx context.Context, actor *types.UserData, id int64) error {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		products, err := s.productRepo.FindAllByIDForHistoryTx(ctx, tx, []int64{id})
+		if err != nil {
+			return fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+		}
+		if len(products) == 0 || products[0].DeletedAt.Valid {
+			return customErr.ErrProductNotFound
+		}
 
-	if err := s.productRepo.Delete(ctx, id); err != nil {
-		if errors.Is(err, customErr.ErrProductNotFound) {
-			return err
+		if err = s.productRepo.DeleteTx(ctx, tx, id); err != nil {
+			if errors.Is(err, customErr.ErrProductNotFound) {
+				return err
+			}
+			return fmt.Errorf("xóa sản phẩm thất bại: %w", err)
 		}
-		return fmt.Errorf("xóa sản phẩm thất bại: %w", err)
+
+		return s.writeProductsHistoryTx(ctx, tx, products, common.ProductHistoryActionDelete, actor)
+	}); err != nil {
+		return err
 	}
 
-	if len(product.Images) > 0 {
-		publishChan := make(chan string, len(product.Images))
-		for _, img := range product.Images {
-			if strings.TrimSpace(img.PublicID) != "" {
-				publishChan <- img.PublicID
+	deleteSuggestions(s.suggestionRepo, common.SuggestionTypeProduct, []int64{id})
+	publishProductIndex(s.rabbitChan, types.ProductIndexMessage{ProductIDs: []int64{id}})
+
+	return nil
+}
+
+func (s *productServiceImpl) DeleteProducts(ctx context.Context, actor *types.UserData, req request.DeleteManyRequest) (int64, error) {
+	var rowsAccepted int64
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		products, err := s.productRepo.FindAllByIDForHistoryTx(ctx, tx, req.IDs)
+		if err != nil {
+			return fmt.Errorf("lấy danh sách sản phẩm cần xóa thất bại: %w", err)
+		}
+		if len(req.IDs) != len(products) {
+			return customErr.ErrHasProductNotFound
+		}
+		for _, product := range products {
+			if product.DeletedAt.Valid {
+				return customErr.ErrHasProductNotFound
 			}
 		}
-		close(publishChan)
 
-		go func() {
-			for req := range publishChan {
-				body := []byte(req)
-				if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyImageDelete, body); err != nil {
-					log.Printf("đẩy tin nhắn xóa ảnh thất bại: %v", err)
-				}
-			}
-		}()
+		rowsAccepted, err = s.productRepo.DeleteAllByIDTx(ctx, tx, req.IDs)
+		if err != nil {
+			return fmt.Errorf("xóa danh sách sản phẩm thât bại: %w", err)
+		}
+
+		return s.writeProductsHistoryTx(ctx, tx, products, common.ProductHistoryActionDelete, actor)
+	}); err != nil {
+		return 0, err
 	}
 
-	return nil
+	deleteSuggestions(s.suggestionRepo, common.SuggestionTypeProduct, req.IDs)
+	publishProductIndex(s.rabbitChan, types.ProductIndexMessage{ProductIDs: req.IDs})
+
+	return rowsAccepted, nil
+}
+
+func (s *productServiceImpl) GetDeletedProducts(ctx context.Context) ([]*model.Product, error) {
+	products, err := s.productRepo.FindAllDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách sản phẩm đã xóa thất bại: %w", err)
+	}
+
+	return products, nil
 }
 
-func (s *productServiceImpl) DeleteProducts(ctx context.Context, req request.DeleteManyRequest) (int64, error) {
-	products, err := s.productRepo.FindAllByIDWithImages(ctx, req.IDs)
+func (s *productServiceImpl) RestoreProduct(ctx context.Context, actor *types.UserData, id int64) (*model.Product, error) {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		products, err := s.productRepo.FindAllByIDForHistoryTx(ctx, tx, []int64{id})
+		if err != nil {
+			return fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+		}
+		if len(products) == 0 || !products[0].DeletedAt.Valid {
+			return customErr.ErrProductNotFound
+		}
+
+		if err = s.productRepo.RestoreTx(ctx, tx, id); err != nil {
+			if errors.Is(err, customErr.ErrProductNotFound) {
+				return err
+			}
+			if common.IsUniqueViolation(err) {
+				return customErr.ErrProductSlugAlreadyExists
+			}
+			return fmt.Errorf("khôi phục sản phẩm thất bại: %w", err)
+		}
+
+		return s.writeProductsHistoryTx(ctx, tx, products, common.ProductHistoryActionRestore, actor)
+	}); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.FindByIDWithDetails(ctx, id)
 	if err != nil {
-		return 0, fmt.Errorf("lấy danh sách sản phẩm cần xóa thất bại: %w", err)
+		return nil, fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+	}
+	if product == nil {
+		return nil, customErr.ErrProductNotFound
 	}
-	if len(req.IDs) != len(products) {
-		return 0, customErr.ErrHasProductNotFound
+
+	if err = s.loadCategoryAncestors(ctx, product); err != nil {
+		return nil, err
 	}
 
-	rowsAccepted, err := s.productRepo.DeleteAllByID(ctx, req.IDs)
+	indexSuggestion(s.suggestionRepo, toProductSuggestionDocument(product))
+	publishProductIndex(s.rabbitChan, types.ProductIndexMessage{ProductIDs: []int64{id}})
+
+	return product, nil
+}
+
+func (s *productServiceImpl) PurgeDeletedProducts(ctx context.Context, before time.Time) (int64, error) {
+	products, err := s.productRepo.FindAllDeletedBeforeWithImages(ctx, before)
 	if err != nil {
-		return 0, fmt.Errorf("xóa danh sách sản phẩm thât bại: %w", err)
+		return 0, fmt.Errorf("lấy danh sách sản phẩm hết hạn lưu trữ thất bại: %w", err)
+	}
+	if len(products) == 0 {
+		return 0, nil
+	}
+
+	productIDs := make([]int64, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+	}
+
+	var rowsAccepted int64
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		lockedProducts, err := s.productRepo.FindAllByIDForHistoryTx(ctx, tx, productIDs)
+		if err != nil {
+			return fmt.Errorf("lấy danh sách sản phẩm hết hạn lưu trữ thất bại: %w", err)
+		}
+
+		products = products[:0]
+		productIDs = productIDs[:0]
+		for _, product := range lockedProducts {
+			if product.DeletedAt.Valid && !product.DeletedAt.Time.After(before) {
+				products = append(products, product)
+				productIDs = append(productIDs, product.ID)
+			}
+		}
+		if len(productIDs) == 0 {
+			return nil
+		}
+
+		for _, product := range products {
+			if err = writeProductHistoryTx(ctx, tx, s.historyRepo, s.sfg, product.ID, common.ProductHistoryActionPurge, nil, model.NewProductSnapshot(product), nil); err != nil {
+				return err
+			}
+		}
+
+		rowsAccepted, err = s.productRepo.PurgeAllByIDTx(ctx, tx, productIDs)
+		if err != nil {
+			return fmt.Errorf("xóa vĩnh viễn sản phẩm thất bại: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return 0, err
 	}
 
 	imgPublicIDs := []string{}
@@ -434,3 +779,170 @@ func (s *productServiceImpl) DeleteProducts(ctx context.Context, req request.Del
 
 	return rowsAccepted, nil
 }
+
+func (s *productServiceImpl) GetFailedImages(ctx context.Context) ([]*model.Image, error) {
+	images, err := s.imageRepo.FindAllByStatus(ctx, common.ImageStatusFailed)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách hình ảnh lỗi thất bại: %w", err)
+	}
+
+	return images, nil
+}
+
+func (s *productServiceImpl) RetryFailedImages(ctx context.Context, actor *types.UserData, req request.RetryImagesRequest) (int64, error) {
+	var images []*model.Image
+	var err error
+	if len(req.ImageIDs) > 0 {
+		images, err = s.imageRepo.FindAllByIDAndStatus(ctx, req.ImageIDs, common.ImageStatusFailed)
+	} else {
+		images, err = s.imageRepo.FindAllByStatus(ctx, common.ImageStatusFailed)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("lấy danh sách hình ảnh lỗi thất bại: %w", err)
+	}
+
+	imageIDs := make([]int64, 0, len(images))
+	productIDs := []int64{}
+	seenProducts := make(map[int64]bool)
+	messages := make([]types.UploadImageMessage, 0, len(images))
+	for _, image := range images {
+		if strings.TrimSpace(image.PublicID) == "" {
+			continue
+		}
+		imageIDs = append(imageIDs, image.ID)
+		messages = append(messages, types.UploadImageMessage{
+			ImageID: image.ID,
+			Key:     image.PublicID,
+		})
+		if !seenProducts[image.ProductID] {
+			seenProducts[image.ProductID] = true
+			productIDs = append(productIDs, image.ProductID)
+		}
+	}
+	if len(imageIDs) == 0 {
+		return 0, nil
+	}
+
+	var rowsAccepted int64
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		products, err := s.productRepo.FindAllByIDForHistoryTx(ctx, tx, productIDs)
+		if err != nil {
+			return fmt.Errorf("lấy danh sách sản phẩm thất bại: %w", err)
+		}
+
+		rowsAccepted, err = s.imageRepo.UpdateAllStatusByIDTx(ctx, tx, imageIDs, common.ImageStatusPending)
+		if err != nil {
+			return fmt.Errorf("cập nhật trạng thái hình ảnh thất bại: %w", err)
+		}
+
+		return s.writeProductsHistoryTx(ctx, tx, products, common.ProductHistoryActionRetryImage, actor)
+	}); err != nil {
+		return 0, err
+	}
+
+	go func() {
+		for _, msg := range messages {
+			body, _ := json.Marshal(msg)
+			if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyImageUpload, body); err != nil {
+				log.Printf("đẩy tin nhắn upload ảnh thất bại: %v", err)
+			}
+		}
+	}()
+
+	return rowsAccepted, nil
+}
+
+func (s *productServiceImpl) FailStaleImages(ctx context.Context, before time.Time) (int64, error) {
+	count, err := s.imageRepo.UpdateStatusStaleBefore(ctx, common.ImageStatusPending, common.ImageStatusFailed, before)
+	if err != nil {
+		return 0, fmt.Errorf("cập nhật trạng thái hình ảnh quá hạn xử lý thất bại: %w", err)
+	}
+
+	return count, nil
+}
+
+func (s *productServiceImpl) SuggestProducts(ctx context.Context, query request.ProductSuggestQuery) ([]*types.SuggestionDocument, string, error) {
+	docs, err := s.suggestionRepo.Suggest(ctx, query.Query, query.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(docs) > 0 {
+		return docs, "", nil
+	}
+
+	didYouMean, err := s.suggestionRepo.DidYouMean(ctx, query.Query)
+	if err != nil {
+		return nil, "", err
+	}
+	if strings.EqualFold(didYouMean, query.Query) {
+		didYouMean = ""
+	}
+
+	return docs, didYouMean, nil
+}
+
+func (s *productServiceImpl) RebuildSuggestions(ctx context.Context) error {
+	created, err := s.suggestionRepo.EnsureIndex(ctx)
+	if err != nil {
+		return fmt.Errorf("khởi tạo chỉ mục gợi ý thất bại: %w", err)
+	}
+
+	products, err := s.productRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("lấy danh sách sản phẩm thất bại: %w", err)
+	}
+
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("lấy danh sách danh mục sản phẩm thất bại: %w", err)
+	}
+
+	docs := make([]*types.SuggestionDocument, 0, len(products)+len(categories))
+	for _, product := range products {
+		docs = append(docs, toProductSuggestionDocument(product))
+	}
+	for _, category := range categories {
+		docs = append(docs, toCategorySuggestionDocument(category))
+	}
+
+	if !created {
+		count, err := s.suggestionRepo.Count(ctx)
+		if err != nil {
+			return fmt.Errorf("đếm số lượng gợi ý thất bại: %w", err)
+		}
+		if count > int64(len(docs)) {
+			if err = s.suggestionRepo.RecreateIndex(ctx); err != nil {
+				return fmt.Errorf("tạo lại chỉ mục gợi ý thất bại: %w", err)
+			}
+		}
+	}
+
+	if err = s.suggestionRepo.IndexAll(ctx, docs); err != nil {
+		return fmt.Errorf("đánh chỉ mục gợi ý thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func (s *productServiceImpl) loadCategoryAncestors(ctx context.Context, product *model.Product) error {
+	if product.Category == nil {
+		return nil
+	}
+
+	ancestorIDs := product.Category.AncestorIDs()
+	if len(ancestorIDs) == 0 {
+		return nil
+	}
+
+	ancestors, err := s.categoryRepo.FindAllByID(ctx, ancestorIDs)
+	if err != nil {
+		return fmt.Errorf("lấy đường dẫn danh mục sản phẩm thất bại: %w", err)
+	}
+
+	sort.Slice(ancestors, func(i, j int) bool {
+		return ancestors[i].Depth < ancestors[j].Depth
+	})
+	product.Category.Ancestors = ancestors
+
+	return nil
+}
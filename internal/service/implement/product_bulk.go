@@ -0,0 +1,245 @@
+package implement
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
+)
+
+const (
+	maxBulkUpdateProducts = 1000
+	priceAdjustPercent    = "percent"
+)
+
+func (s *productServiceImpl) BulkUpdateProducts(ctx context.Context, actor *types.UserData, req request.BulkUpdateProductsRequest) (*types.BulkUpdateResult, error) {
+	if len(req.IDs) > 0 && req.Filter != nil {
+		return nil, customErr.ErrBulkTargetConflict
+	}
+
+	changes := req.Changes
+	if changes.IsActive == nil && changes.CategoryID == nil && changes.PriceAdjust == nil && changes.Quantity == nil {
+		return nil, customErr.ErrEmptyBulkChanges
+	}
+
+	ids := make([]int64, 0, len(req.IDs))
+	seen := make(map[int64]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if filter := req.Filter; filter != nil {
+		if filter.CategoryID == nil && filter.IsActive == nil && filter.Search == "" && filter.MinPrice == nil && filter.MaxPrice == nil {
+			return nil, customErr.ErrEmptyBulkFilter
+		}
+		if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+			return nil, customErr.ErrInvalidPriceRange
+		}
+		if filter.CategoryID != nil {
+			categoryIDs, err := s.findDescendantCategoryIDs(ctx, []int64{*filter.CategoryID})
+			if err != nil {
+				return nil, err
+			}
+			filter.FilterCategoryIDs = categoryIDs
+		}
+	}
+
+	var category *model.Category
+	if changes.CategoryID != nil {
+		var err error
+		category, err = s.categoryRepo.FindByID(ctx, *changes.CategoryID)
+		if err != nil {
+			return nil, fmt.Errorf("lấy thông tin danh mục sản phẩm thất bại: %w", err)
+		}
+		if category == nil {
+			return nil, customErr.ErrCategoryNotFound
+		}
+
+		attributes, err := s.attributeRepo.FindAllByCategoryID(ctx, category.ID)
+		if err != nil {
+			return nil, fmt.Errorf("lấy danh sách thuộc tính của danh mục sản phẩm thất bại: %w", err)
+		}
+		for _, attr := range attributes {
+			if attr.IsRequired {
+				return nil, customErr.ErrAttributeValueRequired
+			}
+		}
+	}
+
+	result := &types.BulkUpdateResult{DryRun: req.DryRun}
+	var changedIDs []int64
+	var activeChanged []*model.Product
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		products, err := s.productRepo.FindAllForBulkUpdateTx(ctx, tx, ids, req.Filter, maxBulkUpdateProducts+1)
+		if err != nil {
+			return fmt.Errorf("lấy danh sách sản phẩm cần cập nhật thất bại: %w", err)
+		}
+		if len(ids) > 0 && len(products) != len(ids) {
+			return customErr.ErrHasProductNotFound
+		}
+		if len(products) > maxBulkUpdateProducts {
+			return customErr.ErrBulkTooManyProducts
+		}
+		result.Matched = len(products)
+
+		var categoryIDs, quantityIDs []int64
+		prices := make(map[int64]float64)
+		publishings := make(map[int64]map[string]any)
+		snapshots := make(map[int64][2]*model.ProductSnapshot)
+		hasConflicts := false
+		for _, product := range products {
+			change := &types.BulkProductChange{ProductID: product.ID, Name: product.Name}
+			before := model.NewProductSnapshot(product)
+			after := model.NewProductSnapshot(product)
+
+			if changes.IsActive != nil && *changes.IsActive != product.IsActive {
+				publishing, err := resolveProductPublishing(product, "", changes.IsActive, nil, nil)
+				if err != nil {
+					change.Errors = append(change.Errors, err.Error())
+				} else {
+					change.Changes = append(change.Changes, &types.BulkFieldChange{Field: "is_active", From: product.IsActive, To: *changes.IsActive})
+					updateData := map[string]any{}
+					publishing.applyTo(product, updateData)
+					publishings[product.ID] = updateData
+					product.IsActive = *changes.IsActive
+					product.Status = publishing.Status
+					product.PublishAt = publishing.PublishAt
+					after.IsActive = product.IsActive
+					after.Status = product.Status
+					after.PublishAt = product.PublishAt
+					activeChanged = append(activeChanged, product)
+				}
+			}
+
+			if category != nil && category.ID != product.CategoryID {
+				change.Changes = append(change.Changes, &types.BulkFieldChange{Field: "category_id", From: product.CategoryID, To: category.ID})
+				categoryIDs = append(categoryIDs, product.ID)
+				after.CategoryID = category.ID
+			}
+
+			if changes.PriceAdjust != nil {
+				price := adjustPrice(product.Price, changes.PriceAdjust)
+				if len(product.Variants) > 0 {
+					change.Errors = append(change.Errors, customErr.ErrBulkPriceProductHasVariants.Error())
+				} else if price <= 0 {
+					change.Errors = append(change.Errors, "giá sau khi điều chỉnh phải lớn hơn 0")
+				} else if price != product.Price {
+					change.Changes = append(change.Changes, &types.BulkFieldChange{Field: "price", From: product.Price, To: price})
+					prices[product.ID] = price
+					after.Price = price
+				}
+			}
+
+			if changes.Quantity != nil && product.Inventory != nil && *changes.Quantity != product.Inventory.Quantity {
+				switch {
+				case len(product.Variants) > 0:
+					change.Errors = append(change.Errors, customErr.ErrImportProductHasVariants.Error())
+				case *changes.Quantity < product.Inventory.Purchased:
+					change.Errors = append(change.Errors, customErr.ErrImportQuantityBelowPurchased.Error())
+				default:
+					change.Changes = append(change.Changes, &types.BulkFieldChange{Field: "quantity", From: product.Inventory.Quantity, To: *changes.Quantity})
+					quantityIDs = append(quantityIDs, product.ID)
+					after.Inventory = &model.InventorySnapshot{
+						Quantity:  *changes.Quantity,
+						Purchased: product.Inventory.Purchased,
+						Stock:     *changes.Quantity - product.Inventory.Purchased,
+					}
+				}
+			}
+
+			if len(change.Errors) > 0 {
+				hasConflicts = true
+			}
+			if len(change.Changes) > 0 {
+				result.Changed++
+				changedIDs = append(changedIDs, product.ID)
+				snapshots[product.ID] = [2]*model.ProductSnapshot{before, after}
+			}
+			if len(change.Changes) > 0 || len(change.Errors) > 0 {
+				result.Products = append(result.Products, change)
+			}
+		}
+
+		if hasConflicts && !req.DryRun {
+			return customErr.ErrBulkUpdateHasConflicts
+		}
+		if req.DryRun || len(changedIDs) == 0 {
+			return nil
+		}
+
+		for id, updateData := range publishings {
+			if err = s.productRepo.UpdateTx(ctx, tx, id, updateData); err != nil {
+				return fmt.Errorf("cập nhật trạng thái sản phẩm thất bại: %w", err)
+			}
+		}
+
+		if len(categoryIDs) > 0 {
+			if err = s.attributeRepo.DeleteValuesByProductIDsTx(ctx, tx, categoryIDs); err != nil {
+				return fmt.Errorf("xóa thuộc tính sản phẩm thất bại: %w", err)
+			}
+			if _, err = s.productRepo.UpdateAllByIDTx(ctx, tx, categoryIDs, map[string]any{"category_id": category.ID}); err != nil {
+				return fmt.Errorf("cập nhật danh mục sản phẩm thất bại: %w", err)
+			}
+		}
+
+		for id, price := range prices {
+			if err = s.productRepo.UpdateTx(ctx, tx, id, map[string]any{"price": price}); err != nil {
+				return fmt.Errorf("cập nhật giá sản phẩm thất bại: %w", err)
+			}
+		}
+
+		if len(quantityIDs) > 0 {
+			if err = s.inventoryRepo.UpdateAllByProductIDTx(ctx, tx, quantityIDs, map[string]any{
+				"quantity": *changes.Quantity,
+				"stock":    gorm.Expr("quantity - purchased"),
+				"is_stock": gorm.Expr("CASE WHEN (quantity - purchased) <= 5 THEN false ELSE true END"),
+			}); err != nil {
+				return fmt.Errorf("cập nhật số lượng sản phẩm thất bại: %w", err)
+			}
+		}
+
+		for _, id := range changedIDs {
+			snapshot := snapshots[id]
+			if err = writeProductHistoryTx(ctx, tx, s.historyRepo, s.sfg, id, common.ProductHistoryActionBulkUpdate, actor, snapshot[0], snapshot[1]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		if err == customErr.ErrBulkUpdateHasConflicts {
+			return result, err
+		}
+		return nil, err
+	}
+
+	if req.DryRun || len(changedIDs) == 0 {
+		return result, nil
+	}
+
+	for _, product := range activeChanged {
+		indexSuggestion(s.suggestionRepo, toProductSuggestionDocument(product))
+	}
+	publishProductIndex(s.rabbitChan, types.ProductIndexMessage{ProductIDs: changedIDs})
+
+	return result, nil
+}
+
+func adjustPrice(price float64, adjust *request.BulkPriceAdjustment) float64 {
+	if adjust.Type == priceAdjustPercent {
+		price = price * (1 + adjust.Value/100)
+	} else {
+		price = price + adjust.Value
+	}
+
+	return math.Round(price*100) / 100
+}
@@ -0,0 +1,127 @@
+package implement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/mapper"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/response"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+func (s *productServiceImpl) findDescendantCategoryIDs(ctx context.Context, categoryIDs []int64) ([]int64, error) {
+	uniqueIDs := make([]int64, 0, len(categoryIDs))
+	seen := make(map[int64]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		if !seen[id] {
+			seen[id] = true
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+
+	categories, err := s.categoryRepo.FindAllByID(ctx, uniqueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách danh mục sản phẩm thất bại: %w", err)
+	}
+	if len(categories) != len(uniqueIDs) {
+		return nil, customErr.ErrCategoryNotFound
+	}
+
+	descendantIDs := []int64{}
+	seen = make(map[int64]bool)
+	for _, category := range categories {
+		ids, err := s.categoryRepo.FindAllDescendantIDs(ctx, category.Path)
+		if err != nil {
+			return nil, fmt.Errorf("lấy danh sách danh mục con thất bại: %w", err)
+		}
+
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				descendantIDs = append(descendantIDs, id)
+			}
+		}
+	}
+
+	return descendantIDs, nil
+}
+
+func (s *productServiceImpl) normalizeAttributeFilters(ctx context.Context, filters map[int64][]string) (map[int64][]string, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	attributeIDs := make([]int64, 0, len(filters))
+	for id := range filters {
+		attributeIDs = append(attributeIDs, id)
+	}
+
+	attributes, err := s.attributeRepo.FindAllByID(ctx, attributeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách thuộc tính sản phẩm thất bại: %w", err)
+	}
+	if len(attributes) != len(attributeIDs) {
+		return nil, customErr.ErrAttributeNotFound
+	}
+
+	normalized := make(map[int64][]string, len(attributes))
+	for _, attribute := range attributes {
+		for _, raw := range filters[attribute.ID] {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+
+			value, err := normalizeAttributeValue(attribute, raw)
+			if err != nil {
+				return nil, err
+			}
+			normalized[attribute.ID] = append(normalized[attribute.ID], value)
+		}
+
+		if len(normalized[attribute.ID]) == 0 {
+			return nil, customErr.ErrInvalidAttributeValue
+		}
+	}
+
+	return normalized, nil
+}
+
+func (s *productServiceImpl) buildProductFacets(ctx context.Context, result *types.ProductSearchResult) (*response.ProductFacetsResponse, error) {
+	categories := []*model.Category{}
+	if len(result.CategoryCounts) > 0 {
+		categoryIDs := make([]int64, 0, len(result.CategoryCounts))
+		for _, count := range result.CategoryCounts {
+			categoryIDs = append(categoryIDs, count.CategoryID)
+		}
+
+		var err error
+		categories, err = s.categoryRepo.FindAllByID(ctx, categoryIDs)
+		if err != nil {
+			return nil, fmt.Errorf("lấy danh sách danh mục sản phẩm thất bại: %w", err)
+		}
+	}
+
+	attributes := []*model.CategoryAttribute{}
+	if len(result.AttributeCounts) > 0 {
+		attributeIDs := make([]int64, 0, len(result.AttributeCounts))
+		seen := make(map[int64]bool, len(result.AttributeCounts))
+		for _, count := range result.AttributeCounts {
+			if !seen[count.AttributeID] {
+				seen[count.AttributeID] = true
+				attributeIDs = append(attributeIDs, count.AttributeID)
+			}
+		}
+
+		var err error
+		attributes, err = s.attributeRepo.FindAllByID(ctx, attributeIDs)
+		if err != nil {
+			return nil, fmt.Errorf("lấy danh sách thuộc tính sản phẩm thất bại: %w", err)
+		}
+	}
+
+	return mapper.ToProductFacetsResponse(result, categories, attributes), nil
+}
@@ -0,0 +1,267 @@
+package implement
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/response"
+	"github.com/tienhai2808/ecom_go/internal/snowflake"
+	"github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
+)
+
+func writeProductHistoryTx(ctx context.Context, tx *gorm.DB, historyRepo repository.ProductHistoryRepository, sfg snowflake.SnowflakeGenerator, productID int64, action string, actor *types.UserData, before, after *model.ProductSnapshot) error {
+	historyID, err := sfg.NextID()
+	if err != nil {
+		return err
+	}
+
+	history := &model.ProductHistory{
+		ID:        historyID,
+		ProductID: productID,
+		Action:    action,
+		Before:    before,
+		After:     after,
+	}
+	if actor != nil {
+		actorID := actor.ID
+		history.ActorID = &actorID
+		history.ActorUsername = actor.Username
+	}
+
+	if err = historyRepo.CreateTx(ctx, tx, history); err != nil {
+		return fmt.Errorf("ghi lịch sử thay đổi sản phẩm thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func (s *productServiceImpl) writeChangedProductHistoryTx(ctx context.Context, tx *gorm.DB, id int64, action string, actor *types.UserData, before *model.ProductSnapshot) error {
+	product, err := s.productRepo.FindByIDWithDetailsTx(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+	}
+	if product == nil {
+		return customErr.ErrProductNotFound
+	}
+
+	after := model.NewProductSnapshot(product)
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+
+	return writeProductHistoryTx(ctx, tx, s.historyRepo, s.sfg, id, action, actor, before, after)
+}
+
+func (s *productServiceImpl) writeProductsHistoryTx(ctx context.Context, tx *gorm.DB, products []*model.Product, action string, actor *types.UserData) error {
+	ids := make([]int64, 0, len(products))
+	befores := make(map[int64]*model.ProductSnapshot, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+		befores[product.ID] = model.NewProductSnapshot(product)
+	}
+
+	updatedProducts, err := s.productRepo.FindAllByIDForHistoryTx(ctx, tx, ids)
+	if err != nil {
+		return fmt.Errorf("lấy danh sách sản phẩm thất bại: %w", err)
+	}
+
+	for _, product := range updatedProducts {
+		before := befores[product.ID]
+		after := model.NewProductSnapshot(product)
+		if reflect.DeepEqual(before, after) {
+			continue
+		}
+
+		if err = writeProductHistoryTx(ctx, tx, s.historyRepo, s.sfg, product.ID, action, actor, before, after); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *productServiceImpl) GetProductHistory(ctx context.Context, id int64, query request.ProductHistoryPaginationQuery) ([]*model.ProductHistory, *response.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	histories, total, err := s.historyRepo.FindAllByProductID(ctx, id, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lấy lịch sử thay đổi sản phẩm thất bại: %w", err)
+	}
+	if total == 0 {
+		product, err := s.productRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+		}
+		if product == nil {
+			return nil, nil, customErr.ErrProductNotFound
+		}
+	}
+
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	meta := &response.MetaResponse{
+		Total:      total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: totalPages,
+		HasPrev:    query.Page > 1,
+		HasNext:    int64(query.Page) < totalPages,
+	}
+
+	return histories, meta, nil
+}
+
+func (s *productServiceImpl) RevertProduct(ctx context.Context, actor *types.UserData, id int64, version int) (*model.Product, error) {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		product, err := s.productRepo.FindByIDWithDetailsTx(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+		}
+		if product == nil {
+			return customErr.ErrProductNotFound
+		}
+
+		history, err := s.historyRepo.FindByProductIDAndVersionTx(ctx, tx, id, version)
+		if err != nil {
+			return fmt.Errorf("lấy lịch sử thay đổi sản phẩm thất bại: %w", err)
+		}
+		if history == nil || history.After == nil {
+			return customErr.ErrProductHistoryNotFound
+		}
+
+		target := history.After
+		before := model.NewProductSnapshot(product)
+
+		currentImages := make(map[int64]*model.ImageSnapshot, len(before.Images))
+		for _, img := range before.Images {
+			currentImages[img.ID] = img
+		}
+		if len(target.Images) != len(currentImages) {
+			return customErr.ErrRevertImagesChanged
+		}
+		for _, img := range target.Images {
+			if _, ok := currentImages[img.ID]; !ok {
+				return customErr.ErrRevertImagesChanged
+			}
+		}
+
+		updateData := map[string]any{}
+		if target.Name != product.Name {
+			updateData["name"] = target.Name
+		}
+		if target.Slug != product.Slug {
+			updateData["slug"] = target.Slug
+		}
+		if target.Price != product.Price {
+			updateData["price"] = target.Price
+		}
+		if target.Description != product.Description {
+			updateData["description"] = target.Description
+		}
+
+		publishing := &productPublishing{target.Status, target.PublishAt, target.UnpublishAt}
+		if publishing.Status == "" {
+			publishing.Status = common.ProductStatusDraft
+			if target.IsActive {
+				publishing.Status = common.ProductStatusPublished
+			}
+		}
+		publishing.applyTo(product, updateData)
+
+		if target.CategoryID != product.CategoryID {
+			category, err := s.categoryRepo.FindByIDTx(ctx, tx, target.CategoryID)
+			if err != nil {
+				return fmt.Errorf("lấy thông tin danh mục sản phẩm thất bại: %w", err)
+			}
+			if category == nil {
+				return customErr.ErrCategoryNotFound
+			}
+
+			attributes, err := s.attributeRepo.FindAllByCategoryIDTx(ctx, tx, category.ID)
+			if err != nil {
+				return fmt.Errorf("lấy danh sách thuộc tính của danh mục sản phẩm thất bại: %w", err)
+			}
+			for _, attr := range attributes {
+				if attr.IsRequired {
+					return customErr.ErrAttributeValueRequired
+				}
+			}
+
+			if err = s.attributeRepo.DeleteValuesByProductIDTx(ctx, tx, product.ID); err != nil {
+				return fmt.Errorf("xóa thuộc tính sản phẩm thất bại: %w", err)
+			}
+			updateData["category_id"] = category.ID
+		}
+
+		if len(updateData) > 0 {
+			if err = s.productRepo.UpdateTx(ctx, tx, id, updateData); err != nil {
+				if common.IsUniqueViolation(err) {
+					return customErr.ErrProductSlugAlreadyExists
+				}
+				return fmt.Errorf("cập nhật thông tin sản phẩm thất bại: %w", err)
+			}
+		}
+
+		if target.Inventory != nil && product.Inventory != nil && len(product.Variants) == 0 && target.Inventory.Quantity != product.Inventory.Quantity {
+			if target.Inventory.Quantity < product.Inventory.Purchased {
+				return customErr.ErrImportQuantityBelowPurchased
+			}
+
+			if err = s.inventoryRepo.UpdateTx(ctx, tx, product.Inventory.ID, map[string]any{
+				"quantity": target.Inventory.Quantity,
+				"stock":    gorm.Expr("quantity - purchased"),
+				"is_stock": gorm.Expr("CASE WHEN (quantity - purchased) <= 5 THEN false ELSE true END"),
+			}); err != nil {
+				return fmt.Errorf("cập nhật số lượng sản phẩm thất bại: %w", err)
+			}
+		}
+
+		for _, img := range target.Images {
+			current := currentImages[img.ID]
+			imageData := map[string]any{}
+			if img.IsThumbnail != current.IsThumbnail {
+				imageData["is_thumbnail"] = img.IsThumbnail
+			}
+			if img.SortOrder != current.SortOrder {
+				imageData["sort_order"] = img.SortOrder
+			}
+			if len(imageData) > 0 {
+				if err = s.imageRepo.UpdateTx(ctx, tx, img.ID, imageData); err != nil {
+					return fmt.Errorf("cập nhật hình ảnh thất bại: %w", err)
+				}
+			}
+		}
+
+		return s.writeChangedProductHistoryTx(ctx, tx, id, common.ProductHistoryActionRevert, actor, before)
+	}); err != nil {
+		return nil, err
+	}
+
+	revertedProduct, err := s.productRepo.FindByIDWithDetails(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+	}
+	if revertedProduct == nil {
+		return nil, customErr.ErrProductNotFound
+	}
+
+	if err = s.loadCategoryAncestors(ctx, revertedProduct); err != nil {
+		return nil, err
+	}
+
+	indexSuggestion(s.suggestionRepo, toProductSuggestionDocument(revertedProduct))
+	publishProductIndex(s.rabbitChan, types.ProductIndexMessage{ProductIDs: []int64{id}})
+
+	return revertedProduct, nil
+}
@@ -0,0 +1,698 @@
+package implement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/imaging"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/rabbitmq"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/response"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/snowflake"
+	"github.com/tienhai2808/ecom_go/internal/storage"
+	"github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
+)
+
+const (
+	maxImportFileBytes     = 10 << 20
+	maxImportRows          = 5000
+	importProgressInterval = 25
+	importDownloadTimeout  = 30 * time.Second
+	maxImportRedirects     = 3
+)
+
+type productImportServiceImpl struct {
+	importRepo     repository.ProductImportRepository
+	productRepo    repository.ProductRepository
+	categoryRepo   repository.CategoryRepository
+	inventoryRepo  repository.InventoryRepository
+	imageRepo      repository.ImageRepository
+	attributeRepo  repository.AttributeRepository
+	historyRepo    repository.ProductHistoryRepository
+	suggestionRepo repository.SuggestionRepository
+	store          storage.Storage
+	cfg            *config.Config
+	db             *gorm.DB
+	rabbitChan     *amqp091.Channel
+	sfg            snowflake.SnowflakeGenerator
+	httpClient     *http.Client
+}
+
+func NewProductImportService(importRepo repository.ProductImportRepository, productRepo repository.ProductRepository, categoryRepo repository.CategoryRepository, inventoryRepo repository.InventoryRepository, imageRepo repository.ImageRepository, attributeRepo repository.AttributeRepository, historyRepo repository.ProductHistoryRepository, suggestionRepo repository.SuggestionRepository, store storage.Storage, cfg *config.Config, db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator) service.ProductImportService {
+	return &productImportServiceImpl{
+		importRepo,
+		productRepo,
+		categoryRepo,
+		inventoryRepo,
+		imageRepo,
+		attributeRepo,
+		historyRepo,
+		suggestionRepo,
+		store,
+		cfg,
+		db,
+		rabbitChan,
+		sfg,
+		newImportHTTPClient(),
+	}
+}
+
+func newImportHTTPClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+	return &http.Client{
+		Timeout: importDownloadTimeout,
+		Transport: &http.Transport{
+			Proxy: nil,
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				host, port, err := net.SplitHostPort(address)
+				if err != nil {
+					return nil, err
+				}
+
+				addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+				if err != nil {
+					return nil, err
+				}
+				if len(addrs) == 0 {
+					return nil, customErr.ErrImportImageURLNotAllowed
+				}
+				for _, addr := range addrs {
+					if !isPublicIP(addr.IP) {
+						return nil, customErr.ErrImportImageURLNotAllowed
+					}
+				}
+
+				return dialer.DialContext(ctx, network, net.JoinHostPort(addrs[0].IP.String(), port))
+			},
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxImportRedirects {
+				return customErr.ErrImportTooManyRedirects
+			}
+			if !isHTTPURL(req.URL) {
+				return customErr.ErrImportImageURLNotAllowed
+			}
+
+			return nil
+		},
+	}
+}
+
+func isHTTPURL(u *neturl.URL) bool {
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Hostname() != ""
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+type importRowResult struct {
+	product    *model.Product
+	created    bool
+	uploads    []*types.UploadImageMessage
+	deleteKeys []string
+}
+
+func (s *productImportServiceImpl) CreateImportJob(ctx context.Context, userID int64, fileName string, content []byte, upsert bool) (*model.ProductImportJob, error) {
+	format, err := detectImportFormat(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) > maxImportFileBytes {
+		return nil, customErr.ErrImportFileTooLarge
+	}
+
+	rows, err := parseImportRows(format, content)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, customErr.ErrEmptyImportFile
+	}
+	if len(rows) > maxImportRows {
+		return nil, customErr.ErrImportTooManyRows
+	}
+
+	jobID, err := s.sfg.NextID()
+	if err != nil {
+		return nil, err
+	}
+
+	job := &model.ProductImportJob{
+		ID:        jobID,
+		FileName:  fileName,
+		Format:    format,
+		Upsert:    upsert,
+		Status:    common.ImportStatusPending,
+		TotalRows: len(rows),
+		Payload:   content,
+		UserID:    userID,
+	}
+
+	if err = s.importRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("tạo phiên nhập sản phẩm thất bại: %w", err)
+	}
+
+	body, _ := json.Marshal(types.ProductImportMessage{JobID: jobID})
+	if err = rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeProductImport, common.RoutingKeyProductImport, body); err != nil {
+		message := fmt.Sprintf("đẩy tin nhắn nhập sản phẩm thất bại: %v", err)
+		if err = s.importRepo.Update(ctx, jobID, map[string]any{
+			"status":      common.ImportStatusFailed,
+			"message":     message,
+			"finished_at": time.Now(),
+			"payload":     nil,
+		}); err != nil {
+			log.Printf("cập nhật phiên nhập sản phẩm %d thất bại: %v", jobID, err)
+		}
+		job.Status = common.ImportStatusFailed
+		job.Message = message
+	}
+
+	return job, nil
+}
+
+func (s *productImportServiceImpl) GetImportJobs(ctx context.Context, query request.ImportJobPaginationQuery) ([]*model.ProductImportJob, *response.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	jobs, total, err := s.importRepo.FindAll(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lấy danh sách phiên nhập sản phẩm thất bại: %w", err)
+	}
+
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	meta := &response.MetaResponse{
+		Total:      total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: totalPages,
+		HasPrev:    query.Page > 1,
+		HasNext:    int64(query.Page) < totalPages,
+	}
+
+	return jobs, meta, nil
+}
+
+func (s *productImportServiceImpl) GetImportJobByID(ctx context.Context, id int64) (*model.ProductImportJob, error) {
+	job, err := s.importRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin phiên nhập sản phẩm thất bại: %w", err)
+	}
+	if job == nil {
+		return nil, customErr.ErrImportJobNotFound
+	}
+
+	return job, nil
+}
+
+func (s *productImportServiceImpl) ProcessImportJob(ctx context.Context, id int64) error {
+	job, err := s.importRepo.FindByIDWithPayload(ctx, id)
+	if err != nil {
+		return fmt.Errorf("lấy thông tin phiên nhập sản phẩm thất bại: %w", err)
+	}
+	if job == nil || job.Status == common.ImportStatusCompleted || job.Status == common.ImportStatusFailed {
+		return nil
+	}
+
+	if err = s.importRepo.Update(ctx, id, map[string]any{
+		"status":         common.ImportStatusRunning,
+		"started_at":     time.Now(),
+		"processed_rows": 0,
+		"created_count":  0,
+		"updated_count":  0,
+		"failed_count":   0,
+	}); err != nil {
+		return fmt.Errorf("cập nhật phiên nhập sản phẩm thất bại: %w", err)
+	}
+
+	rows, err := parseImportRows(job.Format, job.Payload)
+	if err != nil {
+		return err
+	}
+
+	categories, err := s.findImportCategories(ctx, rows)
+	if err != nil {
+		return err
+	}
+
+	actor := &types.UserData{ID: job.UserID}
+	if job.User != nil {
+		actor.Username = job.User.Username
+	}
+
+	requiredAttrs := make(map[int64]bool)
+	rowErrors := make(model.ImportRowErrors, 0)
+	productIDs := make([]int64, 0, len(rows))
+	var created, updated int
+	for i, row := range rows {
+		slug := common.GenerateSlug(row.Data.Slug)
+		if slug == "" {
+			slug = common.GenerateSlug(row.Data.Name)
+		}
+
+		if len(row.Errors) > 0 {
+			rowErrors = append(rowErrors, &model.ImportRowError{Row: row.Line, Slug: slug, Errors: row.Errors})
+		} else {
+			result, err := s.importRow(ctx, job, actor, row.Data, slug, categories, requiredAttrs)
+			if err != nil {
+				rowErrors = append(rowErrors, &model.ImportRowError{Row: row.Line, Slug: slug, Errors: []string{err.Error()}})
+			} else {
+				if result.created {
+					created++
+				} else {
+					updated++
+				}
+				productIDs = append(productIDs, result.product.ID)
+				indexSuggestion(s.suggestionRepo, toProductSuggestionDocument(result.product))
+				s.publishImportImages(result)
+			}
+		}
+
+		if (i+1)%importProgressInterval == 0 {
+			if err = s.importRepo.Update(ctx, id, map[string]any{
+				"processed_rows": i + 1,
+				"created_count":  created,
+				"updated_count":  updated,
+				"failed_count":   len(rowErrors),
+			}); err != nil {
+				log.Printf("cập nhật tiến độ phiên nhập sản phẩm %d thất bại: %v", id, err)
+			}
+		}
+	}
+
+	if err = s.importRepo.Update(ctx, id, map[string]any{
+		"status":         common.ImportStatusCompleted,
+		"processed_rows": len(rows),
+		"created_count":  created,
+		"updated_count":  updated,
+		"failed_count":   len(rowErrors),
+		"row_errors":     rowErrors,
+		"finished_at":    time.Now(),
+		"payload":        nil,
+	}); err != nil {
+		return fmt.Errorf("cập nhật phiên nhập sản phẩm thất bại: %w", err)
+	}
+
+	if len(productIDs) > 0 {
+		publishProductIndex(s.rabbitChan, types.ProductIndexMessage{ProductIDs: productIDs})
+	}
+
+	return nil
+}
+
+func (s *productImportServiceImpl) FailImportJob(ctx context.Context, id int64, cause error) error {
+	message := truncateRunes(cause.Error(), 500)
+
+	if err := s.importRepo.Update(ctx, id, map[string]any{
+		"status":      common.ImportStatusFailed,
+		"message":     message,
+		"finished_at": time.Now(),
+		"payload":     nil,
+	}); err != nil {
+		return fmt.Errorf("cập nhật phiên nhập sản phẩm thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func (s *productImportServiceImpl) findImportCategories(ctx context.Context, rows []*types.ImportRow) (map[string]*model.Category, error) {
+	slugs := make([]string, 0, len(rows))
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		if row.Data.CategorySlug != "" && !seen[row.Data.CategorySlug] {
+			seen[row.Data.CategorySlug] = true
+			slugs = append(slugs, row.Data.CategorySlug)
+		}
+	}
+
+	categories, err := s.categoryRepo.FindAllBySlugs(ctx, slugs)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách danh mục sản phẩm thất bại: %w", err)
+	}
+
+	categoryMap := make(map[string]*model.Category, len(categories))
+	for _, category := range categories {
+		categoryMap[category.Slug] = category
+	}
+
+	return categoryMap, nil
+}
+
+func (s *productImportServiceImpl) hasRequiredAttributes(ctx context.Context, categoryID int64, cache map[int64]bool) (bool, error) {
+	if required, ok := cache[categoryID]; ok {
+		return required, nil
+	}
+
+	attributes, err := s.attributeRepo.FindAllByCategoryID(ctx, categoryID)
+	if err != nil {
+		return false, fmt.Errorf("lấy danh sách thuộc tính của danh mục sản phẩm thất bại: %w", err)
+	}
+
+	required := false
+	for _, attr := range attributes {
+		if attr.IsRequired {
+			required = true
+			break
+		}
+	}
+	cache[categoryID] = required
+
+	return required, nil
+}
+
+func (s *productImportServiceImpl) importRow(ctx context.Context, job *model.ProductImportJob, actor *types.UserData, data request.ImportProductRow, slug string, categories map[string]*model.Category, requiredAttrs map[int64]bool) (*importRowResult, error) {
+	category, ok := categories[data.CategorySlug]
+	if !ok {
+		return nil, customErr.ErrCategoryNotFound
+	}
+
+	keys, err := s.downloadImportImages(ctx, slug, data.ImageURLs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &importRowResult{}
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		product, err := s.productRepo.FindBySlugForImportTx(ctx, tx, slug)
+		if err != nil {
+			return fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+		}
+
+		if product == nil || product.CategoryID != category.ID {
+			required, err := s.hasRequiredAttributes(ctx, category.ID, requiredAttrs)
+			if err != nil {
+				return err
+			}
+			if required {
+				return customErr.ErrAttributeValueRequired
+			}
+		}
+
+		if product == nil {
+			product, err = s.createImportProductTx(ctx, tx, data, slug, category, keys, result)
+			if err != nil {
+				return err
+			}
+			result.product = product
+			result.created = true
+
+			return writeProductHistoryTx(ctx, tx, s.historyRepo, s.sfg, product.ID, common.ProductHistoryActionImport, actor, nil, model.NewProductSnapshot(product))
+		}
+
+		if !job.Upsert {
+			return customErr.ErrProductSlugAlreadyExists
+		}
+
+		before := model.NewProductSnapshot(product)
+		if err = s.updateImportProductTx(ctx, tx, product, data, category, keys, result); err != nil {
+			return err
+		}
+		result.product = product
+
+		updatedProduct, err := s.productRepo.FindByIDWithDetailsTx(ctx, tx, product.ID)
+		if err != nil {
+			return fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+		}
+		after := model.NewProductSnapshot(updatedProduct)
+		if reflect.DeepEqual(before, after) {
+			return nil
+		}
+
+		return writeProductHistoryTx(ctx, tx, s.historyRepo, s.sfg, product.ID, common.ProductHistoryActionImport, actor, before, after)
+	}); err != nil {
+		s.deleteImportObjects(keys)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *productImportServiceImpl) createImportProductTx(ctx context.Context, tx *gorm.DB, data request.ImportProductRow, slug string, category *model.Category, keys []string, result *importRowResult) (*model.Product, error) {
+	productID, err := s.sfg.NextID()
+	if err != nil {
+		return nil, err
+	}
+	inventoryID, err := s.sfg.NextID()
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := s.buildImportImages(productID, keys, result)
+	if err != nil {
+		return nil, err
+	}
+
+	publishing, err := resolveProductPublishing(nil, "", data.IsActive, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	product := &model.Product{
+		ID:          productID,
+		Name:        data.Name,
+		Price:       data.Price,
+		Slug:        slug,
+		CategoryID:  category.ID,
+		Description: data.Description,
+		IsActive:    publishing.Status == common.ProductStatusPublished,
+		Status:      publishing.Status,
+		PublishAt:   publishing.PublishAt,
+		Inventory: &model.Inventory{
+			ID:        inventoryID,
+			Quantity:  data.Quantity,
+			Purchased: 0,
+		},
+		Images: images,
+	}
+	product.Inventory.SetStock()
+
+	if err = s.productRepo.CreateTx(ctx, tx, product); err != nil {
+		if common.IsUniqueViolation(err) {
+			return nil, customErr.ErrProductSlugAlreadyExists
+		}
+		return nil, fmt.Errorf("tạo sản phẩm thất bại: %w", err)
+	}
+
+	return product, nil
+}
+
+func (s *productImportServiceImpl) updateImportProductTx(ctx context.Context, tx *gorm.DB, product *model.Product, data request.ImportProductRow, category *model.Category, keys []string, result *importRowResult) error {
+	updateData := map[string]any{
+		"name":        data.Name,
+		"price":       data.Price,
+		"description": data.Description,
+		"category_id": category.ID,
+	}
+	if data.IsActive != nil && *data.IsActive != product.IsActive {
+		publishing, err := resolveProductPublishing(product, "", data.IsActive, nil, nil)
+		if err != nil {
+			return err
+		}
+		publishing.applyTo(product, updateData)
+		product.IsActive = *data.IsActive
+		product.Status = publishing.Status
+		product.PublishAt = publishing.PublishAt
+	}
+
+	if product.CategoryID != category.ID {
+		if err := s.attributeRepo.DeleteValuesByProductIDTx(ctx, tx, product.ID); err != nil {
+			return fmt.Errorf("xóa thuộc tính sản phẩm thất bại: %w", err)
+		}
+	}
+
+	if err := s.productRepo.UpdateTx(ctx, tx, product.ID, updateData); err != nil {
+		return fmt.Errorf("cập nhật sản phẩm thất bại: %w", err)
+	}
+	product.Name = data.Name
+	product.Price = data.Price
+	product.Description = data.Description
+	product.CategoryID = category.ID
+
+	if product.Inventory != nil && data.Quantity != product.Inventory.Quantity {
+		if len(product.Variants) > 0 {
+			return customErr.ErrImportProductHasVariants
+		}
+		if data.Quantity < product.Inventory.Purchased {
+			return customErr.ErrImportQuantityBelowPurchased
+		}
+
+		if err := s.inventoryRepo.UpdateTx(ctx, tx, product.Inventory.ID, map[string]any{
+			"quantity": data.Quantity,
+			"stock":    gorm.Expr("quantity - purchased"),
+			"is_stock": gorm.Expr("CASE WHEN (quantity - purchased) <= 5 THEN false ELSE true END"),
+		}); err != nil {
+			return fmt.Errorf("cập nhật số lượng sản phẩm thất bại: %w", err)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if len(product.Images) > 0 {
+		imgIDs := make([]int64, 0, len(product.Images))
+		for _, img := range product.Images {
+			imgIDs = append(imgIDs, img.ID)
+			if img.AssetID == nil {
+				result.deleteKeys = append(result.deleteKeys, img.PublicID)
+			}
+		}
+		if err := s.imageRepo.DeleteAllByIDTx(ctx, tx, imgIDs); err != nil {
+			return fmt.Errorf("xóa hình ảnh sản phẩm thất bại: %w", err)
+		}
+	}
+
+	images, err := s.buildImportImages(product.ID, keys, result)
+	if err != nil {
+		return err
+	}
+	if err = s.imageRepo.CreateAllTx(ctx, tx, images); err != nil {
+		return fmt.Errorf("tạo hình ảnh sản phẩm thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func (s *productImportServiceImpl) buildImportImages(productID int64, keys []string, result *importRowResult) ([]*model.Image, error) {
+	images := make([]*model.Image, 0, len(keys))
+	for i, key := range keys {
+		imageID, err := s.sfg.NextID()
+		if err != nil {
+			return nil, err
+		}
+
+		images = append(images, &model.Image{
+			ID:          imageID,
+			PublicID:    key,
+			IsThumbnail: i == 0,
+			SortOrder:   i + 1,
+			Status:      common.ImageStatusPending,
+			ProductID:   productID,
+		})
+		result.uploads = append(result.uploads, &types.UploadImageMessage{
+			ImageID: imageID,
+			Key:     key,
+		})
+	}
+
+	return images, nil
+}
+
+func (s *productImportServiceImpl) downloadImportImages(ctx context.Context, slug string, urls []string) ([]string, error) {
+	maxBytes := s.cfg.Storage.MaxUploadBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxUploadBytes
+	}
+
+	folder := s.cfg.Storage.Folder
+	if folder == "" {
+		folder = defaultUploadFolder
+	}
+
+	keys := make([]string, 0, len(urls))
+	for _, url := range urls {
+		key, err := s.downloadImportImage(ctx, url, slug, folder, maxBytes)
+		if err != nil {
+			s.deleteImportObjects(keys)
+			return nil, fmt.Errorf("tải ảnh %s thất bại: %w", url, err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (s *productImportServiceImpl) downloadImportImage(ctx context.Context, url, slug, folder string, maxBytes int64) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	if !isHTTPURL(req.URL) {
+		return "", customErr.ErrImportImageURLNotAllowed
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("máy chủ trả về mã %d", resp.StatusCode)
+	}
+	if resp.ContentLength > maxBytes {
+		return "", customErr.ErrUploadTooLarge
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(content)) > maxBytes {
+		return "", customErr.ErrUploadTooLarge
+	}
+
+	body, info, err := imaging.Inspect(bytes.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+
+	name := common.GenerateSlug(strings.TrimSuffix(path.Base(req.URL.Path), path.Ext(req.URL.Path)))
+	if name == "" || name == "." {
+		name = slug
+	}
+
+	key := storage.NewObjectKey(folder, name, info.ContentType)
+	if err = s.store.Upload(ctx, key, body, int64(len(content)), info.ContentType); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (s *productImportServiceImpl) deleteImportObjects(keys []string) {
+	for _, key := range keys {
+		body := []byte(key)
+		if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyImageDelete, body); err != nil {
+			log.Printf("đẩy tin nhắn xóa ảnh thất bại: %v", err)
+		}
+	}
+}
+
+func (s *productImportServiceImpl) publishImportImages(result *importRowResult) {
+	for _, req := range result.uploads {
+		body, _ := json.Marshal(req)
+		if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyImageUpload, body); err != nil {
+			log.Printf("đẩy tin nhắn upload ảnh thất bại: %v", err)
+		}
+	}
+	s.deleteImportObjects(result.deleteKeys)
+}
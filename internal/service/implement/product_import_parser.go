@@ -0,0 +1,200 @@
+package implement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+const importImageSeparator = "|"
+
+var importRequiredColumns = []string{"name", "category_slug", "price", "description"}
+
+var importValidator = newImportValidator()
+
+func newImportValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+
+	return validate
+}
+
+func detectImportFormat(fileName string) (string, error) {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".csv":
+		return common.ImportFormatCSV, nil
+	case ".json":
+		return common.ImportFormatJSON, nil
+	}
+
+	return "", customErr.ErrUnsupportedImportFormat
+}
+
+func parseImportRows(format string, content []byte) ([]*types.ImportRow, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	var rows []*types.ImportRow
+	var err error
+	switch format {
+	case common.ImportFormatCSV:
+		rows, err = parseImportCSV(content)
+	case common.ImportFormatJSON:
+		rows, err = parseImportJSON(content)
+	default:
+		return nil, customErr.ErrUnsupportedImportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		row.Data.Name = strings.TrimSpace(row.Data.Name)
+		row.Data.Slug = strings.TrimSpace(row.Data.Slug)
+		row.Data.CategorySlug = strings.TrimSpace(row.Data.CategorySlug)
+		row.Data.Description = strings.TrimSpace(row.Data.Description)
+
+		if err := importValidator.Struct(row.Data); err != nil {
+			var validationErrs validator.ValidationErrors
+			if !errors.As(err, &validationErrs) {
+				row.Errors = append(row.Errors, err.Error())
+				continue
+			}
+			for _, fieldErr := range validationErrs {
+				row.Errors = append(row.Errors, common.HandleValidationError(validator.ValidationErrors{fieldErr}))
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func parseImportCSV(content []byte) ([]*types.ImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, customErr.ErrEmptyImportFile
+		}
+		return nil, customErr.ErrInvalidImportFile
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range importRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, customErr.ErrMissingImportColumn
+		}
+	}
+
+	rows := []*types.ImportRow{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, customErr.ErrInvalidImportFile
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := &types.ImportRow{Line: line}
+		row.Data.Name = cell("name")
+		row.Data.Slug = cell("slug")
+		row.Data.CategorySlug = cell("category_slug")
+		row.Data.Description = cell("description")
+
+		if value := cell("price"); value != "" {
+			price, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				row.Errors = append(row.Errors, "price phải là số")
+			}
+			row.Data.Price = price
+		}
+		if value := cell("quantity"); value != "" {
+			quantity, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				row.Errors = append(row.Errors, "quantity phải là số nguyên không âm")
+			}
+			row.Data.Quantity = uint(quantity)
+		}
+		if value := cell("is_active"); value != "" {
+			isActive, err := strconv.ParseBool(value)
+			if err != nil {
+				row.Errors = append(row.Errors, "is_active phải là true hoặc false")
+			} else {
+				row.Data.IsActive = &isActive
+			}
+		}
+		if value := cell("image_urls"); value != "" {
+			for _, url := range strings.Split(value, importImageSeparator) {
+				if url = strings.TrimSpace(url); url != "" {
+					row.Data.ImageURLs = append(row.Data.ImageURLs, url)
+				}
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseImportJSON(content []byte) ([]*types.ImportRow, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(content, &items); err != nil {
+		return nil, customErr.ErrInvalidImportFile
+	}
+
+	rows := make([]*types.ImportRow, 0, len(items))
+	for i, item := range items {
+		row := &types.ImportRow{Line: i + 1}
+		if err := json.Unmarshal(item, &row.Data); err != nil {
+			row.Errors = append(row.Errors, common.HandleValidationError(err))
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,299 @@
+package implement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/rabbitmq"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+const productIndexBatchSize = 500
+
+type productIndexServiceImpl struct {
+	productRepo      repository.ProductRepository
+	productIndexRepo repository.ProductIndexRepository
+	reindex          *reindexTracker
+}
+
+type reindexTracker struct {
+	mu      sync.Mutex
+	active  bool
+	changed map[int64]bool
+}
+
+func NewProductIndexService(productRepo repository.ProductRepository, productIndexRepo repository.ProductIndexRepository) service.ProductIndexService {
+	return &productIndexServiceImpl{
+		productRepo,
+		productIndexRepo,
+		&reindexTracker{},
+	}
+}
+
+func (t *reindexTracker) start() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.active {
+		return false
+	}
+	t.active = true
+	t.changed = make(map[int64]bool)
+
+	return true
+}
+
+func (t *reindexTracker) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.active = false
+	t.changed = nil
+}
+
+func (t *reindexTracker) track(ids []int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.active {
+		return
+	}
+	for _, id := range ids {
+		t.changed[id] = true
+	}
+}
+
+func (t *reindexTracker) drainLocked() []int64 {
+	ids := make([]int64, 0, len(t.changed))
+	for id := range t.changed {
+		ids = append(ids, id)
+	}
+	t.changed = make(map[int64]bool)
+
+	return ids
+}
+
+func (t *reindexTracker) drain() []int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.drainLocked()
+}
+
+func (s *productIndexServiceImpl) SyncProducts(ctx context.Context, msg types.ProductIndexMessage) error {
+	productIDs := msg.ProductIDs
+	if len(msg.CategoryIDs) > 0 {
+		ids, err := s.productRepo.FindAllIDsByCategoryIDs(ctx, msg.CategoryIDs)
+		if err != nil {
+			return fmt.Errorf("lấy danh sách sản phẩm theo danh mục thất bại: %w", err)
+		}
+		productIDs = append(productIDs, ids...)
+	}
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	s.reindex.track(productIDs)
+
+	if err := s.indexProducts(ctx, common.IndexProducts, productIDs); err != nil {
+		if errors.Is(err, customErr.ErrSearchUnavailable) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *productIndexServiceImpl) indexProducts(ctx context.Context, index string, productIDs []int64) error {
+	products, err := s.productRepo.FindAllForIndex(ctx, productIDs)
+	if err != nil {
+		return fmt.Errorf("lấy danh sách sản phẩm cần đánh chỉ mục thất bại: %w", err)
+	}
+
+	found := make(map[int64]bool, len(products))
+	docs := make([]*types.ProductDocument, 0, len(products))
+	for _, product := range products {
+		found[product.ID] = true
+		docs = append(docs, toProductDocument(product))
+	}
+
+	missingIDs := []int64{}
+	for _, id := range productIDs {
+		if !found[id] {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+
+	if err = s.productIndexRepo.IndexAll(ctx, index, docs); err != nil {
+		if errors.Is(err, customErr.ErrSearchUnavailable) {
+			return err
+		}
+		return fmt.Errorf("đánh chỉ mục sản phẩm thất bại: %w", err)
+	}
+
+	if err = s.productIndexRepo.DeleteAll(ctx, index, missingIDs); err != nil {
+		return fmt.Errorf("xóa chỉ mục sản phẩm thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func (s *productIndexServiceImpl) ReindexProducts(ctx context.Context) (int64, error) {
+	if !s.reindex.start() {
+		return 0, customErr.ErrReindexInProgress
+	}
+	defer s.reindex.stop()
+
+	index := fmt.Sprintf("%s_%d", common.IndexProducts, time.Now().Unix())
+	if err := s.productIndexRepo.CreateIndex(ctx, index); err != nil {
+		return 0, fmt.Errorf("tạo chỉ mục sản phẩm thất bại: %w", err)
+	}
+
+	var total, afterID int64
+	for {
+		ids, err := s.productRepo.FindAllIDsAfter(ctx, afterID, productIndexBatchSize)
+		if err != nil {
+			return 0, fmt.Errorf("lấy danh sách sản phẩm thất bại: %w", err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		products, err := s.productRepo.FindAllForIndex(ctx, ids)
+		if err != nil {
+			return 0, fmt.Errorf("lấy danh sách sản phẩm cần đánh chỉ mục thất bại: %w", err)
+		}
+
+		docs := make([]*types.ProductDocument, 0, len(products))
+		for _, product := range products {
+			docs = append(docs, toProductDocument(product))
+		}
+
+		if err = s.productIndexRepo.IndexAll(ctx, index, docs); err != nil {
+			return 0, fmt.Errorf("đánh chỉ mục sản phẩm thất bại: %w", err)
+		}
+
+		total += int64(len(docs))
+		afterID = ids[len(ids)-1]
+	}
+
+	for ids := s.reindex.drain(); len(ids) > 0; ids = s.reindex.drain() {
+		if err := s.indexProducts(ctx, index, ids); err != nil {
+			return 0, err
+		}
+	}
+
+	oldIndices, err := s.swapReindexedAlias(ctx, index)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = s.productIndexRepo.DeleteIndices(ctx, oldIndices); err != nil {
+		log.Printf("xóa chỉ mục sản phẩm cũ thất bại: %v", err)
+	}
+
+	return total, nil
+}
+
+func (s *productIndexServiceImpl) swapReindexedAlias(ctx context.Context, index string) ([]string, error) {
+	s.reindex.mu.Lock()
+	defer s.reindex.mu.Unlock()
+
+	if ids := s.reindex.drainLocked(); len(ids) > 0 {
+		if err := s.indexProducts(ctx, index, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	oldIndices, err := s.productIndexRepo.SwapAlias(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("chuyển alias chỉ mục sản phẩm thất bại: %w", err)
+	}
+	s.reindex.active = false
+
+	return oldIndices, nil
+}
+
+func (s *productIndexServiceImpl) EnsureProductIndex(ctx context.Context) error {
+	exists, err := s.productIndexRepo.AliasExists(ctx)
+	if err != nil {
+		return fmt.Errorf("kiểm tra chỉ mục sản phẩm thất bại: %w", err)
+	}
+	if exists {
+		upToDate, err := s.productIndexRepo.MappingUpToDate(ctx)
+		if err != nil {
+			return fmt.Errorf("kiểm tra phiên bản chỉ mục sản phẩm thất bại: %w", err)
+		}
+		if upToDate {
+			return nil
+		}
+	}
+
+	if _, err = s.ReindexProducts(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func toProductDocument(product *model.Product) *types.ProductDocument {
+	doc := &types.ProductDocument{
+		ID:            product.ID,
+		Name:          product.Name,
+		Slug:          product.Slug,
+		Description:   product.Description,
+		Price:         product.Price,
+		IsActive:      product.IsActive,
+		Status:        product.Status,
+		PublishAt:     product.PublishAt,
+		UnpublishAt:   product.UnpublishAt,
+		CategoryID:    product.CategoryID,
+		RatingAverage: product.RatingAverage,
+		RatingCount:   product.RatingCount,
+		CreatedAt:     product.CreatedAt,
+		UpdatedAt:     product.UpdatedAt,
+	}
+
+	for i, variant := range product.Variants {
+		price := variant.EffectivePrice(product.Price)
+		if i == 0 || price < doc.Price {
+			doc.Price = price
+		}
+	}
+
+	if product.Category != nil {
+		doc.CategoryName = product.Category.Name
+	}
+	for _, value := range product.Attributes {
+		doc.Attributes = append(doc.Attributes, common.AttributeTerm(value.AttributeID, value.Value))
+	}
+	if product.Inventory != nil {
+		doc.Stock = product.Inventory.Stock
+	}
+	if len(product.Images) > 0 {
+		doc.Thumbnail = product.Images[0].Url
+	}
+
+	return doc
+}
+
+func publishProductIndex(rabbitChan *amqp091.Channel, msg types.ProductIndexMessage) {
+	go func() {
+		body, _ := json.Marshal(msg)
+		if err := rabbitmq.PublishMessage(rabbitChan, common.ExchangeProductSearch, common.RoutingKeyProductIndex, body); err != nil {
+			log.Printf("đẩy tin nhắn đồng bộ chỉ mục sản phẩm thất bại: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,162 @@
+package implement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
+)
+
+const publishScheduleBatchSize = 100
+
+type productPublishing struct {
+	Status      string
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+}
+
+func resolveProductPublishing(current *model.Product, status string, isActive *bool, publishAt, unpublishAt *time.Time) (*productPublishing, error) {
+	publishing := &productPublishing{Status: common.ProductStatusDraft}
+	if current != nil {
+		publishing = &productPublishing{current.Status, current.PublishAt, current.UnpublishAt}
+		if status == "" && isActive == nil && publishAt == nil && unpublishAt == nil {
+			return publishing, nil
+		}
+	}
+
+	switch {
+	case status != "":
+		publishing.Status = status
+	case isActive != nil && *isActive:
+		publishing.Status = common.ProductStatusPublished
+	case isActive != nil:
+		publishing.Status = common.ProductStatusDraft
+	}
+
+	if publishAt != nil {
+		publishing.PublishAt = publishAt
+		if publishAt.IsZero() {
+			publishing.PublishAt = nil
+		}
+	}
+	if unpublishAt != nil {
+		publishing.UnpublishAt = unpublishAt
+		if unpublishAt.IsZero() {
+			publishing.UnpublishAt = nil
+		}
+	}
+
+	now := time.Now()
+	switch publishing.Status {
+	case common.ProductStatusScheduled:
+		if publishing.PublishAt == nil || !publishing.PublishAt.After(now) {
+			return nil, customErr.ErrPublishAtRequired
+		}
+	case common.ProductStatusPublished:
+		if publishing.PublishAt == nil || publishing.PublishAt.After(now) {
+			publishing.PublishAt = &now
+		}
+	default:
+		return publishing, nil
+	}
+
+	if publishing.UnpublishAt != nil && (!publishing.UnpublishAt.After(now) || !publishing.UnpublishAt.After(*publishing.PublishAt)) {
+		return nil, customErr.ErrInvalidUnpublishTime
+	}
+
+	return publishing, nil
+}
+
+func (p *productPublishing) applyTo(product *model.Product, updateData map[string]any) {
+	if p.Status != product.Status {
+		updateData["status"] = p.Status
+		updateData["is_active"] = p.Status == common.ProductStatusPublished
+	}
+	if !sameTime(p.PublishAt, product.PublishAt) {
+		updateData["publish_at"] = p.PublishAt
+	}
+	if !sameTime(p.UnpublishAt, product.UnpublishAt) {
+		updateData["unpublish_at"] = p.UnpublishAt
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+func (s *productServiceImpl) PublishScheduledProducts(ctx context.Context) (int, error) {
+	count := 0
+	for {
+		products, err := s.publishScheduledBatch(ctx)
+		if err != nil {
+			return count, err
+		}
+		if len(products) == 0 {
+			return count, nil
+		}
+
+		productIDs := make([]int64, 0, len(products))
+		for _, product := range products {
+			productIDs = append(productIDs, product.ID)
+			indexSuggestion(s.suggestionRepo, toProductSuggestionDocument(product))
+		}
+		publishProductIndex(s.rabbitChan, types.ProductIndexMessage{ProductIDs: productIDs})
+
+		count += len(products)
+		if len(products) < publishScheduleBatchSize {
+			return count, nil
+		}
+	}
+}
+
+func (s *productServiceImpl) publishScheduledBatch(ctx context.Context) ([]*model.Product, error) {
+	var products []*model.Product
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		var err error
+		products, err = s.productRepo.FindAllDueForPublishTx(ctx, tx, now, publishScheduleBatchSize)
+		if err != nil {
+			return fmt.Errorf("lấy danh sách sản phẩm đến hạn đăng bán thất bại: %w", err)
+		}
+
+		for _, product := range products {
+			before := model.NewProductSnapshot(product)
+
+			status := common.ProductStatusArchived
+			action := common.ProductHistoryActionUnpublish
+			if product.Status == common.ProductStatusScheduled && (product.UnpublishAt == nil || product.UnpublishAt.After(now)) {
+				status = common.ProductStatusPublished
+				action = common.ProductHistoryActionPublish
+			}
+
+			if err = s.productRepo.UpdateTx(ctx, tx, product.ID, map[string]any{
+				"status":    status,
+				"is_active": status == common.ProductStatusPublished,
+			}); err != nil {
+				return fmt.Errorf("cập nhật trạng thái đăng bán sản phẩm thất bại: %w", err)
+			}
+			product.Status = status
+			product.IsActive = status == common.ProductStatusPublished
+
+			if err = writeProductHistoryTx(ctx, tx, s.historyRepo, s.sfg, product.ID, action, nil, before, model.NewProductSnapshot(product)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
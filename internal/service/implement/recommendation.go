@@ -0,0 +1,157 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"gorm.io/gorm"
+)
+
+type recommendationServiceImpl struct {
+	productRepo      repository.ProductRepository
+	productIndexRepo repository.ProductIndexRepository
+	coPurchaseRepo   repository.CoPurchaseRepository
+	db               *gorm.DB
+}
+
+func NewRecommendationService(productRepo repository.ProductRepository, productIndexRepo repository.ProductIndexRepository, coPurchaseRepo repository.CoPurchaseRepository, db *gorm.DB) service.RecommendationService {
+	return &recommendationServiceImpl{
+		productRepo,
+		productIndexRepo,
+		coPurchaseRepo,
+		db,
+	}
+}
+
+func (s *recommendationServiceImpl) GetRelatedProducts(ctx context.Context, productID int64, query request.RelatedProductQuery) ([]*model.Product, []*model.Product, error) {
+	if query.Limit == 0 {
+		query.Limit = 8
+	}
+
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+	}
+	if product == nil || !product.IsActive {
+		return nil, nil, customErr.ErrProductNotFound
+	}
+
+	relatedIDs, err := s.productIndexRepo.MoreLikeThis(ctx, product.ID, product.CategoryID, product.Price, query.Limit)
+	if err != nil {
+		if !errors.Is(err, customErr.ErrSearchUnavailable) {
+			log.Printf("tìm sản phẩm liên quan bằng Elasticsearch thất bại: %v", err)
+		}
+		relatedIDs = nil
+	}
+
+	if len(relatedIDs) < query.Limit {
+		similarIDs, err := s.productRepo.FindAllSimilarIDs(ctx, product, relatedIDs, query.Limit-len(relatedIDs))
+		if err != nil {
+			return nil, nil, fmt.Errorf("lấy danh sách sản phẩm tương tự thất bại: %w", err)
+		}
+		relatedIDs = append(relatedIDs, similarIDs...)
+	}
+
+	related, err := s.findDisplayableProducts(ctx, relatedIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	boughtTogetherIDs, err := s.coPurchaseRepo.FindAllRelatedIDs(ctx, []int64{product.ID}, query.Limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lấy danh sách sản phẩm thường được mua cùng thất bại: %w", err)
+	}
+
+	boughtTogether, err := s.findDisplayableProducts(ctx, boughtTogetherIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return related, boughtTogether, nil
+}
+
+func (s *recommendationServiceImpl) GetCartSuggestions(ctx context.Context, productIDs []int64, limit int) ([]*model.Product, error) {
+	if len(productIDs) == 0 {
+		return []*model.Product{}, nil
+	}
+
+	ids, err := s.coPurchaseRepo.FindAllRelatedIDs(ctx, productIDs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("lấy danh sách sản phẩm thường được mua cùng thất bại: %w", err)
+	}
+
+	if len(ids) < limit {
+		product, err := s.productRepo.FindByID(ctx, productIDs[0])
+		if err != nil {
+			return nil, fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+		}
+
+		if product != nil {
+			excludeIDs := append(append([]int64{}, productIDs...), ids...)
+			similarIDs, err := s.productRepo.FindAllSimilarIDs(ctx, product, excludeIDs, limit-len(ids))
+			if err != nil {
+				return nil, fmt.Errorf("lấy danh sách sản phẩm tương tự thất bại: %w", err)
+			}
+			ids = append(ids, similarIDs...)
+		}
+	}
+
+	return s.findDisplayableProducts(ctx, ids)
+}
+
+func (s *recommendationServiceImpl) RebuildCoPurchases(ctx context.Context) (int64, error) {
+	var total int64
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.coPurchaseRepo.DeleteAllTx(ctx, tx); err != nil {
+			return fmt.Errorf("xóa dữ liệu sản phẩm mua cùng thất bại: %w", err)
+		}
+
+		rows, err := s.coPurchaseRepo.CreateAllFromOrdersTx(ctx, tx, []string{common.OrderStatusConfirmed, common.OrderStatusShipped, common.OrderStatusDelivered})
+		if err != nil {
+			return fmt.Errorf("tính toán dữ liệu sản phẩm mua cùng thất bại: %w", err)
+		}
+		total = rows
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (s *recommendationServiceImpl) findDisplayableProducts(ctx context.Context, ids []int64) ([]*model.Product, error) {
+	if len(ids) == 0 {
+		return []*model.Product{}, nil
+	}
+
+	products, err := s.productRepo.FindAllByIDWithImages(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin danh sách sản phẩm thất bại: %w", err)
+	}
+
+	productMap := make(map[int64]*model.Product, len(products))
+	for _, product := range products {
+		productMap[product.ID] = product
+	}
+
+	result := make([]*model.Product, 0, len(ids))
+	for _, id := range ids {
+		product, ok := productMap[id]
+		if !ok || !product.IsActive || len(product.Images) == 0 {
+			continue
+		}
+		result = append(result, product)
+		delete(productMap, id)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,270 @@
+package implement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/rabbitmq"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/response"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/snowflake"
+	"github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
+)
+
+type reviewServiceImpl struct {
+	reviewRepo  repository.ReviewRepository
+	productRepo repository.ProductRepository
+	orderRepo   repository.OrderRepository
+	uploadRepo  repository.UploadRepository
+	db          *gorm.DB
+	rabbitChan  *amqp091.Channel
+	sfg         snowflake.SnowflakeGenerator
+}
+
+func NewReviewService(reviewRepo repository.ReviewRepository, productRepo repository.ProductRepository, orderRepo repository.OrderRepository, uploadRepo repository.UploadRepository, db *gorm.DB, rabbitChan *amqp091.Channel, sfg snowflake.SnowflakeGenerator) service.ReviewService {
+	return &reviewServiceImpl{
+		reviewRepo,
+		productRepo,
+		orderRepo,
+		uploadRepo,
+		db,
+		rabbitChan,
+		sfg,
+	}
+}
+
+func (s *reviewServiceImpl) GetProductReviews(ctx context.Context, productID int64, query request.ReviewPaginationQuery) ([]*model.Review, *response.MetaResponse, error) {
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+	}
+	if product == nil || !product.IsActive {
+		return nil, nil, customErr.ErrProductNotFound
+	}
+
+	query.ProductID = productID
+	query.Status = common.ReviewStatusApproved
+
+	return s.GetReviews(ctx, query)
+}
+
+func (s *reviewServiceImpl) GetReviews(ctx context.Context, query request.ReviewPaginationQuery) ([]*model.Review, *response.MetaResponse, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	reviews, total, err := s.reviewRepo.FindAll(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lấy danh sách đánh giá thất bại: %w", err)
+	}
+
+	totalPages := (total + int64(query.Limit) - 1) / int64(query.Limit)
+	meta := &response.MetaResponse{
+		Total:      total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: totalPages,
+		HasPrev:    query.Page > 1,
+		HasNext:    int64(query.Page) < totalPages,
+	}
+
+	return reviews, meta, nil
+}
+
+func (s *reviewServiceImpl) CreateReview(ctx context.Context, userID, productID int64, req *request.CreateReviewForm) (*model.Review, error) {
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin sản phẩm thất bại: %w", err)
+	}
+	if product == nil || !product.IsActive {
+		return nil, customErr.ErrProductNotFound
+	}
+
+	delivered, err := s.orderRepo.ExistsDeliveredItem(ctx, userID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("kiểm tra lịch sử mua hàng thất bại: %w", err)
+	}
+	if !delivered {
+		return nil, customErr.ErrReviewNotAllowed
+	}
+
+	exists, err := s.reviewRepo.ExistsByProductIDAndUserID(ctx, productID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("kiểm tra đánh giá thất bại: %w", err)
+	}
+	if exists {
+		return nil, customErr.ErrReviewAlreadyExists
+	}
+
+	reviewID, err := s.sfg.NextID()
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]*model.ReviewImage, 0, len(req.Images))
+	uploads := make([]*types.UploadImageMessage, 0, len(req.Images))
+	keys := make([]string, 0, len(req.Images))
+	for _, img := range req.Images {
+		imageID, err := s.sfg.NextID()
+		if err != nil {
+			return nil, err
+		}
+
+		images = append(images, &model.ReviewImage{
+			ID:        imageID,
+			PublicID:  img.Key,
+			SortOrder: img.SortOrder,
+		})
+		uploads = append(uploads, &types.UploadImageMessage{
+			ImageID: imageID,
+			Key:     img.Key,
+		})
+		keys = append(keys, img.Key)
+	}
+
+	newReview := &model.Review{
+		ID:        reviewID,
+		Rating:    req.Rating,
+		Title:     req.Title,
+		Body:      req.Body,
+		Status:    common.ReviewStatusPending,
+		ProductID: productID,
+		UserID:    userID,
+		Images:    images,
+	}
+
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := attachUploadsTx(ctx, tx, s.uploadRepo, keys, userID); err != nil {
+			return err
+		}
+
+		if err := s.reviewRepo.CreateTx(ctx, tx, newReview); err != nil {
+			if common.IsUniqueViolation(err) {
+				return customErr.ErrReviewAlreadyExists
+			}
+			return fmt.Errorf("tạo đánh giá thất bại: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for _, upload := range uploads {
+			body, _ := json.Marshal(upload)
+			if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyReviewImageUpload, body); err != nil {
+				log.Printf("đẩy tin nhắn upload ảnh đánh giá thất bại: %v", err)
+			}
+		}
+	}()
+
+	createdReview, err := s.reviewRepo.FindByIDWithDetails(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin đánh giá thất bại: %w", err)
+	}
+	if createdReview == nil {
+		return nil, customErr.ErrReviewNotFound
+	}
+
+	return createdReview, nil
+}
+
+func (s *reviewServiceImpl) ModerateReview(ctx context.Context, id int64, req request.ModerateReviewRequest) (*model.Review, error) {
+	review, err := s.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin đánh giá thất bại: %w", err)
+	}
+	if review == nil {
+		return nil, customErr.ErrReviewNotFound
+	}
+
+	if review.Status != req.Status {
+		if err = s.db.Transaction(func(tx *gorm.DB) error {
+			if err := s.reviewRepo.UpdateStatusTx(ctx, tx, id, req.Status); err != nil {
+				if err == customErr.ErrReviewNotFound {
+					return err
+				}
+				return fmt.Errorf("cập nhật trạng thái đánh giá thất bại: %w", err)
+			}
+
+			if err := s.productRepo.UpdateRatingTx(ctx, tx, review.ProductID); err != nil {
+				return fmt.Errorf("cập nhật điểm đánh giá sản phẩm thất bại: %w", err)
+			}
+
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		publishProductIndex(s.rabbitChan, types.ProductIndexMessage{ProductIDs: []int64{review.ProductID}})
+	}
+
+	updatedReview, err := s.reviewRepo.FindByIDWithDetails(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin đánh giá thất bại: %w", err)
+	}
+	if updatedReview == nil {
+		return nil, customErr.ErrReviewNotFound
+	}
+
+	return updatedReview, nil
+}
+
+func (s *reviewServiceImpl) DeleteReview(ctx context.Context, id int64) error {
+	review, err := s.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("lấy thông tin đánh giá thất bại: %w", err)
+	}
+	if review == nil {
+		return customErr.ErrReviewNotFound
+	}
+
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.reviewRepo.DeleteTx(ctx, tx, id); err != nil {
+			if err == customErr.ErrReviewNotFound {
+				return err
+			}
+			return fmt.Errorf("xóa đánh giá thất bại: %w", err)
+		}
+
+		if review.Status == common.ReviewStatusApproved {
+			if err := s.productRepo.UpdateRatingTx(ctx, tx, review.ProductID); err != nil {
+				return fmt.Errorf("cập nhật điểm đánh giá sản phẩm thất bại: %w", err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go func() {
+		for _, img := range review.Images {
+			if img.PublicID == "" {
+				continue
+			}
+			if err := rabbitmq.PublishMessage(s.rabbitChan, common.ExchangeImage, common.RoutingKeyImageDelete, []byte(img.PublicID)); err != nil {
+				log.Printf("đẩy tin nhắn xóa ảnh đánh giá thất bại: %v", err)
+			}
+		}
+	}()
+
+	if review.Status == common.ReviewStatusApproved {
+		publishProductIndex(s.rabbitChan, types.ProductIndexMessage{ProductIDs: []int64{review.ProductID}})
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+func toProductSuggestionDocument(product *model.Product) *types.SuggestionDocument {
+	return &types.SuggestionDocument{
+		ID:       product.ID,
+		Type:     common.SuggestionTypeProduct,
+		Name:     product.Name,
+		Slug:     product.Slug,
+		IsActive: product.IsActive,
+	}
+}
+
+func toCategorySuggestionDocument(category *model.Category) *types.SuggestionDocument {
+	return &types.SuggestionDocument{
+		ID:       category.ID,
+		Type:     common.SuggestionTypeCategory,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		IsActive: true,
+	}
+}
+
+func indexSuggestion(suggestionRepo repository.SuggestionRepository, doc *types.SuggestionDocument) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := suggestionRepo.Index(ctx, doc); err != nil && !errors.Is(err, customErr.ErrSearchUnavailable) {
+			log.Printf("đánh chỉ mục gợi ý %s %d thất bại: %v", doc.Type, doc.ID, err)
+		}
+	}()
+}
+
+func deleteSuggestions(suggestionRepo repository.SuggestionRepository, docType string, ids []int64) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := suggestionRepo.DeleteAll(ctx, docType, ids); err != nil && !errors.Is(err, customErr.ErrSearchUnavailable) {
+			log.Printf("xóa chỉ mục gợi ý %s thất bại: %v", docType, err)
+		}
+	}()
+}
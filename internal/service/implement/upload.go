@@ -0,0 +1,340 @@
+package implement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	"github.com/tienhai2808/ecom_go/internal/config"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/imaging"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/repository"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/service"
+	"github.com/tienhai2808/ecom_go/internal/snowflake"
+	"github.com/tienhai2808/ecom_go/internal/storage"
+	"github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultUploadFolder    = "ecom_go/product"
+	defaultMaxUploadBytes  = 10 << 20
+	defaultUploadExpiryMin = 15
+	reapUploadBatchSize    = 100
+)
+
+type uploadServiceImpl struct {
+	uploadRepo repository.UploadRepository
+	store      storage.Storage
+	cfg        *config.Config
+	sfg        snowflake.SnowflakeGenerator
+}
+
+func NewUploadService(uploadRepo repository.UploadRepository, store storage.Storage, cfg *config.Config, sfg snowflake.SnowflakeGenerator) service.UploadService {
+	return &uploadServiceImpl{
+		uploadRepo,
+		store,
+		cfg,
+		sfg,
+	}
+}
+
+func (s *uploadServiceImpl) CreateUploadSlots(ctx context.Context, userID int64, req request.CreateUploadSlotsRequest) ([]*types.UploadTarget, error) {
+	maxBytes := s.maxUploadBytes()
+
+	expiry := time.Duration(s.cfg.Storage.UploadExpiryMinutes) * time.Minute
+	if expiry <= 0 {
+		expiry = defaultUploadExpiryMin * time.Minute
+	}
+
+	folder := s.cfg.Storage.Folder
+	if folder == "" {
+		folder = defaultUploadFolder
+	}
+
+	expiresAt := time.Now().Add(expiry)
+	slots := make([]*model.UploadSlot, 0, len(req.Files))
+	for _, file := range req.Files {
+		if file.Size > maxBytes {
+			return nil, customErr.ErrUploadTooLarge
+		}
+
+		slotID, err := s.sfg.NextID()
+		if err != nil {
+			return nil, err
+		}
+
+		name := common.GenerateSlug(strings.TrimSuffix(file.FileName, path.Ext(file.FileName)))
+		if name == "" {
+			name = "file"
+		}
+
+		slots = append(slots, &model.UploadSlot{
+			ID:          slotID,
+			Key:         storage.NewObjectKey(folder, name, file.ContentType),
+			FileName:    file.FileName,
+			ContentType: file.ContentType,
+			Size:        file.Size,
+			Status:      common.UploadStatusPending,
+			ExpiresAt:   expiresAt,
+			UserID:      userID,
+		})
+	}
+
+	targets := make([]*types.UploadTarget, 0, len(slots))
+	for _, slot := range slots {
+		url, err := s.store.PresignUpload(ctx, slot.Key, slot.ContentType, expiry)
+		if err != nil {
+			if !errors.Is(err, storage.ErrPresignNotSupported) {
+				return nil, fmt.Errorf("tạo URL tải lên thất bại: %w", err)
+			}
+			url = s.cfg.App.ApiPrefix + "/uploads/" + strconv.FormatInt(slot.ID, 10)
+		}
+
+		targets = append(targets, &types.UploadTarget{
+			Slot:    slot,
+			Method:  "PUT",
+			URL:     url,
+			Headers: map[string]string{"Content-Type": slot.ContentType},
+		})
+	}
+
+	if err := s.uploadRepo.CreateAll(ctx, slots); err != nil {
+		return nil, fmt.Errorf("tạo phiên tải lên thất bại: %w", err)
+	}
+
+	return targets, nil
+}
+
+func (s *uploadServiceImpl) UploadContent(ctx context.Context, userID, id int64, body io.Reader, size int64, contentType string) (*model.UploadSlot, error) {
+	slot, err := s.findPendingSlot(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if size != slot.Size {
+		return nil, customErr.ErrUploadSizeMismatch
+	}
+	if contentType != slot.ContentType {
+		return nil, customErr.ErrImageTypeMismatch
+	}
+
+	if err = s.store.Upload(ctx, slot.Key, body, slot.Size, slot.ContentType); err != nil {
+		return nil, fmt.Errorf("tải file lên thất bại: %w", err)
+	}
+
+	if err = s.uploadRepo.UpdateStatus(ctx, slot.ID, common.UploadStatusUploaded); err != nil {
+		return nil, fmt.Errorf("cập nhật phiên tải lên thất bại: %w", err)
+	}
+	slot.Status = common.UploadStatusUploaded
+
+	return slot, nil
+}
+
+func (s *uploadServiceImpl) ConfirmUpload(ctx context.Context, userID, id int64) (*model.UploadSlot, error) {
+	slot, err := s.uploadRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin phiên tải lên thất bại: %w", err)
+	}
+	if slot == nil || slot.UserID != userID {
+		return nil, customErr.ErrUploadNotFound
+	}
+	if slot.Status != common.UploadStatusPending {
+		return slot, nil
+	}
+
+	object, err := s.store.Stat(ctx, slot.Key)
+	if err != nil {
+		return nil, fmt.Errorf("kiểm tra file tải lên thất bại: %w", err)
+	}
+	if time.Now().After(slot.ExpiresAt) {
+		if object != nil {
+			s.deleteRejectedUpload(ctx, slot.Key)
+		}
+		return nil, customErr.ErrUploadExpired
+	}
+	if object == nil {
+		return nil, customErr.ErrUploadNotReady
+	}
+
+	if err = s.verifyUploadedObject(ctx, slot, object); err != nil {
+		s.deleteRejectedUpload(ctx, slot.Key)
+		return nil, err
+	}
+
+	if err = s.uploadRepo.UpdateStatus(ctx, slot.ID, common.UploadStatusUploaded); err != nil {
+		return nil, fmt.Errorf("cập nhật phiên tải lên thất bại: %w", err)
+	}
+	slot.Status = common.UploadStatusUploaded
+
+	return slot, nil
+}
+
+func (s *uploadServiceImpl) ReapExpiredUploads(ctx context.Context, before time.Time) (int64, error) {
+	slots, err := s.uploadRepo.FindAllExpired(ctx, before, reapUploadBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("lấy danh sách phiên tải lên hết hạn thất bại: %w", err)
+	}
+	if len(slots) == 0 {
+		return 0, nil
+	}
+
+	slotIDs := make([]int64, 0, len(slots))
+	for _, slot := range slots {
+		if err = s.store.Delete(ctx, slot.Key); err != nil {
+			log.Printf("xóa file tải lên %s thất bại: %v", slot.Key, err)
+			continue
+		}
+		slotIDs = append(slotIDs, slot.ID)
+	}
+	if len(slotIDs) == 0 {
+		return 0, nil
+	}
+
+	count, err := s.uploadRepo.DeleteAllByID(ctx, slotIDs)
+	if err != nil {
+		return 0, fmt.Errorf("xóa phiên tải lên hết hạn thất bại: %w", err)
+	}
+
+	return count, nil
+}
+
+func (s *uploadServiceImpl) verifyUploadedObject(ctx context.Context, slot *model.UploadSlot, object *storage.ObjectInfo) error {
+	if object.Size > s.maxUploadBytes() {
+		return customErr.ErrUploadTooLarge
+	}
+	if object.Size != slot.Size {
+		return customErr.ErrUploadSizeMismatch
+	}
+
+	file, err := s.store.Open(ctx, slot.Key)
+	if err != nil {
+		return fmt.Errorf("đọc file tải lên thất bại: %w", err)
+	}
+	defer file.Close()
+
+	_, info, err := imaging.Inspect(file)
+	if err != nil {
+		return err
+	}
+	if info.ContentType != slot.ContentType {
+		return customErr.ErrImageTypeMismatch
+	}
+
+	return nil
+}
+
+func (s *uploadServiceImpl) deleteRejectedUpload(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil {
+		log.Printf("xóa file tải lên %s không hợp lệ thất bại: %v", key, err)
+	}
+}
+
+func (s *uploadServiceImpl) maxUploadBytes() int64 {
+	if s.cfg.Storage.MaxUploadBytes <= 0 {
+		return defaultMaxUploadBytes
+	}
+
+	return s.cfg.Storage.MaxUploadBytes
+}
+
+func (s *uploadServiceImpl) findPendingSlot(ctx context.Context, userID, id int64) (*model.UploadSlot, error) {
+	slot, err := s.uploadRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("lấy thông tin phiên tải lên thất bại: %w", err)
+	}
+	if slot == nil || slot.UserID != userID {
+		return nil, customErr.ErrUploadNotFound
+	}
+	if slot.Status != common.UploadStatusPending {
+		return nil, customErr.ErrUploadNotReady
+	}
+	if time.Now().After(slot.ExpiresAt) {
+		return nil, customErr.ErrUploadExpired
+	}
+
+	return slot, nil
+}
+
+func attachUploadsTx(ctx context.Context, tx *gorm.DB, uploadRepo repository.UploadRepository, keys []string, userID int64) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	slots, err := uploadRepo.FindAllByKeysTx(ctx, tx, keys)
+	if err != nil {
+		return fmt.Errorf("lấy danh sách file tải lên thất bại: %w", err)
+	}
+	if len(slots) != len(keys) {
+		return customErr.ErrUploadNotReady
+	}
+
+	for _, slot := range slots {
+		if slot.Status != common.UploadStatusUploaded || (userID != 0 && slot.UserID != userID) {
+			return customErr.ErrUploadNotReady
+		}
+	}
+
+	if err = uploadRepo.UpdateStatusByKeysTx(ctx, tx, keys, common.UploadStatusAttached); err != nil {
+		return fmt.Errorf("cập nhật trạng thái file tải lên thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func (s *productServiceImpl) attachImagesTx(ctx context.Context, tx *gorm.DB, images []*model.Image) error {
+	keys := make([]string, 0, len(images))
+	assetIDs := []int64{}
+	for _, image := range images {
+		if image.AssetID != nil {
+			assetIDs = append(assetIDs, *image.AssetID)
+			continue
+		}
+		keys = append(keys, image.PublicID)
+	}
+
+	if len(assetIDs) > 0 {
+		assets, err := s.mediaRepo.FindAllByIDTx(ctx, tx, assetIDs)
+		if err != nil {
+			return fmt.Errorf("lấy danh sách tài nguyên thư viện ảnh thất bại: %w", err)
+		}
+
+		assetMap := make(map[int64]*model.MediaAsset, len(assets))
+		for _, asset := range assets {
+			assetMap[asset.ID] = asset
+		}
+
+		for _, image := range images {
+			if image.AssetID == nil {
+				continue
+			}
+
+			asset, ok := assetMap[*image.AssetID]
+			if !ok {
+				return customErr.ErrHasMediaAssetNotFound
+			}
+			if asset.Status != common.ImageStatusReady {
+				return customErr.ErrMediaAssetNotReady
+			}
+
+			image.PublicID = asset.Key
+			image.Url = asset.Url
+			image.Width = asset.Width
+			image.Height = asset.Height
+			image.PlaceholderColor = asset.PlaceholderColor
+			image.Srcset = asset.Srcset
+			image.Status = common.ImageStatusReady
+		}
+	}
+
+	return attachUploadsTx(ctx, tx, s.uploadRepo, keys, 0)
+}
@@ -2,9 +2,11 @@ package implement
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
+	"github.com/tienhai2808/ecom_go/internal/common"
 	customErr "github.com/tienhai2808/ecom_go/internal/errors"
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"github.com/tienhai2808/ecom_go/internal/repository"
@@ -12,18 +14,24 @@ import (
 	"github.com/tienhai2808/ecom_go/internal/security"
 	"github.com/tienhai2808/ecom_go/internal/service"
 	"github.com/tienhai2808/ecom_go/internal/snowflake"
+	"github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
 )
 
 type userServiceImpl struct {
-	userRepo    repository.UserRepository
-	profileRepo repository.ProfileRepository
-	sfg         snowflake.SnowflakeGenerator
+	db           *gorm.DB
+	userRepo     repository.UserRepository
+	profileRepo  repository.ProfileRepository
+	auditLogRepo repository.AuditLogRepository
+	sfg          snowflake.SnowflakeGenerator
 }
 
-func NewUserService(userRepo repository.UserRepository, profileRepo repository.ProfileRepository, sfg snowflake.SnowflakeGenerator) service.UserService {
+func NewUserService(db *gorm.DB, userRepo repository.UserRepository, profileRepo repository.ProfileRepository, auditLogRepo repository.AuditLogRepository, sfg snowflake.SnowflakeGenerator) service.UserService {
 	return &userServiceImpl{
+		db,
 		userRepo,
 		profileRepo,
+		auditLogRepo,
 		sfg,
 	}
 }
@@ -50,7 +58,29 @@ func (s *userServiceImpl) GetUserByID(ctx context.Context, id int64) (*model.Use
 	return user, nil
 }
 
-func (s *userServiceImpl) CreateUser(ctx context.Context, req request.CreateUserRequest) (*model.User, error) {
+func (s *userServiceImpl) GetUserAuditLogs(ctx context.Context, id int64) ([]*model.AuditLog, error) {
+	exists, err := s.userRepo.ExistsByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("kiểm tra người dùng tồn tại thất bại: %w", err)
+	}
+
+	auditLogs, err := s.auditLogRepo.FindAllByEntity(ctx, common.AuditEntityUser, id)
+	if err != nil {
+		return nil, fmt.Errorf("lấy nhật ký thay đổi người dùng thất bại: %w", err)
+	}
+
+	if !exists && len(auditLogs) == 0 {
+		return nil, customErr.ErrUserNotFound
+	}
+
+	return auditLogs, nil
+}
+
+func (s *userServiceImpl) CreateUser(ctx context.Context, currentUser *types.UserData, req request.CreateUserRequest) (*model.User, error) {
+	if req.Role == common.RoleAdmin && currentUser.Role != common.RoleAdmin {
+		return nil, customErr.ErrAdminOnly
+	}
+
 	exists, err := s.userRepo.ExistsByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, fmt.Errorf("kiểm tra người dùng tồn tại thất bại: %w", err)
@@ -97,14 +127,24 @@ func (s *userServiceImpl) CreateUser(ctx context.Context, req request.CreateUser
 		},
 	}
 
-	if err = s.userRepo.Create(ctx, newUser); err != nil {
-		return nil, fmt.Errorf("tạo người dùng thất bại: %w", err)
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.userRepo.CreateTx(ctx, tx, newUser); err != nil {
+			return fmt.Errorf("tạo người dùng thất bại: %w", err)
+		}
+
+		return s.writeAuditLogTx(ctx, tx, currentUser.ID, common.AuditActionUserCreate, newUser.ID, map[string]any{
+			"username": newUser.Username,
+			"email":    newUser.Email,
+			"role":     newUser.Role,
+		})
+	}); err != nil {
+		return nil, err
 	}
 
 	return newUser, nil
 }
 
-func (s *userServiceImpl) UpdateUser(ctx context.Context, id int64, req *request.UpdateUserRequest) (*model.User, error) {
+func (s *userServiceImpl) UpdateUser(ctx context.Context, currentUser *types.UserData, id int64, req *request.UpdateUserRequest) (*model.User, error) {
 	user, err := s.userRepo.FindByIDWithProfile(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("lấy thông tin người dùng thất bại: %w", err)
@@ -113,6 +153,10 @@ func (s *userServiceImpl) UpdateUser(ctx context.Context, id int64, req *request
 		return nil, customErr.ErrUserNotFound
 	}
 
+	if currentUser.Role != common.RoleAdmin && (user.Role == common.RoleAdmin || (req.Role != nil && *req.Role == common.RoleAdmin)) {
+		return nil, customErr.ErrAdminOnly
+	}
+
 	updateUserData := map[string]any{}
 	if req.Username != nil && *req.Username != user.Username {
 		exists, err := s.userRepo.ExistsByUsername(ctx, *req.Username)
@@ -141,7 +185,14 @@ func (s *userServiceImpl) UpdateUser(ctx context.Context, id int64, req *request
 		}
 		updateUserData["password"] = hashedPw
 	}
+	demotingAdmin := false
 	if req.Role != nil && *req.Role != user.Role {
+		if user.Role == common.RoleAdmin {
+			if user.ID == currentUser.ID {
+				return nil, customErr.ErrDemoteSelf
+			}
+			demotingAdmin = user.DeactivatedAt == nil
+		}
 		updateUserData["role"] = *req.Role
 	}
 
@@ -162,21 +213,56 @@ func (s *userServiceImpl) UpdateUser(ctx context.Context, id int64, req *request
 		updateProfileData["gender"] = *req.Gender
 	}
 
-	if len(updateUserData) > 0 {
-		if err := s.userRepo.Update(ctx, user.ID, updateUserData); err != nil {
-			if errors.Is(err, customErr.ErrUserNotFound) {
-				return nil, err
+	if len(updateUserData) > 0 || len(updateProfileData) > 0 {
+		changes := map[string]any{}
+		for field, value := range updateUserData {
+			switch field {
+			case "password":
+				changes[field] = map[string]any{"changed": true}
+			case "username":
+				changes[field] = map[string]any{"old": user.Username, "new": value}
+			case "email":
+				changes[field] = map[string]any{"old": user.Email, "new": value}
+			case "role":
+				changes[field] = map[string]any{"old": user.Role, "new": value}
 			}
-			return nil, fmt.Errorf("cập nhật người dùng thất bại: %w", err)
 		}
-	}
+		for field, value := range updateProfileData {
+			changes["profile."+field] = map[string]any{"new": value}
+		}
+
+		if err := s.db.Transaction(func(tx *gorm.DB) error {
+			if demotingAdmin {
+				adminCount, err := s.userRepo.CountByRoleForUpdateTx(ctx, tx, common.RoleAdmin)
+				if err != nil {
+					return fmt.Errorf("đếm số lượng admin thất bại: %w", err)
+				}
+				if adminCount <= 1 {
+					return customErr.ErrLastAdmin
+				}
+			}
+
+			if len(updateUserData) > 0 {
+				if err := s.userRepo.UpdateTx(ctx, tx, user.ID, updateUserData); err != nil {
+					if errors.Is(err, customErr.ErrUserNotFound) {
+						return err
+					}
+					return fmt.Errorf("cập nhật người dùng thất bại: %w", err)
+				}
+			}
 
-	if len(updateProfileData) > 0 {
-		if err := s.profileRepo.Update(ctx, user.Profile.ID, updateProfileData); err != nil {
-			if errors.Is(err, customErr.ErrProfileNotFound) {
-				return nil, err
+			if len(updateProfileData) > 0 {
+				if err := s.profileRepo.UpdateTx(ctx, tx, user.Profile.ID, updateProfileData); err != nil {
+					if errors.Is(err, customErr.ErrProfileNotFound) {
+						return err
+					}
+					return fmt.Errorf("cập nhật thông tin người dùng thất bại: %w", err)
+				}
 			}
-			return nil, fmt.Errorf("cập nhật thông tin người dùng thất bại: %w", err)
+
+			return s.writeAuditLogTx(ctx, tx, currentUser.ID, common.AuditActionUserUpdate, user.ID, changes)
+		}); err != nil {
+			return nil, err
 		}
 	}
 
@@ -192,23 +278,59 @@ func (s *userServiceImpl) UpdateUser(ctx context.Context, id int64, req *request
 	return updatedUser, nil
 }
 
-func (s *userServiceImpl) DeleteUser(ctx context.Context, id int64) error {
-	if err := s.userRepo.Delete(ctx, id); err != nil {
-		if errors.Is(err, customErr.ErrUserNotFound) {
-			return err
+func (s *userServiceImpl) DeleteUser(ctx context.Context, currentUser *types.UserData, id int64) error {
+	if id == currentUser.ID {
+		return customErr.ErrUserConflict
+	}
+
+	user, err := s.userRepo.FindByIDWithProfile(ctx, id)
+	if err != nil {
+		return fmt.Errorf("lấy thông tin người dùng thất bại: %w", err)
+	}
+	if user == nil {
+		return customErr.ErrUserNotFound
+	}
+
+	if user.Role == common.RoleAdmin && currentUser.Role != common.RoleAdmin {
+		return customErr.ErrAdminOnly
+	}
+
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		if user.Role == common.RoleAdmin && user.DeactivatedAt == nil {
+			adminCount, err := s.userRepo.CountByRoleForUpdateTx(ctx, tx, common.RoleAdmin)
+			if err != nil {
+				return fmt.Errorf("đếm số lượng admin thất bại: %w", err)
+			}
+			if adminCount <= 1 {
+				return customErr.ErrLastAdmin
+			}
+		}
+
+		if err := s.userRepo.DeleteTx(ctx, tx, id); err != nil {
+			if errors.Is(err, customErr.ErrUserNotFound) {
+				return err
+			}
+			return fmt.Errorf("xóa người dùng thất bại: %w", err)
 		}
-		return fmt.Errorf("xóa người dùng thất bại: %w", err)
+
+		return s.writeAuditLogTx(ctx, tx, currentUser.ID, common.AuditActionUserDelete, user.ID, map[string]any{
+			"username": user.Username,
+			"email":    user.Email,
+			"role":     user.Role,
+		})
+	}); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-func (s *userServiceImpl) DeleteUsers(ctx context.Context, currentUserID int64, req request.DeleteManyRequest) (int64, error) {
+func (s *userServiceImpl) DeleteUsers(ctx context.Context, currentUser *types.UserData, req request.DeleteManyRequest) (int64, error) {
 	userIDs := req.IDs
 	filteredUserIDs := []int64{}
 
 	for _, id := range userIDs {
-		if id != currentUserID {
+		if id != currentUser.ID {
 			filteredUserIDs = append(filteredUserIDs, id)
 		}
 	}
@@ -217,10 +339,83 @@ func (s *userServiceImpl) DeleteUsers(ctx context.Context, currentUserID int64,
 		return 0, customErr.ErrUserConflict
 	}
 
-	rowsAffected, err := s.userRepo.DeleteAllByID(ctx, filteredUserIDs)
+	users, err := s.userRepo.FindAllByID(ctx, filteredUserIDs)
 	if err != nil {
-		return 0, fmt.Errorf("xóa người dùng thất bại: %w", err)
+		return 0, fmt.Errorf("lấy danh sách người dùng cần xóa thất bại: %w", err)
+	}
+
+	var deletingAdmins, deletingActiveAdmins int64
+	for _, user := range users {
+		if user.Role == common.RoleAdmin {
+			deletingAdmins++
+			if user.DeactivatedAt == nil {
+				deletingActiveAdmins++
+			}
+		}
+	}
+
+	if deletingAdmins > 0 && currentUser.Role != common.RoleAdmin {
+		return 0, customErr.ErrAdminOnly
+	}
+
+	var rowsAffected int64
+	if err = s.db.Transaction(func(tx *gorm.DB) error {
+		if deletingActiveAdmins > 0 {
+			adminCount, err := s.userRepo.CountByRoleForUpdateTx(ctx, tx, common.RoleAdmin)
+			if err != nil {
+				return fmt.Errorf("đếm số lượng admin thất bại: %w", err)
+			}
+			if adminCount-deletingActiveAdmins < 1 {
+				return customErr.ErrLastAdmin
+			}
+		}
+
+		rowsAffected, err = s.userRepo.DeleteAllByIDTx(ctx, tx, filteredUserIDs)
+		if err != nil {
+			return fmt.Errorf("xóa người dùng thất bại: %w", err)
+		}
+
+		for _, user := range users {
+			if err = s.writeAuditLogTx(ctx, tx, currentUser.ID, common.AuditActionUserDelete, user.ID, map[string]any{
+				"username": user.Username,
+				"email":    user.Email,
+				"role":     user.Role,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return 0, err
 	}
 
 	return rowsAffected, nil
 }
+
+func (s *userServiceImpl) writeAuditLogTx(ctx context.Context, tx *gorm.DB, actorID int64, action string, entityID int64, changes map[string]any) error {
+	auditLogID, err := s.sfg.NextID()
+	if err != nil {
+		return fmt.Errorf("tạo ID nhật ký thay đổi thất bại: %w", err)
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("mã hóa nhật ký thay đổi thất bại: %w", err)
+	}
+
+	auditLog := &model.AuditLog{
+		ID:         auditLogID,
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: common.AuditEntityUser,
+		EntityID:   entityID,
+		Changes:    string(changesJSON),
+	}
+
+	if err = s.auditLogRepo.CreateTx(ctx, tx, auditLog); err != nil {
+		return fmt.Errorf("ghi nhật ký thay đổi người dùng thất bại: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,383 @@
+package implement
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tienhai2808/ecom_go/internal/common"
+	customErr "github.com/tienhai2808/ecom_go/internal/errors"
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/types"
+	"gorm.io/gorm"
+)
+
+func (s *productServiceImpl) buildOptions(productID int64, forms []request.CreateProductOptionForm) ([]*model.ProductOption, error) {
+	options := make([]*model.ProductOption, 0, len(forms))
+	seen := make(map[string]bool, len(forms))
+
+	for i, form := range forms {
+		name := strings.TrimSpace(form.Name)
+		if seen[strings.ToLower(name)] {
+			return nil, customErr.ErrInvalidVariantOptions
+		}
+		seen[strings.ToLower(name)] = true
+
+		optionID, err := s.sfg.NextID()
+		if err != nil {
+			return nil, err
+		}
+
+		option := &model.ProductOption{
+			ID:        optionID,
+			Name:      name,
+			Position:  i + 1,
+			ProductID: productID,
+		}
+
+		seenValues := make(map[string]bool, len(form.Values))
+		for j, value := range form.Values {
+			value = strings.TrimSpace(value)
+			if seenValues[strings.ToLower(value)] {
+				continue
+			}
+			seenValues[strings.ToLower(value)] = true
+
+			valueID, err := s.sfg.NextID()
+			if err != nil {
+				return nil, err
+			}
+
+			option.Values = append(option.Values, &model.ProductOptionValue{
+				ID:       valueID,
+				Value:    value,
+				Position: j + 1,
+				OptionID: optionID,
+			})
+		}
+
+		options = append(options, option)
+	}
+
+	return options, nil
+}
+
+func (s *productServiceImpl) buildVariants(product *model.Product, options []*model.ProductOption, existing []*model.ProductVariant, forms []request.CreateProductVariantForm) ([]*model.ProductVariant, []*model.ProductOptionValue, []*model.Image, []*types.UploadImageMessage, error) {
+	if len(forms) == 0 {
+		return nil, nil, nil, nil, nil
+	}
+	if len(options) == 0 {
+		return nil, nil, nil, nil, customErr.ErrInvalidVariantOptions
+	}
+
+	combinations := make(map[string]bool, len(existing)+len(forms))
+	for _, variant := range existing {
+		combinations[variantCombinationKey(variant.OptionValues)] = true
+	}
+
+	variants := make([]*model.ProductVariant, 0, len(forms))
+	newValues := []*model.ProductOptionValue{}
+	images := []*model.Image{}
+	uploads := []*types.UploadImageMessage{}
+
+	for _, form := range forms {
+		if len(form.OptionValues) != len(options) {
+			return nil, nil, nil, nil, customErr.ErrInvalidVariantOptions
+		}
+
+		variantID, err := s.sfg.NextID()
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		inventoryID, err := s.sfg.NextID()
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		optionValues := make([]*model.ProductOptionValue, 0, len(options))
+		for i, option := range options {
+			value := findOptionValue(option, form.OptionValues[i])
+			if value == nil {
+				valueID, err := s.sfg.NextID()
+				if err != nil {
+					return nil, nil, nil, nil, err
+				}
+
+				value = &model.ProductOptionValue{
+					ID:       valueID,
+					Value:    strings.TrimSpace(form.OptionValues[i]),
+					Position: len(option.Values) + 1,
+					OptionID: option.ID,
+				}
+				option.Values = append(option.Values, value)
+				newValues = append(newValues, value)
+			}
+
+			optionValues = append(optionValues, value)
+		}
+
+		key := variantCombinationKey(optionValues)
+		if combinations[key] {
+			return nil, nil, nil, nil, customErr.ErrDuplicateVariant
+		}
+		combinations[key] = true
+
+		variant := &model.ProductVariant{
+			ID:           variantID,
+			SKU:          strings.TrimSpace(form.SKU),
+			Price:        form.Price,
+			IsActive:     *form.IsActive,
+			ProductID:    product.ID,
+			OptionValues: optionValues,
+			Inventory: &model.Inventory{
+				ID:        inventoryID,
+				Quantity:  form.Quantity,
+				Purchased: 0,
+			},
+		}
+		variant.Inventory.SetStock()
+
+		for _, img := range form.Images {
+			imageID, err := s.sfg.NextID()
+			if err != nil {
+				return nil, nil, nil, nil, err
+			}
+
+			newImg := &model.Image{
+				ID:          imageID,
+				PublicID:    img.Key,
+				IsThumbnail: *img.IsThumbnail,
+				SortOrder:   img.SortOrder,
+				Status:      common.ImageStatusPending,
+				ProductID:   product.ID,
+				VariantID:   &variant.ID,
+			}
+			images = append(images, newImg)
+			if img.AssetID != 0 {
+				assetID := img.AssetID
+				newImg.AssetID = &assetID
+				continue
+			}
+
+			uploads = append(uploads, &types.UploadImageMessage{
+				ImageID: imageID,
+				Key:     img.Key,
+			})
+		}
+
+		variants = append(variants, variant)
+	}
+
+	return variants, newValues, images, uploads, nil
+}
+
+func (s *productServiceImpl) checkVariantSKUsTx(ctx context.Context, tx *gorm.DB, skus []string) error {
+	if len(skus) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(skus))
+	for _, sku := range skus {
+		if seen[sku] {
+			return customErr.ErrVariantSKUAlreadyExists
+		}
+		seen[sku] = true
+	}
+
+	variants, err := s.variantRepo.FindAllBySKUTx(ctx, tx, skus)
+	if err != nil {
+		return fmt.Errorf("kiểm tra SKU của biến thể sản phẩm thất bại: %w", err)
+	}
+	if len(variants) > 0 {
+		return customErr.ErrVariantSKUAlreadyExists
+	}
+
+	return nil
+}
+
+func (s *productServiceImpl) syncProductInventoryTx(ctx context.Context, tx *gorm.DB, product *model.Product) error {
+	quantity, err := s.inventoryRepo.SumVariantQuantityByProductIDTx(ctx, tx, product.ID)
+	if err != nil {
+		return fmt.Errorf("tính tổng số lượng biến thể sản phẩm thất bại: %w", err)
+	}
+
+	if product.Inventory == nil {
+		return nil
+	}
+	if quantity < product.Inventory.Purchased {
+		return customErr.ErrImportQuantityBelowPurchased
+	}
+
+	updateData := map[string]any{
+		"quantity": quantity,
+		"stock":    gorm.Expr("quantity - purchased"),
+		"is_stock": gorm.Expr("CASE WHEN (quantity - purchased) <= 5 THEN false ELSE true END"),
+	}
+	if err = s.inventoryRepo.UpdateTx(ctx, tx, product.Inventory.ID, updateData); err != nil {
+		return fmt.Errorf("cập nhật số lượng sản phẩm thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func findOptionValue(option *model.ProductOption, value string) *model.ProductOptionValue {
+	value = strings.TrimSpace(value)
+	for _, v := range option.Values {
+		if strings.EqualFold(v.Value, value) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func variantCombinationKey(values []*model.ProductOptionValue) string {
+	ids := make([]string, 0, len(values))
+	for _, v := range values {
+		ids = append(ids, fmt.Sprintf("%d:%d", v.OptionID, v.ID))
+	}
+	sort.Strings(ids)
+
+	return strings.Join(ids, "|")
+}
+
+func (s *productServiceImpl) updateVariantsTx(ctx context.Context, tx *gorm.DB, product *model.Product, req *request.UpdateProductForm) ([]*types.UploadImageMessage, error) {
+	if len(req.Options) == 0 && len(req.DeleteVariantIDs) == 0 && len(req.UpdateVariants) == 0 && len(req.NewVariants) == 0 {
+		return nil, nil
+	}
+
+	variantMap := make(map[int64]*model.ProductVariant, len(product.Variants))
+	for _, variant := range product.Variants {
+		variantMap[variant.ID] = variant
+	}
+
+	if len(req.DeleteVariantIDs) > 0 {
+		for _, id := range req.DeleteVariantIDs {
+			if _, ok := variantMap[id]; !ok {
+				return nil, customErr.ErrHasVariantNotFound
+			}
+			delete(variantMap, id)
+		}
+
+		if err := s.variantRepo.DeleteAllByIDTx(ctx, tx, req.DeleteVariantIDs); err != nil {
+			return nil, fmt.Errorf("xóa biến thể sản phẩm thất bại: %w", err)
+		}
+	}
+
+	options := product.Options
+	if len(req.Options) > 0 {
+		if len(variantMap) > 0 {
+			return nil, customErr.ErrProductHasVariants
+		}
+
+		if err := s.variantRepo.DeleteOptionsByProductIDTx(ctx, tx, product.ID); err != nil {
+			return nil, fmt.Errorf("xóa tùy chọn sản phẩm thất bại: %w", err)
+		}
+
+		newOptions, err := s.buildOptions(product.ID, req.Options)
+		if err != nil {
+			return nil, err
+		}
+		if err = s.variantRepo.CreateOptionsTx(ctx, tx, newOptions); err != nil {
+			return nil, fmt.Errorf("tạo tùy chọn sản phẩm thất bại: %w", err)
+		}
+		options = newOptions
+	}
+
+	skus := []string{}
+	for _, form := range req.UpdateVariants {
+		variant, ok := variantMap[form.ID]
+		if !ok {
+			return nil, customErr.ErrHasVariantNotFound
+		}
+
+		updateData := map[string]any{}
+		if form.SKU != nil && strings.TrimSpace(*form.SKU) != variant.SKU {
+			updateData["sku"] = strings.TrimSpace(*form.SKU)
+			skus = append(skus, strings.TrimSpace(*form.SKU))
+		}
+		if form.Price != nil {
+			updateData["price"] = *form.Price
+		}
+		if form.IsActive != nil && *form.IsActive != variant.IsActive {
+			updateData["is_active"] = *form.IsActive
+		}
+
+		if len(updateData) > 0 {
+			if err := s.variantRepo.UpdateTx(ctx, tx, variant.ID, updateData); err != nil {
+				if common.IsUniqueViolation(err) {
+					return nil, customErr.ErrVariantSKUAlreadyExists
+				}
+				return nil, fmt.Errorf("cập nhật biến thể sản phẩm thất bại: %w", err)
+			}
+		}
+
+		if form.Quantity != nil && variant.Inventory != nil && *form.Quantity != variant.Inventory.Quantity {
+			if *form.Quantity < variant.Inventory.Purchased {
+				return nil, customErr.ErrImportQuantityBelowPurchased
+			}
+
+			updateData := map[string]any{
+				"quantity": *form.Quantity,
+				"stock":    gorm.Expr("quantity - purchased"),
+				"is_stock": gorm.Expr("CASE WHEN (quantity - purchased) <= 5 THEN false ELSE true END"),
+			}
+
+			if err := s.inventoryRepo.UpdateTx(ctx, tx, variant.Inventory.ID, updateData); err != nil {
+				return nil, fmt.Errorf("cập nhật số lượng biến thể sản phẩm thất bại: %w", err)
+			}
+		}
+	}
+
+	existing := make([]*model.ProductVariant, 0, len(variantMap))
+	for _, variant := range variantMap {
+		existing = append(existing, variant)
+	}
+
+	variants, newValues, images, uploads, err := s.buildVariants(product, options, existing, req.NewVariants)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, variant := range variants {
+		skus = append(skus, variant.SKU)
+	}
+	if err = s.checkVariantSKUsTx(ctx, tx, skus); err != nil {
+		return nil, err
+	}
+
+	if len(newValues) > 0 {
+		if err = s.variantRepo.CreateOptionValuesTx(ctx, tx, newValues); err != nil {
+			return nil, fmt.Errorf("tạo giá trị tùy chọn sản phẩm thất bại: %w", err)
+		}
+	}
+
+	if len(variants) > 0 {
+		if err = s.variantRepo.CreateAllTx(ctx, tx, variants); err != nil {
+			if common.IsUniqueViolation(err) {
+				return nil, customErr.ErrVariantSKUAlreadyExists
+			}
+			return nil, fmt.Errorf("tạo biến thể sản phẩm thất bại: %w", err)
+		}
+	}
+
+	if len(images) > 0 {
+		if err = s.attachImagesTx(ctx, tx, images); err != nil {
+			return nil, err
+		}
+
+		if err = s.imageRepo.CreateAllTx(ctx, tx, images); err != nil {
+			return nil, fmt.Errorf("tạo hình ảnh biến thể sản phẩm thất bại: %w", err)
+		}
+	}
+
+	if len(variantMap) > 0 || len(variants) > 0 || len(req.DeleteVariantIDs) > 0 {
+		if err = s.syncProductInventoryTx(ctx, tx, product); err != nil {
+			return nil, err
+		}
+	}
+
+	return uploads, nil
+}
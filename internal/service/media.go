@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+type MediaService interface {
+	GetMediaAssets(ctx context.Context, query request.MediaPaginationQuery) ([]*model.MediaAsset, *response.MetaResponse, error)
+
+	GetMediaAssetByID(ctx context.Context, id int64) (*model.MediaAsset, error)
+
+	CreateMediaAsset(ctx context.Context, userID int64, req request.CreateMediaAssetRequest) (*model.MediaAsset, error)
+
+	UpdateMediaAsset(ctx context.Context, id int64, req request.UpdateMediaAssetRequest) (*model.MediaAsset, error)
+
+	DeleteMediaAsset(ctx context.Context, id int64) error
+}
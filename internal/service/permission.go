@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+)
+
+type PermissionService interface {
+	GetAllRolePermissions(ctx context.Context) ([]*model.RolePermission, error)
+
+	GetRolePermissions(ctx context.Context, role string) ([]*model.RolePermission, error)
+
+	GrantPermissions(ctx context.Context, role string, req request.RolePermissionsRequest) ([]*model.RolePermission, error)
+
+	RevokePermissions(ctx context.Context, role string, req request.RolePermissionsRequest) ([]*model.RolePermission, error)
+}
@@ -2,22 +2,50 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"github.com/tienhai2808/ecom_go/internal/request"
 	"github.com/tienhai2808/ecom_go/internal/response"
+	"github.com/tienhai2808/ecom_go/internal/types"
 )
 
 type ProductService interface {
-	GetAllProducts(ctx context.Context, query request.ProductPaginationQuery) ([]*model.Product, *response.MetaResponse, error)
+	GetAllProducts(ctx context.Context, query request.ProductPaginationQuery) ([]*model.Product, *response.MetaResponse, *response.ProductFacetsResponse, error)
 
 	GetProductByID(ctx context.Context, id int64) (*model.Product, error)
 
-	CreateProduct(ctx context.Context, req *request.CreateProductForm) (*model.Product, error)
+	GetProductBySlug(ctx context.Context, slug string) (*model.Product, error)
 
-	UpdateProduct(ctx context.Context, id int64, req *request.UpdateProductForm) (*model.Product, error)
+	CreateProduct(ctx context.Context, actor *types.UserData, req *request.CreateProductForm) (*model.Product, error)
 
-	DeleteProduct(ctx context.Context, id int64) error
+	UpdateProduct(ctx context.Context, actor *types.UserData, id int64, req *request.UpdateProductForm) (*model.Product, error)
 
-	DeleteProducts(ctx context.Context, req request.DeleteManyRequest) (int64, error)
+	DeleteProduct(ctx context.Context, actor *types.UserData, id int64) error
+
+	DeleteProducts(ctx context.Context, actor *types.UserData, req request.DeleteManyRequest) (int64, error)
+
+	BulkUpdateProducts(ctx context.Context, actor *types.UserData, req request.BulkUpdateProductsRequest) (*types.BulkUpdateResult, error)
+
+	GetProductHistory(ctx context.Context, id int64, query request.ProductHistoryPaginationQuery) ([]*model.ProductHistory, *response.MetaResponse, error)
+
+	RevertProduct(ctx context.Context, actor *types.UserData, id int64, version int) (*model.Product, error)
+
+	GetDeletedProducts(ctx context.Context) ([]*model.Product, error)
+
+	RestoreProduct(ctx context.Context, actor *types.UserData, id int64) (*model.Product, error)
+
+	PurgeDeletedProducts(ctx context.Context, before time.Time) (int64, error)
+
+	PublishScheduledProducts(ctx context.Context) (int, error)
+
+	SuggestProducts(ctx context.Context, query request.ProductSuggestQuery) ([]*types.SuggestionDocument, string, error)
+
+	RebuildSuggestions(ctx context.Context) error
+
+	GetFailedImages(ctx context.Context) ([]*model.Image, error)
+
+	RetryFailedImages(ctx context.Context, actor *types.UserData, req request.RetryImagesRequest) (int64, error)
+
+	FailStaleImages(ctx context.Context, before time.Time) (int64, error)
 }
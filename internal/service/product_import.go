@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+type ProductImportService interface {
+	CreateImportJob(ctx context.Context, userID int64, fileName string, content []byte, upsert bool) (*model.ProductImportJob, error)
+
+	GetImportJobs(ctx context.Context, query request.ImportJobPaginationQuery) ([]*model.ProductImportJob, *response.MetaResponse, error)
+
+	GetImportJobByID(ctx context.Context, id int64) (*model.ProductImportJob, error)
+
+	ProcessImportJob(ctx context.Context, id int64) error
+
+	FailImportJob(ctx context.Context, id int64, cause error) error
+}
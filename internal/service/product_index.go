@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type ProductIndexService interface {
+	SyncProducts(ctx context.Context, msg types.ProductIndexMessage) error
+
+	ReindexProducts(ctx context.Context) (int64, error)
+
+	EnsureProductIndex(ctx context.Context) error
+}
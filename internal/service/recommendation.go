@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+)
+
+type RecommendationService interface {
+	GetRelatedProducts(ctx context.Context, productID int64, query request.RelatedProductQuery) ([]*model.Product, []*model.Product, error)
+
+	GetCartSuggestions(ctx context.Context, productIDs []int64, limit int) ([]*model.Product, error)
+
+	RebuildCoPurchases(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/response"
+)
+
+type ReviewService interface {
+	GetProductReviews(ctx context.Context, productID int64, query request.ReviewPaginationQuery) ([]*model.Review, *response.MetaResponse, error)
+
+	GetReviews(ctx context.Context, query request.ReviewPaginationQuery) ([]*model.Review, *response.MetaResponse, error)
+
+	CreateReview(ctx context.Context, userID, productID int64, req *request.CreateReviewForm) (*model.Review, error)
+
+	ModerateReview(ctx context.Context, id int64, req request.ModerateReviewRequest) (*model.Review, error)
+
+	DeleteReview(ctx context.Context, id int64) error
+}
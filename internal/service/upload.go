@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/tienhai2808/ecom_go/internal/model"
+	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/types"
+)
+
+type UploadService interface {
+	CreateUploadSlots(ctx context.Context, userID int64, req request.CreateUploadSlotsRequest) ([]*types.UploadTarget, error)
+
+	UploadContent(ctx context.Context, userID, id int64, body io.Reader, size int64, contentType string) (*model.UploadSlot, error)
+
+	ConfirmUpload(ctx context.Context, userID, id int64) (*model.UploadSlot, error)
+
+	ReapExpiredUploads(ctx context.Context, before time.Time) (int64, error)
+}
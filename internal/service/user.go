@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+
 	"github.com/tienhai2808/ecom_go/internal/model"
 	"github.com/tienhai2808/ecom_go/internal/request"
+	"github.com/tienhai2808/ecom_go/internal/types"
 )
 
 type UserService interface {
@@ -11,11 +13,13 @@ type UserService interface {
 
 	GetUserByID(ctx context.Context, id int64) (*model.User, error)
 
-	CreateUser(ctx context.Context, req request.CreateUserRequest) (*model.User, error)
+	GetUserAuditLogs(ctx context.Context, id int64) ([]*model.AuditLog, error)
+
+	CreateUser(ctx context.Context, currentUser *types.UserData, req request.CreateUserRequest) (*model.User, error)
 
-	UpdateUser(ctx context.Context, id int64, req *request.UpdateUserRequest) (*model.User, error)
+	UpdateUser(ctx context.Context, currentUser *types.UserData, id int64, req *request.UpdateUserRequest) (*model.User, error)
 
-	DeleteUser(ctx context.Context, id int64) error
+	DeleteUser(ctx context.Context, currentUser *types.UserData, id int64) error
 
-	DeleteUsers(ctx context.Context, currentUserID int64, req request.DeleteManyRequest) (int64, error)
+	DeleteUsers(ctx context.Context, currentUser *types.UserData, req request.DeleteManyRequest) (int64, error)
 }
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+type cloudinaryStorageImpl struct {
+	cld *cloudinary.Cloudinary
+}
+
+func NewCloudinaryStorage(cld *cloudinary.Cloudinary) Storage {
+	return &cloudinaryStorageImpl{cld}
+}
+
+func (s *cloudinaryStorageImpl) Upload(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	res, err := s.cld.Upload.Upload(ctx, body, uploader.UploadParams{
+		PublicID:       publicID(key),
+		UniqueFilename: toBoolPnt(false),
+		Overwrite:      toBoolPnt(false),
+	})
+	if err != nil {
+		return fmt.Errorf("đăng tải file lên Cloudinary thất bại: %w", err)
+	}
+	if res.Error.Message != "" {
+		return fmt.Errorf("đăng tải file lên Cloudinary thất bại: %s", res.Error.Message)
+	}
+
+	return nil
+}
+
+func (s *cloudinaryStorageImpl) PresignUpload(ctx context.Context, key, contentType string, expires time.Duration) (string, error) {
+	return "", ErrPresignNotSupported
+}
+
+func (s *cloudinaryStorageImpl) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("tạo yêu cầu tải file từ Cloudinary thất bại: %w", err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("tải file từ Cloudinary thất bại: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("tải file từ Cloudinary thất bại: %s", res.Status)
+	}
+
+	return res.Body, nil
+}
+
+func (s *cloudinaryStorageImpl) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	res, err := s.cld.Admin.Asset(ctx, admin.AssetParams{PublicID: publicID(key)})
+	if err != nil {
+		return nil, fmt.Errorf("kiểm tra file trên Cloudinary thất bại: %w", err)
+	}
+	if res.Error.Message != "" || res.PublicID == "" {
+		return nil, nil
+	}
+
+	return &ObjectInfo{Size: int64(res.Bytes)}, nil
+}
+
+func (s *cloudinaryStorageImpl) URL(key string) string {
+	img, err := s.cld.Image(publicID(key))
+	if err != nil {
+		return ""
+	}
+
+	url, err := img.String()
+	if err != nil {
+		return ""
+	}
+
+	return url
+}
+
+func (s *cloudinaryStorageImpl) Delete(ctx context.Context, key string) error {
+	if _, err := s.cld.Upload.Destroy(ctx, uploader.DestroyParams{
+		PublicID:     publicID(key),
+		ResourceType: "image",
+	}); err != nil {
+		return fmt.Errorf("xóa file trên Cloudinary thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func publicID(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key))
+}
+
+func toBoolPnt(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type localStorageImpl struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStorage(dir, baseURL string) Storage {
+	return &localStorageImpl{dir, strings.TrimRight(baseURL, "/")}
+}
+
+func (s *localStorageImpl) Upload(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path := s.path(key)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("tạo thư mục lưu trữ thất bại: %w", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("tạo file thất bại: %w", err)
+	}
+	defer file.Close()
+
+	if _, err = io.Copy(file, body); err != nil {
+		os.Remove(path)
+		return fmt.Errorf("ghi file thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func (s *localStorageImpl) PresignUpload(ctx context.Context, key, contentType string, expires time.Duration) (string, error) {
+	return "", ErrPresignNotSupported
+}
+
+func (s *localStorageImpl) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(key))
+	if err != nil {
+		return nil, fmt.Errorf("mở file thất bại: %w", err)
+	}
+
+	return file, nil
+}
+
+func (s *localStorageImpl) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	info, err := os.Stat(s.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("kiểm tra file thất bại: %w", err)
+	}
+
+	return &ObjectInfo{Size: info.Size()}, nil
+}
+
+func (s *localStorageImpl) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+func (s *localStorageImpl) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("xóa file thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func (s *localStorageImpl) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(filepath.Clean("/"+key)))
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+type s3StorageImpl struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+}
+
+func NewS3Storage(client *minio.Client, bucket, publicURL string) Storage {
+	if publicURL == "" {
+		publicURL = client.EndpointURL().String() + "/" + bucket
+	}
+
+	return &s3StorageImpl{client, bucket, strings.TrimRight(publicURL, "/")}
+}
+
+func (s *s3StorageImpl) Upload(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if _, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	}); err != nil {
+		return fmt.Errorf("đăng tải file lên S3 thất bại: %w", err)
+	}
+
+	return nil
+}
+
+func (s *s3StorageImpl) PresignUpload(ctx context.Context, key, contentType string, expires time.Duration) (string, error) {
+	url, err := s.client.PresignedPutObject(ctx, s.bucket, key, expires)
+	if err != nil {
+		return "", fmt.Errorf("tạo URL upload S3 thất bại: %w", err)
+	}
+
+	return url.String(), nil
+}
+
+func (s *s3StorageImpl) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("đọc file trên S3 thất bại: %w", err)
+	}
+
+	return object, nil
+}
+
+func (s *s3StorageImpl) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	info, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("kiểm tra file trên S3 thất bại: %w", err)
+	}
+
+	return &ObjectInfo{Size: info.Size}, nil
+}
+
+func (s *s3StorageImpl) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+func (s *s3StorageImpl) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("xóa file trên S3 thất bại: %w", err)
+	}
+
+	return nil
+}
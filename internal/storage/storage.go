@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var ErrPresignNotSupported = errors.New("backend lưu trữ không hỗ trợ URL ký sẵn")
+
+type ObjectInfo struct {
+	Size int64
+}
+
+type Storage interface {
+	Upload(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+
+	PresignUpload(ctx context.Context, key, contentType string, expires time.Duration) (string, error)
+
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+
+	URL(key string) string
+
+	Delete(ctx context.Context, key string) error
+}
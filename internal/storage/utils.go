@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+func NewObjectKey(folder, name, contentType string) string {
+	ext := ""
+	if exts, err := mime.ExtensionsByType(contentType); err == nil && len(exts) > 0 {
+		ext = exts[0]
+	}
+
+	return path.Join(folder, fmt.Sprintf("%s_%s%s", name, uuid.NewString()[:8], ext))
+}
+
+func VariantKey(key, name string) string {
+	return fmt.Sprintf("%s_%s.webp", strings.TrimSuffix(key, path.Ext(key)), name)
+}
@@ -14,6 +14,14 @@ type ForgotPasswordData struct {
 	Attempts int    `json:"attempts"`
 }
 
+type ChangeEmailData struct {
+	UserID   int64  `json:"user_id"`
+	OldEmail string `json:"old_email"`
+	NewEmail string `json:"new_email"`
+	Otp      string `json:"otp"`
+	Attempts int    `json:"attempts"`
+}
+
 type SendEmailMessage struct {
 	To      string `json:"to"`
 	Subject string `json:"subject"`
@@ -11,4 +11,5 @@ type CartItemData struct {
 	Quantity   uint    `json:"quantity"`
 	TotalPrice float64 `json:"total_price"`
 	ProductID  int64   `json:"product_id"`
+	VariantID  *int64  `json:"variant_id"`
 }
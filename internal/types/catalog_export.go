@@ -0,0 +1,45 @@
+package types
+
+import "encoding/xml"
+
+type ExportProductRow struct {
+	ID           int64    `json:"id"`
+	Name         string   `json:"name"`
+	Slug         string   `json:"slug"`
+	CategorySlug string   `json:"category_slug"`
+	CategoryName string   `json:"category_name"`
+	Price        float64  `json:"price"`
+	Quantity     uint     `json:"quantity"`
+	Purchased    uint     `json:"purchased"`
+	Stock        uint     `json:"stock"`
+	IsActive     bool     `json:"is_active"`
+	Description  string   `json:"description"`
+	ImageURLs    []string `json:"image_urls"`
+}
+
+type GoogleMerchantItem struct {
+	XMLName              xml.Name `xml:"item"`
+	ID                   string   `xml:"g:id"`
+	Title                string   `xml:"g:title"`
+	Description          string   `xml:"g:description"`
+	Link                 string   `xml:"g:link"`
+	ImageLink            string   `xml:"g:image_link,omitempty"`
+	AdditionalImageLinks []string `xml:"g:additional_image_link"`
+	Availability         string   `xml:"g:availability"`
+	Price                string   `xml:"g:price"`
+	ProductType          string   `xml:"g:product_type,omitempty"`
+	Condition            string   `xml:"g:condition"`
+	IdentifierExists     string   `xml:"g:identifier_exists"`
+}
+
+type SitemapURL struct {
+	XMLName xml.Name `xml:"url"`
+	Loc     string   `xml:"loc"`
+	LastMod string   `xml:"lastmod,omitempty"`
+}
+
+type SitemapIndexEntry struct {
+	XMLName xml.Name `xml:"sitemap"`
+	Loc     string   `xml:"loc"`
+	LastMod string   `xml:"lastmod,omitempty"`
+}
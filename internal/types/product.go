@@ -1,9 +1,10 @@
 package types
 
+import "time"
+
 type UploadImageMessage struct {
-	ImageID  int64  `json:"image_id"`
-	FileName string `json:"file_name"`
-	FileData []byte `json:"file_data"`
+	ImageID int64  `json:"image_id"`
+	Key     string `json:"key"`
 }
 
 type ProductSearchResult struct {
@@ -14,4 +15,62 @@ type ProductSearchResult struct {
 	TotalPages int64   `json:"total_pages"`
 	HasPrev    bool    `json:"has_prev"`
 	HasNext    bool    `json:"has_next"`
+
+	CategoryCounts []*CategoryCount `json:"category_counts"`
+	PriceBuckets   []*PriceBucket   `json:"price_buckets"`
+	PriceMin       *float64         `json:"price_min"`
+	PriceMax       *float64         `json:"price_max"`
+
+	AttributeCounts []*AttributeValueCount `json:"attribute_counts"`
+}
+
+type CategoryCount struct {
+	CategoryID int64 `json:"category_id"`
+	Count      int64 `json:"count"`
+}
+
+type PriceBucket struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int64   `json:"count"`
+}
+
+type AttributeValueCount struct {
+	AttributeID int64  `json:"attribute_id"`
+	Value       string `json:"value"`
+	Count       int64  `json:"count"`
+}
+
+type SuggestionDocument struct {
+	ID       int64  `json:"id"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	IsActive bool   `json:"is_active"`
+}
+
+type ProductDocument struct {
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	Slug          string     `json:"slug"`
+	Description   string     `json:"description"`
+	Price         float64    `json:"price"`
+	IsActive      bool       `json:"is_active"`
+	Status        string     `json:"status"`
+	PublishAt     *time.Time `json:"publish_at"`
+	UnpublishAt   *time.Time `json:"unpublish_at"`
+	CategoryID    int64      `json:"category_id"`
+	CategoryName  string     `json:"category_name"`
+	Attributes    []string   `json:"attributes"`
+	Thumbnail     string     `json:"thumbnail"`
+	Stock         uint       `json:"stock"`
+	RatingAverage float64    `json:"rating_average"`
+	RatingCount   uint       `json:"rating_count"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type ProductIndexMessage struct {
+	ProductIDs  []int64 `json:"product_ids"`
+	CategoryIDs []int64 `json:"category_ids"`
 }
@@ -0,0 +1,21 @@
+package types
+
+type BulkFieldChange struct {
+	Field string
+	From  any
+	To    any
+}
+
+type BulkProductChange struct {
+	ProductID int64
+	Name      string
+	Changes   []*BulkFieldChange
+	Errors    []string
+}
+
+type BulkUpdateResult struct {
+	DryRun   bool
+	Matched  int
+	Changed  int
+	Products []*BulkProductChange
+}
@@ -0,0 +1,13 @@
+package types
+
+import "github.com/tienhai2808/ecom_go/internal/request"
+
+type ImportRow struct {
+	Line   int
+	Data   request.ImportProductRow
+	Errors []string
+}
+
+type ProductImportMessage struct {
+	JobID int64 `json:"job_id"`
+}
@@ -0,0 +1,10 @@
+package types
+
+import "github.com/tienhai2808/ecom_go/internal/model"
+
+type UploadTarget struct {
+	Slot    *model.UploadSlot
+	Method  string
+	URL     string
+	Headers map[string]string
+}
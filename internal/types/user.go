@@ -3,12 +3,13 @@ package types
 import "time"
 
 type UserData struct {
-	ID        int64       `json:"id"`
-	Username  string      `json:"username"`
-	Email     string      `json:"email"`
-	Role      string      `json:"role"`
-	CreatedAt time.Time   `json:"created_at"`
-	Profile   ProfileData `json:"profile"`
+	ID          int64       `json:"id"`
+	Username    string      `json:"username"`
+	Email       string      `json:"email"`
+	Role        string      `json:"role"`
+	Permissions []string    `json:"permissions"`
+	CreatedAt   time.Time   `json:"created_at"`
+	Profile     ProfileData `json:"profile"`
 }
 
 type ProfileData struct {